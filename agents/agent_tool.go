@@ -0,0 +1,113 @@
+package agents
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/devmiahub/langchaingo/callbacks"
+	"github.com/devmiahub/langchaingo/chains"
+	"github.com/devmiahub/langchaingo/schema"
+	"github.com/devmiahub/langchaingo/tools"
+)
+
+// AgentTool is a tool delegating its input to an agent executor, so that an
+// agent can use another agent as a tool.
+type AgentTool struct {
+	// ToolName is the name of the tool.
+	ToolName string
+	// ToolDescription describes what the agent can do.
+	ToolDescription string
+	// Executor runs the agent. Its first input key gets the input of the tool
+	// and its first output key is returned.
+	Executor *Executor
+	// Memory is the memory of the agent. If nil the memory of the executor is
+	// used. Agent tools sharing a memory see each other's conversations.
+	Memory schema.Memory
+	// CallbacksHandler is the handler for callbacks.
+	CallbacksHandler callbacks.Handler
+}
+
+var _ tools.Tool = &AgentTool{}
+
+// NewAgentTool creates a new tool delegating to the executor.
+func NewAgentTool(name, description string, executor *Executor) *AgentTool {
+	return &AgentTool{
+		ToolName:        name,
+		ToolDescription: description,
+		Executor:        executor,
+	}
+}
+
+// Name returns the name of the tool.
+func (t *AgentTool) Name() string {
+	return t.ToolName
+}
+
+// Description returns the description of the tool.
+func (t *AgentTool) Description() string {
+	return t.ToolDescription
+}
+
+// Call runs the agent with the input and returns its output. The name of the
+// tool is added to the agent path of the context, so callbacks of the nested
+// run can tell where they are called from with AgentPath.
+func (t *AgentTool) Call(ctx context.Context, input string) (string, error) {
+	if t.CallbacksHandler != nil {
+		t.CallbacksHandler.HandleToolStart(ctx, input)
+	}
+
+	output, err := t.call(ctx, input)
+	if err != nil {
+		if t.CallbacksHandler != nil {
+			t.CallbacksHandler.HandleToolError(ctx, err)
+		}
+		return "", err
+	}
+
+	if t.CallbacksHandler != nil {
+		t.CallbacksHandler.HandleToolEnd(ctx, output)
+	}
+	return output, nil
+}
+
+func (t *AgentTool) call(ctx context.Context, input string) (string, error) {
+	executor := *t.Executor
+	if t.Memory != nil {
+		executor.Memory = t.Memory
+	}
+
+	inputKeys := executor.GetInputKeys()
+	outputKeys := executor.GetOutputKeys()
+	if len(inputKeys) == 0 || len(outputKeys) == 0 {
+		return "", fmt.Errorf("%w: agent %s has no input or output key", ErrInvalidOptions, t.ToolName)
+	}
+
+	// The nested run is checkpointed and streamed on its own.
+	ctx = ContextWithRunID(ctx, "")
+	ctx = context.WithValue(ctx, eventsContextKey{}, nil)
+	ctx = contextWithAgent(ctx, t.ToolName)
+
+	outputs, err := chains.Call(ctx, &executor, map[string]any{inputKeys[0]: input})
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprint(outputs[outputKeys[0]]), nil
+}
+
+type agentPathContextKey struct{}
+
+// AgentPath returns the names of the agent tools the context was passed
+// through, outermost first. Callback handlers can use it to show the
+// hierarchy of nested agent runs.
+func AgentPath(ctx context.Context) []string {
+	path, _ := ctx.Value(agentPathContextKey{}).([]string)
+	return path
+}
+
+func contextWithAgent(ctx context.Context, name string) context.Context {
+	parent := AgentPath(ctx)
+	path := make([]string, len(parent), len(parent)+1)
+	copy(path, parent)
+	return context.WithValue(ctx, agentPathContextKey{}, append(path, name))
+}
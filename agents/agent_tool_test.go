@@ -0,0 +1,68 @@
+package agents_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/devmiahub/langchaingo/agents"
+	"github.com/devmiahub/langchaingo/callbacks"
+	"github.com/devmiahub/langchaingo/chains"
+	"github.com/devmiahub/langchaingo/llms/fake"
+	"github.com/devmiahub/langchaingo/memory"
+	"github.com/devmiahub/langchaingo/schema"
+	"github.com/devmiahub/langchaingo/tools"
+	"github.com/stretchr/testify/require"
+)
+
+// pathRecorder records the agent path of every agent action.
+type pathRecorder struct {
+	callbacks.SimpleHandler
+	paths [][]string
+}
+
+func (h *pathRecorder) HandleAgentAction(ctx context.Context, _ schema.AgentAction) {
+	h.paths = append(h.paths, agents.AgentPath(ctx))
+}
+
+func TestSupervisorAgent(t *testing.T) {
+	t.Parallel()
+
+	recorder := &pathRecorder{}
+	researcher := agents.NewAgentTool(
+		"researcher",
+		"looks up facts",
+		agents.NewExecutor(&scriptedAgent{
+			actions: []schema.AgentAction{{Tool: "echo", ToolInput: "Paris is the capital of France"}},
+			tools:   []tools.Tool{&flakyTool{}},
+		}, agents.WithCallbacksHandler(recorder)),
+	)
+	shared := memory.NewConversationBuffer()
+	researcher.Memory = shared
+
+	llm := fake.NewFakeLLM([]string{
+		`{"action": "researcher", "action_input": "What is the capital of France?"}`,
+		`{"action": "Final Answer", "action_input": "The capital of France is Paris."}`,
+	})
+	supervisor := agents.NewExecutor(
+		agents.NewSupervisorAgent(llm, []*agents.AgentTool{researcher}),
+		agents.WithCallbacksHandler(recorder),
+		agents.WithReturnIntermediateSteps(),
+	)
+
+	outputs, err := chains.Call(context.Background(), supervisor, map[string]any{
+		"input": "What is the capital of France?",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "The capital of France is Paris.", outputs["output"])
+
+	steps, ok := outputs["intermediateSteps"].([]schema.AgentStep)
+	require.True(t, ok)
+	require.Len(t, steps, 1)
+	require.Equal(t, "Paris is the capital of France", steps[0].Observation)
+
+	require.Equal(t, [][]string{nil, {"researcher"}}, recorder.paths)
+
+	messages, err := shared.ChatHistory.Messages(context.Background())
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+}
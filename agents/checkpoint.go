@@ -0,0 +1,151 @@
+package agents
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/devmiahub/langchaingo/internal/checkpointstore"
+	"github.com/devmiahub/langchaingo/schema"
+)
+
+// CheckpointStatus is the state a checkpointed run is in.
+type CheckpointStatus string
+
+const (
+	// CheckpointRunning is the status of a run that can be resumed directly.
+	CheckpointRunning CheckpointStatus = "running"
+	// CheckpointPaused is the status of a run waiting for a human to approve or
+	// reject its pending actions.
+	CheckpointPaused CheckpointStatus = "paused"
+	// CheckpointApproved is the status of a paused run whose pending actions
+	// have been approved and will be executed when the run is resumed.
+	CheckpointApproved CheckpointStatus = "approved"
+)
+
+// Checkpoint is the state of an agent run persisted by the executor after each
+// step.
+type Checkpoint struct {
+	// RunID identifies the run.
+	RunID string `json:"run_id"`
+	// Inputs are the inputs the run was started with.
+	Inputs map[string]string `json:"inputs"`
+	// Steps are the intermediate steps taken so far.
+	Steps []schema.AgentStep `json:"steps"`
+	// Iteration is the number of completed iterations.
+	Iteration int `json:"iteration"`
+	// Status is the state of the run.
+	Status CheckpointStatus `json:"status"`
+	// PendingActions are the actions waiting for approval when the run is paused.
+	PendingActions []schema.AgentAction `json:"pending_actions,omitempty"`
+	// UpdatedAt is the time the checkpoint was saved.
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Checkpointer is the interface for persisting the state of agent runs so
+// that they can be resumed.
+type Checkpointer interface {
+	// Save stores the checkpoint, replacing any previous checkpoint of the run.
+	Save(ctx context.Context, checkpoint Checkpoint) error
+	// Load returns the last checkpoint of a run. If the run has no checkpoint
+	// ErrCheckpointNotFound is returned.
+	Load(ctx context.Context, runID string) (*Checkpoint, error)
+	// Delete removes the checkpoint of a run.
+	Delete(ctx context.Context, runID string) error
+}
+
+type runIDContextKey struct{}
+
+// ContextWithRunID returns a copy of ctx carrying the id used to checkpoint
+// the executor run started with it. Runs started without an id get a
+// generated one.
+func ContextWithRunID(ctx context.Context, runID string) context.Context {
+	return context.WithValue(ctx, runIDContextKey{}, runID)
+}
+
+// RunIDFromContext returns the run id stored in ctx, if any.
+func RunIDFromContext(ctx context.Context) (string, bool) {
+	runID, ok := ctx.Value(runIDContextKey{}).(string)
+	return runID, ok && runID != ""
+}
+
+// MemoryCheckpointer is a checkpointer that keeps checkpoints in memory. It is
+// useful for pausing runs for approval within a single process.
+type MemoryCheckpointer struct {
+	store *checkpointstore.Memory[Checkpoint]
+}
+
+var _ Checkpointer = &MemoryCheckpointer{}
+
+// NewMemoryCheckpointer creates a new in-memory checkpointer.
+func NewMemoryCheckpointer() *MemoryCheckpointer {
+	return &MemoryCheckpointer{
+		store: checkpointstore.NewMemory(copyCheckpoint, ErrCheckpointNotFound),
+	}
+}
+
+// Save stores the checkpoint.
+func (c *MemoryCheckpointer) Save(_ context.Context, checkpoint Checkpoint) error {
+	c.store.Save(checkpoint.RunID, checkpoint)
+	return nil
+}
+
+// Load returns the last checkpoint of a run.
+func (c *MemoryCheckpointer) Load(_ context.Context, runID string) (*Checkpoint, error) {
+	return c.store.Load(runID)
+}
+
+// Delete removes the checkpoint of a run.
+func (c *MemoryCheckpointer) Delete(_ context.Context, runID string) error {
+	c.store.Delete(runID)
+	return nil
+}
+
+func copyCheckpoint(checkpoint Checkpoint) Checkpoint {
+	inputs := make(map[string]string, len(checkpoint.Inputs))
+	for key, value := range checkpoint.Inputs {
+		inputs[key] = value
+	}
+	checkpoint.Inputs = inputs
+	checkpoint.Steps = append([]schema.AgentStep(nil), checkpoint.Steps...)
+	checkpoint.PendingActions = append([]schema.AgentAction(nil), checkpoint.PendingActions...)
+	return checkpoint
+}
+
+// FileCheckpointer is a checkpointer that stores each run as a JSON file in a
+// directory.
+type FileCheckpointer struct {
+	// Dir is the directory the checkpoint files are written to.
+	Dir string
+}
+
+var _ Checkpointer = FileCheckpointer{}
+
+// NewFileCheckpointer creates a new checkpointer storing checkpoints in dir.
+// The directory is created if it does not exist.
+func NewFileCheckpointer(dir string) (FileCheckpointer, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return FileCheckpointer{}, err
+	}
+	return FileCheckpointer{Dir: dir}, nil
+}
+
+// Save writes the checkpoint to the file of the run. The file is replaced
+// atomically so a crash never leaves a partially written checkpoint behind.
+func (c FileCheckpointer) Save(_ context.Context, checkpoint Checkpoint) error {
+	return c.store().Save(checkpoint.RunID, checkpoint)
+}
+
+// Load reads the checkpoint of a run.
+func (c FileCheckpointer) Load(_ context.Context, runID string) (*Checkpoint, error) {
+	return c.store().Load(runID)
+}
+
+// Delete removes the checkpoint file of a run.
+func (c FileCheckpointer) Delete(_ context.Context, runID string) error {
+	return c.store().Delete(runID)
+}
+
+func (c FileCheckpointer) store() checkpointstore.File[Checkpoint] {
+	return checkpointstore.NewFile[Checkpoint](c.Dir, ErrCheckpointNotFound, ErrInvalidRunID)
+}
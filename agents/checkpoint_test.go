@@ -0,0 +1,213 @@
+package agents_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/devmiahub/langchaingo/agents"
+	"github.com/devmiahub/langchaingo/chains"
+	"github.com/devmiahub/langchaingo/schema"
+	"github.com/devmiahub/langchaingo/tools"
+	"github.com/stretchr/testify/require"
+)
+
+// scriptedAgent plans one action per step until it runs out of actions and
+// finishes with the last observation.
+type scriptedAgent struct {
+	actions []schema.AgentAction
+	tools   []tools.Tool
+}
+
+func (a *scriptedAgent) Plan(
+	_ context.Context,
+	intermediateSteps []schema.AgentStep,
+	_ map[string]string,
+	_ ...chains.ChainCallOption,
+) ([]schema.AgentAction, *schema.AgentFinish, error) {
+	if len(intermediateSteps) < len(a.actions) {
+		return []schema.AgentAction{a.actions[len(intermediateSteps)]}, nil, nil
+	}
+	return nil, &schema.AgentFinish{
+		ReturnValues: map[string]any{"output": intermediateSteps[len(intermediateSteps)-1].Observation},
+	}, nil
+}
+
+func (a *scriptedAgent) GetInputKeys() []string  { return []string{"input"} }
+func (a *scriptedAgent) GetOutputKeys() []string { return []string{"output"} }
+func (a *scriptedAgent) GetTools() []tools.Tool  { return a.tools }
+
+// flakyTool echoes its input, failing the first failures calls with failOn.
+type flakyTool struct {
+	failOn   string
+	failures int
+	calls    int
+}
+
+func (t *flakyTool) Name() string        { return "echo" }
+func (t *flakyTool) Description() string { return "echoes its input" }
+
+func (t *flakyTool) Call(_ context.Context, input string) (string, error) {
+	t.calls++
+	if input == t.failOn && t.failures > 0 {
+		t.failures--
+		return "", errors.New("deploy in progress")
+	}
+	return input, nil
+}
+
+func TestExecutorResumesFromCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	checkpointer, err := agents.NewFileCheckpointer(t.TempDir())
+	require.NoError(t, err)
+
+	tool := &flakyTool{}
+	agent := &scriptedAgent{
+		actions: []schema.AgentAction{
+			{Tool: "echo", ToolInput: "first"},
+			{Tool: "echo", ToolInput: "second"},
+		},
+		tools: []tools.Tool{tool},
+	}
+	executor := agents.NewExecutor(agent, agents.WithCheckpointer(checkpointer))
+	ctx := context.Background()
+
+	// Simulate a run that died after its first step.
+	checkpoint := agents.Checkpoint{
+		RunID:  "run-2",
+		Inputs: map[string]string{"input": "go"},
+		Steps: []schema.AgentStep{
+			{Action: agent.actions[0], Observation: "first"},
+		},
+		Iteration: 1,
+		Status:    agents.CheckpointRunning,
+	}
+	require.NoError(t, checkpointer.Save(ctx, checkpoint))
+
+	outputs, err := executor.Resume(ctx, "run-2")
+	require.NoError(t, err)
+	require.Equal(t, "second", outputs["output"])
+	require.Equal(t, 1, tool.calls)
+
+	_, err = checkpointer.Load(ctx, "run-2")
+	require.ErrorIs(t, err, agents.ErrCheckpointNotFound)
+}
+
+func TestExecutorCheckpointsFailedRun(t *testing.T) {
+	t.Parallel()
+
+	checkpointer := agents.NewMemoryCheckpointer()
+	tool := &flakyTool{}
+	agent := &scriptedAgent{
+		actions: []schema.AgentAction{
+			{Tool: "echo", ToolInput: "first"},
+			{Tool: "echo", ToolInput: "second"},
+		},
+		tools: []tools.Tool{tool},
+	}
+	executor := agents.NewExecutor(agent, agents.WithCheckpointer(checkpointer))
+	ctx := agents.ContextWithRunID(context.Background(), "run")
+
+	tool.failOn = "second"
+	tool.failures = 1
+	_, err := chains.Call(ctx, executor, map[string]any{"input": "go"})
+	require.Error(t, err)
+
+	checkpoint, err := checkpointer.Load(ctx, "run")
+	require.NoError(t, err)
+	require.Equal(t, 1, checkpoint.Iteration)
+	require.Len(t, checkpoint.Steps, 1)
+	require.Equal(t, map[string]string{"input": "go"}, checkpoint.Inputs)
+
+	outputs, err := executor.Resume(ctx, "run")
+	require.NoError(t, err)
+	require.Equal(t, "second", outputs["output"])
+	require.Equal(t, 3, tool.calls)
+}
+
+func TestExecutorPausesForApproval(t *testing.T) {
+	t.Parallel()
+
+	checkpointer := agents.NewMemoryCheckpointer()
+	tool := &flakyTool{}
+	agent := &scriptedAgent{
+		actions: []schema.AgentAction{
+			{Tool: "echo", ToolInput: "safe"},
+			{Tool: "echo", ToolInput: "dangerous"},
+		},
+		tools: []tools.Tool{tool},
+	}
+	executor := agents.NewExecutor(
+		agent,
+		agents.WithCheckpointer(checkpointer),
+		agents.WithApproval(func(action schema.AgentAction) bool {
+			return action.ToolInput == "dangerous"
+		}),
+	)
+
+	_, err := chains.Call(context.Background(), executor, map[string]any{"input": "go"})
+	require.ErrorIs(t, err, agents.ErrRunPaused)
+	require.Equal(t, 1, tool.calls)
+
+	var paused *agents.PausedError
+	require.ErrorAs(t, err, &paused)
+	require.NotEmpty(t, paused.RunID)
+	require.Equal(t, agent.actions[1:], paused.Actions)
+
+	ctx := context.Background()
+	_, err = executor.Resume(ctx, paused.RunID)
+	require.ErrorIs(t, err, agents.ErrRunPaused)
+
+	require.NoError(t, executor.Approve(ctx, paused.RunID))
+	require.ErrorIs(t, executor.Approve(ctx, paused.RunID), agents.ErrRunNotPaused)
+
+	outputs, err := executor.Resume(ctx, paused.RunID)
+	require.NoError(t, err)
+	require.Equal(t, "dangerous", outputs["output"])
+	require.Equal(t, 2, tool.calls)
+}
+
+func TestExecutorRejectedActions(t *testing.T) {
+	t.Parallel()
+
+	checkpointer := agents.NewMemoryCheckpointer()
+	tool := &flakyTool{}
+	agent := &scriptedAgent{
+		actions: []schema.AgentAction{{Tool: "echo", ToolInput: "dangerous"}},
+		tools:   []tools.Tool{tool},
+	}
+	executor := agents.NewExecutor(
+		agent,
+		agents.WithCheckpointer(checkpointer),
+		agents.WithApproval(func(schema.AgentAction) bool { return true }),
+	)
+	ctx := agents.ContextWithRunID(context.Background(), "run")
+
+	_, err := chains.Call(ctx, executor, map[string]any{"input": "go"})
+	require.ErrorIs(t, err, agents.ErrRunPaused)
+
+	require.NoError(t, executor.Reject(ctx, "run", "not allowed"))
+	outputs, err := executor.Resume(ctx, "run")
+	require.NoError(t, err)
+	require.Equal(t, "not allowed", outputs["output"])
+	require.Equal(t, 0, tool.calls)
+}
+
+func TestExecutorResumeWithoutCheckpointer(t *testing.T) {
+	t.Parallel()
+
+	executor := agents.NewExecutor(&scriptedAgent{})
+	_, err := executor.Resume(context.Background(), "run")
+	require.ErrorIs(t, err, agents.ErrNoCheckpointer)
+}
+
+func TestFileCheckpointerRejectsPaths(t *testing.T) {
+	t.Parallel()
+
+	checkpointer, err := agents.NewFileCheckpointer(t.TempDir())
+	require.NoError(t, err)
+
+	err = checkpointer.Save(context.Background(), agents.Checkpoint{RunID: "../escape"})
+	require.ErrorIs(t, err, agents.ErrInvalidRunID)
+}
@@ -19,4 +19,8 @@
 // calling the tool that the action references with the corresponding input,
 // getting the output of the tool, and then passing all that information back
 // into the Agent to get the next action it should take.
+//
+// An Executor with a Checkpointer persists the intermediate steps of a run
+// after each step. Runs interrupted by a crash or paused for human approval of
+// an action can then be continued with Executor.Resume.
 package agents
@@ -1,6 +1,11 @@
 package agents
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+
+	"github.com/devmiahub/langchaingo/schema"
+)
 
 var (
 	// ErrExecutorInputNotString is returned if an input to the executor call function is not a string.
@@ -20,8 +25,37 @@ var (
 	// ErrInvalidChainReturnType is returned if the internal chain of the agent returns a value in the
 	// "text" filed that is not a string.
 	ErrInvalidChainReturnType = errors.New("agent chain did not return a string")
+
+	// ErrNoCheckpointer is returned when resuming a run on an executor without a checkpointer.
+	ErrNoCheckpointer = errors.New("executor has no checkpointer")
+	// ErrCheckpointNotFound is returned by checkpointers if a run has no checkpoint.
+	ErrCheckpointNotFound = errors.New("checkpoint not found")
+	// ErrInvalidRunID is returned if a run id cannot be used by a checkpointer.
+	ErrInvalidRunID = errors.New("invalid run id")
+	// ErrRunPaused is returned if a run is paused waiting for its actions to be approved.
+	ErrRunPaused = errors.New("agent run paused for approval")
+	// ErrRunNotPaused is returned when approving or rejecting a run that is not paused.
+	ErrRunNotPaused = errors.New("agent run not paused")
 )
 
+// PausedError is the error returned when a run is paused waiting for a human to
+// approve its pending actions. It matches ErrRunPaused with errors.Is.
+type PausedError struct {
+	// RunID is the id to approve, reject and resume the run with.
+	RunID string
+	// Actions are the actions waiting for approval.
+	Actions []schema.AgentAction
+}
+
+func (e *PausedError) Error() string {
+	return fmt.Sprintf("%s: run %s", ErrRunPaused, e.RunID)
+}
+
+// Is reports whether target is ErrRunPaused.
+func (e *PausedError) Is(target error) bool {
+	return target == ErrRunPaused //nolint:errorlint
+}
+
 // ParserErrorHandler is the struct used to handle parse errors from the agent in the executor. If
 // an executor have a ParserErrorHandler, parsing errors will be formatted using the formatter
 // function and added as an observation. In the next executor step the agent will then have the
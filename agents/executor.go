@@ -5,11 +5,13 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/devmiahub/langchaingo/callbacks"
 	"github.com/devmiahub/langchaingo/chains"
 	"github.com/devmiahub/langchaingo/schema"
 	"github.com/devmiahub/langchaingo/tools"
+	"github.com/google/uuid"
 )
 
 const _intermediateStepsOutputKey = "intermediateSteps"
@@ -21,6 +23,14 @@ type Executor struct {
 	CallbacksHandler callbacks.Handler
 	ErrorHandler     *ParserErrorHandler
 
+	// Checkpointer persists the state of runs after each step so that they can
+	// be resumed with Resume. If nil, runs are not checkpointed.
+	Checkpointer Checkpointer
+	// RequiresApproval reports whether an action must be approved by a human
+	// before it is executed. Runs planning such an action are paused and must
+	// be approved or rejected and then resumed. Requires a Checkpointer.
+	RequiresApproval func(action schema.AgentAction) bool
+
 	MaxIterations           int
 	ReturnIntermediateSteps bool
 }
@@ -44,23 +54,137 @@ func NewExecutor(agent Agent, opts ...Option) *Executor {
 		ReturnIntermediateSteps: options.returnIntermediateSteps,
 		CallbacksHandler:        options.callbacksHandler,
 		ErrorHandler:            options.errorHandler,
+		Checkpointer:            options.checkpointer,
+		RequiresApproval:        options.requiresApproval,
 	}
 }
 
+// Call runs the agent until it finishes. If the executor has a checkpointer
+// the run is checkpointed under the id set with ContextWithRunID, or a
+// generated id if none is set.
 func (e *Executor) Call(ctx context.Context, inputValues map[string]any, options ...chains.ChainCallOption) (map[string]any, error) { //nolint:lll
 	inputs, err := inputsToString(inputValues)
 	if err != nil {
 		return nil, err
 	}
+
+	runID, ok := RunIDFromContext(ctx)
+	if !ok {
+		runID = uuid.NewString()
+	}
+
+	return e.run(ctx, &Checkpoint{
+		RunID:  runID,
+		Inputs: inputs,
+		Steps:  make([]schema.AgentStep, 0),
+		Status: CheckpointRunning,
+	}, options...)
+}
+
+// Resume continues a checkpointed run from its last checkpoint. Paused runs
+// must be approved or rejected before they can be resumed. The inputs of the
+// run are restored from the checkpoint, so memory is neither loaded nor saved.
+func (e *Executor) Resume(ctx context.Context, runID string, options ...chains.ChainCallOption) (map[string]any, error) { //nolint:lll
+	checkpoint, err := e.loadCheckpoint(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	if checkpoint.Status == CheckpointPaused {
+		return nil, &PausedError{RunID: runID, Actions: checkpoint.PendingActions}
+	}
+
+	return e.run(ctx, checkpoint, options...)
+}
+
+// Approve approves the pending actions of a paused run. The actions are
+// executed when the run is resumed.
+func (e *Executor) Approve(ctx context.Context, runID string) error {
+	checkpoint, err := e.loadCheckpoint(ctx, runID)
+	if err != nil {
+		return err
+	}
+	if checkpoint.Status != CheckpointPaused {
+		return fmt.Errorf("%w: %s", ErrRunNotPaused, runID)
+	}
+
+	checkpoint.Status = CheckpointApproved
+	return e.saveCheckpoint(ctx, checkpoint)
+}
+
+// Reject rejects the pending actions of a paused run. Each action is recorded
+// as a step with the reason as observation, so the agent can plan around it
+// when the run is resumed.
+func (e *Executor) Reject(ctx context.Context, runID string, reason string) error {
+	checkpoint, err := e.loadCheckpoint(ctx, runID)
+	if err != nil {
+		return err
+	}
+	if checkpoint.Status != CheckpointPaused {
+		return fmt.Errorf("%w: %s", ErrRunNotPaused, runID)
+	}
+
+	for _, action := range checkpoint.PendingActions {
+		checkpoint.Steps = append(checkpoint.Steps, schema.AgentStep{
+			Action:      action,
+			Observation: reason,
+		})
+	}
+	checkpoint.PendingActions = nil
+	checkpoint.Status = CheckpointRunning
+	checkpoint.Iteration++
+	return e.saveCheckpoint(ctx, checkpoint)
+}
+
+func (e *Executor) run(
+	ctx context.Context,
+	checkpoint *Checkpoint,
+	options ...chains.ChainCallOption,
+) (map[string]any, error) {
 	nameToTool := getNameToTool(e.Agent.GetTools())
 
-	steps := make([]schema.AgentStep, 0)
-	for i := 0; i < e.MaxIterations; i++ {
+	var err error
+	if checkpoint.Status == CheckpointApproved {
+		for _, action := range checkpoint.PendingActions {
+			checkpoint.Steps, err = e.doAction(ctx, checkpoint.Steps, nameToTool, action)
+			if err != nil {
+				return nil, err
+			}
+		}
+		checkpoint.PendingActions = nil
+		checkpoint.Status = CheckpointRunning
+		checkpoint.Iteration++
+		if err := e.saveCheckpoint(ctx, checkpoint); err != nil {
+			return nil, err
+		}
+	}
+
+	for checkpoint.Iteration < e.MaxIterations {
+		emit(ctx, Event{Type: EventPlanStarted, Iteration: checkpoint.Iteration})
+
 		var finish map[string]any
-		steps, finish, err = e.doIteration(ctx, steps, nameToTool, inputs, options...)
-		if finish != nil || err != nil {
+		checkpoint.Steps, finish, err = e.doIteration(ctx, checkpoint.Steps, nameToTool, checkpoint.Inputs, options...)
+
+		var paused *PausedError
+		if errors.As(err, &paused) {
+			paused.RunID = checkpoint.RunID
+			checkpoint.Status = CheckpointPaused
+			checkpoint.PendingActions = paused.Actions
+			if err := e.saveCheckpoint(ctx, checkpoint); err != nil {
+				return nil, err
+			}
+			return nil, paused
+		}
+		if err != nil {
 			return finish, err
 		}
+		if finish != nil {
+			return finish, e.deleteCheckpoint(ctx, checkpoint.RunID)
+		}
+
+		checkpoint.Iteration++
+		if err := e.saveCheckpoint(ctx, checkpoint); err != nil {
+			return nil, err
+		}
 	}
 
 	if e.CallbacksHandler != nil {
@@ -68,9 +192,12 @@ func (e *Executor) Call(ctx context.Context, inputValues map[string]any, options
 			ReturnValues: map[string]any{"output": ErrNotFinished.Error()},
 		})
 	}
+	if err := e.deleteCheckpoint(ctx, checkpoint.RunID); err != nil {
+		return nil, err
+	}
 	return e.getReturn(
 		&schema.AgentFinish{ReturnValues: make(map[string]any)},
-		steps,
+		checkpoint.Steps,
 	), ErrNotFinished
 }
 
@@ -87,10 +214,11 @@ func (e *Executor) doIteration( // nolint
 		if e.ErrorHandler.Formatter != nil {
 			formattedObservation = e.ErrorHandler.Formatter(formattedObservation)
 		}
-		steps = append(steps, schema.AgentStep{
+		step := schema.AgentStep{
 			Observation: formattedObservation,
-		})
-		return steps, nil, nil
+		}
+		emit(ctx, Event{Type: EventStepCompleted, Step: &step})
+		return append(steps, step), nil, nil
 	}
 	if err != nil {
 		return steps, nil, err
@@ -107,6 +235,10 @@ func (e *Executor) doIteration( // nolint
 		return steps, e.getReturn(finish, steps), nil
 	}
 
+	if e.needsApproval(actions) {
+		return steps, nil, &PausedError{Actions: actions}
+	}
+
 	for _, action := range actions {
 		steps, err = e.doAction(ctx, steps, nameToTool, action)
 		if err != nil {
@@ -129,21 +261,61 @@ func (e *Executor) doAction(
 
 	tool, ok := nameToTool[strings.ToUpper(action.Tool)]
 	if !ok {
-		return append(steps, schema.AgentStep{
+		step := schema.AgentStep{
 			Action:      action,
 			Observation: fmt.Sprintf("%s is not a valid tool, try another one", action.Tool),
-		}), nil
+		}
+		emit(ctx, Event{Type: EventStepCompleted, Step: &step})
+		return append(steps, step), nil
 	}
 
+	emit(ctx, Event{Type: EventToolCall, Action: &action})
 	observation, err := tool.Call(ctx, strings.TrimSuffix(action.ToolInput, "\nObservation:"))
 	if err != nil {
 		return nil, err
 	}
+	emit(ctx, Event{Type: EventToolResult, Action: &action, Observation: observation})
 
-	return append(steps, schema.AgentStep{
+	step := schema.AgentStep{
 		Action:      action,
 		Observation: observation,
-	}), nil
+	}
+	emit(ctx, Event{Type: EventStepCompleted, Step: &step})
+	return append(steps, step), nil
+}
+
+func (e *Executor) needsApproval(actions []schema.AgentAction) bool {
+	if e.RequiresApproval == nil || e.Checkpointer == nil {
+		return false
+	}
+	for _, action := range actions {
+		if e.RequiresApproval(action) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *Executor) loadCheckpoint(ctx context.Context, runID string) (*Checkpoint, error) {
+	if e.Checkpointer == nil {
+		return nil, ErrNoCheckpointer
+	}
+	return e.Checkpointer.Load(ctx, runID)
+}
+
+func (e *Executor) saveCheckpoint(ctx context.Context, checkpoint *Checkpoint) error {
+	if e.Checkpointer == nil {
+		return nil
+	}
+	checkpoint.UpdatedAt = time.Now()
+	return e.Checkpointer.Save(ctx, *checkpoint)
+}
+
+func (e *Executor) deleteCheckpoint(ctx context.Context, runID string) error {
+	if e.Checkpointer == nil {
+		return nil
+	}
+	return e.Checkpointer.Delete(ctx, runID)
 }
 
 func (e *Executor) getReturn(finish *schema.AgentFinish, steps []schema.AgentStep) map[string]any {
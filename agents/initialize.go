@@ -17,6 +17,9 @@ const (
 	// ConversationalReactDescription is an AgentType constant that represents
 	// the "conversationalReactDescription" agent type.
 	ConversationalReactDescription AgentType = "conversationalReactDescription"
+	// ReActJSONDescription is an AgentType constant that represents
+	// the "reActJSONDescription" agent type.
+	ReActJSONDescription AgentType = "reActJSONDescription"
 )
 
 // Deprecated: This may be removed in the future; please use NewExecutor instead.
@@ -35,6 +38,8 @@ func Initialize(
 		agent = NewOneShotAgent(llm, tools, opts...)
 	case ConversationalReactDescription:
 		agent = NewConversationalAgent(llm, tools, opts...)
+	case ReActJSONDescription:
+		agent = NewReActJSONAgent(llm, tools, opts...)
 	default:
 		return &Executor{}, ErrUnknownAgentType
 	}
@@ -2,6 +2,7 @@ package agents
 
 import (
 	"github.com/devmiahub/langchaingo/callbacks"
+	"github.com/devmiahub/langchaingo/llms"
 	"github.com/devmiahub/langchaingo/memory"
 	"github.com/devmiahub/langchaingo/prompts"
 	"github.com/devmiahub/langchaingo/schema"
@@ -15,10 +16,16 @@ type Options struct {
 	errorHandler            *ParserErrorHandler
 	maxIterations           int
 	returnIntermediateSteps bool
+	checkpointer            Checkpointer
+	requiresApproval        func(action schema.AgentAction) bool
 	outputKey               string
 	promptPrefix            string
 	formatInstructions      string
 	promptSuffix            string
+	maxParseRetries         int
+
+	// plan and execute
+	replanner llms.Model
 
 	// openai
 	systemMessage string
@@ -55,6 +62,22 @@ func conversationalDefaultOptions() Options {
 	}
 }
 
+func reActJSONDefaultOptions() Options {
+	return Options{
+		promptPrefix:       _defaultReActJSONPrefix,
+		formatInstructions: _defaultReActJSONFormatInstructions,
+		promptSuffix:       _defaultReActJSONSuffix,
+		outputKey:          _defaultOutputKey,
+		maxParseRetries:    _defaultMaxParseRetries,
+	}
+}
+
+func planAndExecuteDefaultOptions() Options {
+	return Options{
+		outputKey: _defaultOutputKey,
+	}
+}
+
 func openAIFunctionsDefaultOptions() Options {
 	return Options{
 		systemMessage: "You are a helpful AI assistant.",
@@ -88,6 +111,19 @@ func (co Options) getConversationalPrompt(tools []tools.Tool) prompts.PromptTemp
 	)
 }
 
+func (co Options) getReActJSONPrompt(tools []tools.Tool) prompts.PromptTemplate {
+	if co.prompt.Template != "" {
+		return co.prompt
+	}
+
+	return createReActJSONPrompt(
+		tools,
+		co.promptPrefix,
+		co.formatInstructions,
+		co.promptSuffix,
+	)
+}
+
 // WithMaxIterations is an option for setting the max number of iterations the executor
 // will complete.
 func WithMaxIterations(iterations int) Option {
@@ -161,6 +197,39 @@ func WithParserErrorHandler(errorHandler *ParserErrorHandler) Option {
 	}
 }
 
+// WithMaxParseRetries is an option for setting how many times an agent asks the
+// model to fix an action it could not parse before giving up.
+func WithMaxParseRetries(retries int) Option {
+	return func(co *Options) {
+		co.maxParseRetries = retries
+	}
+}
+
+// WithReplanner is an option for setting the model a plan-and-execute agent
+// revises its plan with.
+func WithReplanner(llm llms.Model) Option {
+	return func(co *Options) {
+		co.replanner = llm
+	}
+}
+
+// WithCheckpointer is an option for setting the checkpointer an executor persists
+// the state of its runs with.
+func WithCheckpointer(checkpointer Checkpointer) Option {
+	return func(co *Options) {
+		co.checkpointer = checkpointer
+	}
+}
+
+// WithApproval is an option for pausing executor runs until a human approves
+// the actions for which requiresApproval returns true. The executor must also
+// have a checkpointer.
+func WithApproval(requiresApproval func(action schema.AgentAction) bool) Option {
+	return func(co *Options) {
+		co.requiresApproval = requiresApproval
+	}
+}
+
 type OpenAIOption struct{}
 
 func NewOpenAIOption() OpenAIOption {
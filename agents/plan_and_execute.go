@@ -0,0 +1,347 @@
+package agents
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/devmiahub/langchaingo/callbacks"
+	"github.com/devmiahub/langchaingo/chains"
+	"github.com/devmiahub/langchaingo/llms"
+	"github.com/devmiahub/langchaingo/prompts"
+	"github.com/devmiahub/langchaingo/schema"
+	"github.com/devmiahub/langchaingo/tools"
+)
+
+const (
+	// PlanUpdateTool is the name of the action a PlanAndExecuteAgent records
+	// the remaining plan with. Its observation is the plan as a numbered list.
+	PlanUpdateTool = "plan_and_execute_update_plan"
+	// PlanStepCompleteTool is the name of the action a PlanAndExecuteAgent
+	// records the result of a completed plan step with.
+	PlanStepCompleteTool = "plan_and_execute_complete_step"
+)
+
+// PlanAndExecuteAgent is an agent that first asks a planner model for an
+// explicit list of steps, then carries out each step with an executor agent
+// and finally asks a replanner model to revise the remaining steps based on
+// the results, until the replanner gives the final answer.
+//
+// The plan and the results of the steps are recorded as intermediate steps of
+// the actions PlanUpdateTool and PlanStepCompleteTool, so the agent runs in an
+// Executor like any other agent. Every plan update, executor action and step
+// completion takes one iteration of the Executor, so the max iterations
+// should be set accordingly.
+type PlanAndExecuteAgent struct {
+	// Planner is the model producing the initial plan.
+	Planner llms.Model
+	// Replanner is the model revising the plan after each step.
+	Replanner llms.Model
+	// StepAgent is the agent carrying out each step of the plan.
+	StepAgent Agent
+	// PlannerPrompt is the prompt used for the initial plan. It has the inputs
+	// of the agent as input.
+	PlannerPrompt prompts.FormatPrompter
+	// ReplannerPrompt is the prompt used to revise the plan. It additionally
+	// has "plan" and "completed_steps" as input.
+	ReplannerPrompt prompts.FormatPrompter
+	// StepPrompt formats the input given to the step agent from "objective",
+	// "completed_steps" and "task".
+	StepPrompt prompts.FormatPrompter
+	// Output key is the key where the final output is placed.
+	OutputKey string
+	// CallbacksHandler is the handler for callbacks.
+	CallbacksHandler callbacks.Handler
+}
+
+var _ Agent = (*PlanAndExecuteAgent)(nil)
+
+// NewPlanAndExecuteAgent creates a new PlanAndExecuteAgent planning with the
+// planner model and carrying out the steps with the step agent. The planner is
+// also used for replanning unless WithReplanner is given.
+func NewPlanAndExecuteAgent(planner llms.Model, stepAgent Agent, opts ...Option) *PlanAndExecuteAgent {
+	options := planAndExecuteDefaultOptions()
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	replanner := options.replanner
+	if replanner == nil {
+		replanner = planner
+	}
+
+	plannerPrompt := createPlannerPrompt()
+	if options.prompt.Template != "" {
+		plannerPrompt = options.prompt
+	}
+
+	return &PlanAndExecuteAgent{
+		Planner:          planner,
+		Replanner:        replanner,
+		StepAgent:        stepAgent,
+		PlannerPrompt:    plannerPrompt,
+		ReplannerPrompt:  createReplannerPrompt(),
+		StepPrompt:       createPlanStepPrompt(),
+		OutputKey:        options.outputKey,
+		CallbacksHandler: options.callbacksHandler,
+	}
+}
+
+// planState is the state of a plan-and-execute run derived from its
+// intermediate steps.
+type planState struct {
+	// plan is the remaining plan, nil if no plan was made yet.
+	plan []string
+	// originalPlan is the first plan made.
+	originalPlan []string
+	// completed are the completed plan steps with their results.
+	completed []schema.AgentStep
+	// stepSteps are the intermediate steps of the step agent for the current
+	// plan step.
+	stepSteps []schema.AgentStep
+	// needsReplan is true if a plan step was completed since the last plan.
+	needsReplan bool
+}
+
+// Plan decides what action to take or returns the final result of the input.
+func (a *PlanAndExecuteAgent) Plan(
+	ctx context.Context,
+	intermediateSteps []schema.AgentStep,
+	inputs map[string]string,
+	options ...chains.ChainCallOption,
+) ([]schema.AgentAction, *schema.AgentFinish, error) {
+	state := newPlanState(intermediateSteps)
+
+	switch {
+	case state.plan == nil:
+		return a.makePlan(ctx, inputs, options...)
+	case state.needsReplan:
+		return a.replan(ctx, state, inputs, options...)
+	case len(state.plan) == 0:
+		return nil, &schema.AgentFinish{
+			ReturnValues: map[string]any{a.OutputKey: lastStepResult(state)},
+		}, nil
+	}
+
+	task := state.plan[0]
+	stepInput, err := a.StepPrompt.FormatPrompt(map[string]any{
+		"objective":       inputs["input"],
+		"completed_steps": formatCompletedSteps(state.completed),
+		"task":            task,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stepInputs := make(map[string]string, len(inputs))
+	for key, value := range inputs {
+		stepInputs[key] = value
+	}
+	stepInputs["input"] = stepInput.String()
+
+	actions, finish, err := a.StepAgent.Plan(ctx, state.stepSteps, stepInputs, options...)
+	if err != nil || finish == nil {
+		return actions, nil, err
+	}
+
+	return []schema.AgentAction{{
+		Tool:      PlanStepCompleteTool,
+		ToolInput: fmt.Sprint(finish.ReturnValues[firstOutputKey(a.StepAgent)]),
+		Log:       task,
+	}}, nil, nil
+}
+
+func (a *PlanAndExecuteAgent) makePlan(
+	ctx context.Context,
+	inputs map[string]string,
+	options ...chains.ChainCallOption,
+) ([]schema.AgentAction, *schema.AgentFinish, error) {
+	values := make(map[string]any, len(inputs))
+	for key, value := range inputs {
+		values[key] = value
+	}
+
+	output, err := a.generate(ctx, a.Planner, a.PlannerPrompt, values, options...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	plan := parsePlan(output)
+	if len(plan) == 0 {
+		return nil, nil, fmt.Errorf("%w: planner returned no steps: %s", ErrUnableToParseOutput, output)
+	}
+
+	return []schema.AgentAction{planUpdateAction(plan, output)}, nil, nil
+}
+
+func (a *PlanAndExecuteAgent) replan(
+	ctx context.Context,
+	state planState,
+	inputs map[string]string,
+	options ...chains.ChainCallOption,
+) ([]schema.AgentAction, *schema.AgentFinish, error) {
+	values := make(map[string]any, len(inputs)+2)
+	for key, value := range inputs {
+		values[key] = value
+	}
+	values["plan"] = formatPlan(state.originalPlan)
+	values["completed_steps"] = formatCompletedSteps(state.completed)
+
+	output, err := a.generate(ctx, a.Replanner, a.ReplannerPrompt, values, options...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if _, answer, ok := strings.Cut(output, _finalAnswerAction); ok {
+		return nil, &schema.AgentFinish{
+			ReturnValues: map[string]any{a.OutputKey: strings.TrimSpace(answer)},
+			Log:          output,
+		}, nil
+	}
+
+	plan := parsePlan(output)
+	if len(plan) == 0 {
+		return nil, nil, fmt.Errorf("%w: replanner returned neither steps nor a final answer: %s",
+			ErrUnableToParseOutput, output)
+	}
+
+	return []schema.AgentAction{planUpdateAction(plan, output)}, nil, nil
+}
+
+func (a *PlanAndExecuteAgent) generate(
+	ctx context.Context,
+	llm llms.Model,
+	prompt prompts.FormatPrompter,
+	values map[string]any,
+	options ...chains.ChainCallOption,
+) (string, error) {
+	promptValue, err := prompt.FormatPrompt(values)
+	if err != nil {
+		return "", err
+	}
+
+	var stream func(ctx context.Context, chunk []byte) error
+	if a.CallbacksHandler != nil {
+		stream = func(ctx context.Context, chunk []byte) error {
+			a.CallbacksHandler.HandleStreamingFunc(ctx, chunk)
+			return nil
+		}
+	}
+
+	llmOptions := []llms.CallOption{llms.WithStreamingFunc(stream)}
+	llmOptions = append(llmOptions, chains.GetLLMCallOptions(options...)...)
+
+	return llms.GenerateFromSinglePrompt(ctx, llm, promptValue.String(), llmOptions...)
+}
+
+// GetInputKeys returns the input keys of the planner prompt.
+func (a *PlanAndExecuteAgent) GetInputKeys() []string {
+	return a.PlannerPrompt.GetInputVariables()
+}
+
+func (a *PlanAndExecuteAgent) GetOutputKeys() []string {
+	return []string{a.OutputKey}
+}
+
+// GetTools returns the tools of the step agent along with the tools recording
+// the plan and the completed steps.
+func (a *PlanAndExecuteAgent) GetTools() []tools.Tool {
+	return append([]tools.Tool{
+		recordTool{name: PlanUpdateTool},
+		recordTool{name: PlanStepCompleteTool},
+	}, a.StepAgent.GetTools()...)
+}
+
+func newPlanState(steps []schema.AgentStep) planState {
+	var state planState
+	for _, step := range steps {
+		switch step.Action.Tool {
+		case PlanUpdateTool:
+			state.plan = parsePlan(step.Observation)
+			if state.originalPlan == nil {
+				state.originalPlan = state.plan
+			}
+			state.stepSteps = nil
+			state.needsReplan = false
+		case PlanStepCompleteTool:
+			state.completed = append(state.completed, step)
+			if len(state.plan) > 0 {
+				state.plan = state.plan[1:]
+			}
+			state.stepSteps = nil
+			state.needsReplan = true
+		default:
+			state.stepSteps = append(state.stepSteps, step)
+		}
+	}
+	return state
+}
+
+func planUpdateAction(plan []string, log string) schema.AgentAction {
+	return schema.AgentAction{
+		Tool:      PlanUpdateTool,
+		ToolInput: formatPlan(plan),
+		Log:       log,
+	}
+}
+
+var _planStepRegex = regexp.MustCompile(`^\s*(?:\d+[.)]|[-*])\s+(.+)$`)
+
+// parsePlan parses a numbered or bulleted list of steps.
+func parsePlan(text string) []string {
+	plan := make([]string, 0)
+	for _, line := range strings.Split(text, "\n") {
+		if matches := _planStepRegex.FindStringSubmatch(line); matches != nil {
+			plan = append(plan, strings.TrimSpace(matches[1]))
+		}
+	}
+	return plan
+}
+
+func formatPlan(plan []string) string {
+	var b strings.Builder
+	for i, step := range plan {
+		fmt.Fprintf(&b, "%d. %s\n", i+1, step)
+	}
+	return b.String()
+}
+
+func formatCompletedSteps(completed []schema.AgentStep) string {
+	if len(completed) == 0 {
+		return "None"
+	}
+
+	var b strings.Builder
+	for i, step := range completed {
+		fmt.Fprintf(&b, "%d. %s\nResult: %s\n", i+1, step.Action.Log, step.Observation)
+	}
+	return b.String()
+}
+
+func lastStepResult(state planState) string {
+	if len(state.completed) == 0 {
+		return ""
+	}
+	return state.completed[len(state.completed)-1].Observation
+}
+
+func firstOutputKey(agent Agent) string {
+	if keys := agent.GetOutputKeys(); len(keys) > 0 {
+		return keys[0]
+	}
+	return _defaultOutputKey
+}
+
+// recordTool is a tool returning its input, used to record agent state as
+// intermediate steps.
+type recordTool struct {
+	name string
+}
+
+func (t recordTool) Name() string        { return t.name }
+func (t recordTool) Description() string { return "records the state of the agent" }
+
+func (t recordTool) Call(_ context.Context, input string) (string, error) {
+	return input, nil
+}
@@ -0,0 +1,47 @@
+package agents
+
+import (
+	"github.com/devmiahub/langchaingo/prompts"
+)
+
+const (
+	_defaultPlannerTemplate = `Let's first understand the problem and devise a plan to solve it.
+Output the plan as a numbered list of steps, one step per line, and nothing else.
+Each step should be a self-contained task that can be carried out with the available tools.
+The result of the final step should be the answer to the objective.
+
+Objective: {{.input}}`
+
+	_defaultReplannerTemplate = `You are revising a plan for the following objective.
+
+Objective: {{.input}}
+
+The original plan was:
+{{.plan}}
+
+The following steps have been completed:
+{{.completed_steps}}
+
+If no more steps are needed to fulfill the objective, respond with "Final Answer:" followed by the answer to the objective.
+Otherwise, respond with the remaining steps as a numbered list, one step per line, and nothing else.
+Do not include steps that have already been completed.`
+
+	_defaultPlanStepTemplate = `Objective: {{.objective}}
+
+Completed steps:
+{{.completed_steps}}
+
+Your current task is: {{.task}}`
+)
+
+func createPlannerPrompt() prompts.PromptTemplate {
+	return prompts.NewPromptTemplate(_defaultPlannerTemplate, []string{"input"})
+}
+
+func createReplannerPrompt() prompts.PromptTemplate {
+	return prompts.NewPromptTemplate(_defaultReplannerTemplate, []string{"input", "plan", "completed_steps"})
+}
+
+func createPlanStepPrompt() prompts.PromptTemplate {
+	return prompts.NewPromptTemplate(_defaultPlanStepTemplate, []string{"objective", "completed_steps", "task"})
+}
@@ -0,0 +1,83 @@
+package agents_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/devmiahub/langchaingo/agents"
+	"github.com/devmiahub/langchaingo/chains"
+	"github.com/devmiahub/langchaingo/llms/fake"
+	"github.com/devmiahub/langchaingo/schema"
+	"github.com/devmiahub/langchaingo/tools"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanAndExecuteAgent(t *testing.T) {
+	t.Parallel()
+
+	planner := fake.NewFakeLLM([]string{
+		"1. Look up the population of France\n2. Look up the population of Spain",
+		"1. Look up the population of Spain",
+		"Final Answer: France has more inhabitants.",
+	})
+	tool := &flakyTool{}
+	stepAgent := &scriptedAgent{
+		actions: []schema.AgentAction{{Tool: "echo", ToolInput: "population"}},
+		tools:   []tools.Tool{tool},
+	}
+	agent := agents.NewPlanAndExecuteAgent(planner, stepAgent)
+	executor := agents.NewExecutor(
+		agent,
+		agents.WithMaxIterations(10),
+		agents.WithReturnIntermediateSteps(),
+	)
+
+	outputs, err := chains.Call(context.Background(), executor, map[string]any{
+		"input": "Which country has more inhabitants, France or Spain?",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "France has more inhabitants.", outputs["output"])
+	require.Equal(t, 2, tool.calls)
+
+	steps, ok := outputs["intermediateSteps"].([]schema.AgentStep)
+	require.True(t, ok)
+	names := make([]string, 0, len(steps))
+	for _, step := range steps {
+		names = append(names, step.Action.Tool)
+	}
+	require.Equal(t, []string{
+		agents.PlanUpdateTool,
+		"echo",
+		agents.PlanStepCompleteTool,
+		agents.PlanUpdateTool,
+		"echo",
+		agents.PlanStepCompleteTool,
+	}, names)
+	require.Equal(t, "1. Look up the population of Spain\n", steps[3].Observation)
+}
+
+func TestPlanAndExecuteAgentEmptyPlan(t *testing.T) {
+	t.Parallel()
+
+	agent := agents.NewPlanAndExecuteAgent(fake.NewFakeLLM([]string{"I don't know."}), &scriptedAgent{})
+	_, _, err := agent.Plan(context.Background(), nil, map[string]string{"input": "?"})
+	require.ErrorIs(t, err, agents.ErrUnableToParseOutput)
+}
+
+func TestPlanAndExecuteAgentUnparsableReplan(t *testing.T) {
+	t.Parallel()
+
+	planner := fake.NewFakeLLM([]string{
+		"1. Look up the population of France",
+		"France is big, I think.",
+	})
+	stepAgent := &scriptedAgent{
+		actions: []schema.AgentAction{{Tool: "echo", ToolInput: "population"}},
+		tools:   []tools.Tool{&flakyTool{}},
+	}
+	executor := agents.NewExecutor(agents.NewPlanAndExecuteAgent(planner, stepAgent), agents.WithMaxIterations(10))
+
+	// The answer of the replanner is not taken as the final answer.
+	_, err := chains.Call(context.Background(), executor, map[string]any{"input": "How many people live in France?"})
+	require.ErrorIs(t, err, agents.ErrUnableToParseOutput)
+}
@@ -0,0 +1,220 @@
+package agents
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/devmiahub/langchaingo/callbacks"
+	"github.com/devmiahub/langchaingo/chains"
+	"github.com/devmiahub/langchaingo/internal/codeblock"
+	"github.com/devmiahub/langchaingo/llms"
+	"github.com/devmiahub/langchaingo/prompts"
+	"github.com/devmiahub/langchaingo/schema"
+	"github.com/devmiahub/langchaingo/tools"
+)
+
+const (
+	_reActJSONFinalAnswer      = "Final Answer"
+	_defaultMaxParseRetries    = 2
+	_reActJSONObservationStop  = "\nObservation:"
+	_reActJSONRetryInstruction = "Your previous response could not be used: %s\n" +
+		"Respond again with a single JSON blob in the action format."
+)
+
+// ReActJSONAgent is an agent using the ReAct framework whose actions are
+// emitted as JSON blobs instead of "Action:/Action Input:" lines. The input of
+// tools implementing tools.SchemaTool is validated against their schema.
+// Malformed actions are sent back to the model to be fixed.
+//
+// The agent only relies on text generation, so it works with models without
+// native tool calling.
+type ReActJSONAgent struct {
+	// LLM is the model used to plan.
+	LLM llms.Model
+	// Prompt is the prompt used to plan. It should have an input called
+	// "agent_scratchpad" for the agent to put its thoughts in.
+	Prompt prompts.FormatPrompter
+	// Tools is a list of the tools the agent can use.
+	Tools []tools.Tool
+	// Output key is the key where the final output is placed.
+	OutputKey string
+	// MaxParseRetries is the number of times the model is asked to fix an
+	// action that could not be parsed or validated.
+	MaxParseRetries int
+	// CallbacksHandler is the handler for callbacks.
+	CallbacksHandler callbacks.Handler
+}
+
+var _ Agent = (*ReActJSONAgent)(nil)
+
+// NewReActJSONAgent creates a new ReActJSONAgent with the given model, tools
+// and options.
+func NewReActJSONAgent(llm llms.Model, tools []tools.Tool, opts ...Option) *ReActJSONAgent {
+	options := reActJSONDefaultOptions()
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return &ReActJSONAgent{
+		LLM:              llm,
+		Prompt:           options.getReActJSONPrompt(tools),
+		Tools:            tools,
+		OutputKey:        options.outputKey,
+		MaxParseRetries:  options.maxParseRetries,
+		CallbacksHandler: options.callbacksHandler,
+	}
+}
+
+// Plan decides what action to take or returns the final result of the input.
+func (a *ReActJSONAgent) Plan(
+	ctx context.Context,
+	intermediateSteps []schema.AgentStep,
+	inputs map[string]string,
+	options ...chains.ChainCallOption,
+) ([]schema.AgentAction, *schema.AgentFinish, error) {
+	fullInputs := make(map[string]any, len(inputs))
+	for key, value := range inputs {
+		fullInputs[key] = value
+	}
+	fullInputs[agentScratchpad] = constructReActJSONScratchPad(intermediateSteps)
+
+	prompt, err := a.Prompt.FormatPrompt(fullInputs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var stream func(ctx context.Context, chunk []byte) error
+	if a.CallbacksHandler != nil {
+		stream = func(ctx context.Context, chunk []byte) error {
+			a.CallbacksHandler.HandleStreamingFunc(ctx, chunk)
+			return nil
+		}
+	}
+
+	llmOptions := []llms.CallOption{
+		llms.WithStopWords([]string{_reActJSONObservationStop}),
+		llms.WithStreamingFunc(stream),
+	}
+	llmOptions = append(llmOptions, chains.GetLLMCallOptions(options...)...)
+
+	text := prompt.String()
+	for attempt := 0; ; attempt++ {
+		output, err := llms.GenerateFromSinglePrompt(ctx, a.LLM, text, llmOptions...)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		actions, finish, err := a.parseOutput(output)
+		if !errors.Is(err, ErrUnableToParseOutput) || attempt >= a.MaxParseRetries {
+			return actions, finish, err
+		}
+
+		text += output + "\n" + fmt.Sprintf(_reActJSONRetryInstruction, err) + "\n"
+	}
+}
+
+func (a *ReActJSONAgent) GetInputKeys() []string {
+	chainInputs := a.Prompt.GetInputVariables()
+
+	// Remove inputs given in plan.
+	agentInput := make([]string, 0, len(chainInputs))
+	for _, v := range chainInputs {
+		if v == agentScratchpad {
+			continue
+		}
+		agentInput = append(agentInput, v)
+	}
+
+	return agentInput
+}
+
+func (a *ReActJSONAgent) GetOutputKeys() []string {
+	return []string{a.OutputKey}
+}
+
+func (a *ReActJSONAgent) GetTools() []tools.Tool {
+	return a.Tools
+}
+
+func constructReActJSONScratchPad(steps []schema.AgentStep) string {
+	var scratchPad strings.Builder
+	for _, step := range steps {
+		scratchPad.WriteString(step.Action.Log)
+		scratchPad.WriteString("\nObservation: " + step.Observation + "\nThought:")
+	}
+
+	return scratchPad.String()
+}
+
+// reActJSONAction is the JSON blob the model emits.
+type reActJSONAction struct {
+	Action      string          `json:"action"`
+	ActionInput json.RawMessage `json:"action_input"`
+}
+
+func (a *ReActJSONAgent) parseOutput(output string) ([]schema.AgentAction, *schema.AgentFinish, error) {
+	blob, ok := codeblock.Object(output)
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: no JSON blob found", ErrUnableToParseOutput)
+	}
+
+	var action reActJSONAction
+	if err := json.Unmarshal([]byte(blob), &action); err != nil {
+		return nil, nil, fmt.Errorf("%w: invalid JSON: %w", ErrUnableToParseOutput, err)
+	}
+	if action.Action == "" {
+		return nil, nil, fmt.Errorf("%w: missing \"action\" key", ErrUnableToParseOutput)
+	}
+
+	input, err := a.actionInput(action)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if strings.EqualFold(action.Action, _reActJSONFinalAnswer) {
+		return nil, &schema.AgentFinish{
+			ReturnValues: map[string]any{a.OutputKey: input},
+			Log:          output,
+		}, nil
+	}
+
+	return []schema.AgentAction{
+		{Tool: action.Action, ToolInput: input, Log: output},
+	}, nil, nil
+}
+
+// actionInput validates the input of the action and returns it as the string
+// the tool is called with.
+func (a *ReActJSONAgent) actionInput(action reActJSONAction) (string, error) {
+	if len(action.ActionInput) == 0 {
+		return "", fmt.Errorf("%w: missing \"action_input\" key", ErrUnableToParseOutput)
+	}
+
+	var tool tools.Tool
+	for _, t := range a.Tools {
+		if strings.EqualFold(t.Name(), action.Action) {
+			tool = t
+		}
+	}
+	if tool == nil && !strings.EqualFold(action.Action, _reActJSONFinalAnswer) {
+		return "", fmt.Errorf("%w: %q is not a valid action, use one of [%s] or %q",
+			ErrUnableToParseOutput, action.Action, toolNames(a.Tools), _reActJSONFinalAnswer)
+	}
+
+	if schemaTool, ok := tool.(tools.SchemaTool); ok {
+		if err := schemaTool.Schema().ValidateJSON(action.ActionInput); err != nil {
+			return "", fmt.Errorf("%w: invalid action_input for %s: %w", ErrUnableToParseOutput, tool.Name(), err)
+		}
+		return string(action.ActionInput), nil
+	}
+
+	var input string
+	if err := json.Unmarshal(action.ActionInput, &input); err != nil {
+		// Tools taking strings get non-string inputs as JSON.
+		return string(action.ActionInput), nil //nolint:nilerr
+	}
+	return input, nil
+}
@@ -0,0 +1,83 @@
+package agents
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/devmiahub/langchaingo/prompts"
+	"github.com/devmiahub/langchaingo/tools"
+)
+
+const (
+	_defaultReActJSONPrefix = `Answer the following questions as best you can. You have access to the following tools:
+
+{{.tool_descriptions}}`
+
+	_defaultReActJSONFormatInstructions = `Use a JSON blob to specify a tool by providing an "action" key (the tool name) and an "action_input" key (the tool input).
+
+Valid "action" values: "Final Answer" or one of [ {{.tool_names}} ]
+
+Provide only ONE action per JSON blob, as shown:
+
+` + "```json" + `
+{
+  "action": "tool name",
+  "action_input": "input"
+}
+` + "```" + `
+
+Use the following format:
+
+Question: the input question you must answer
+Thought: you should always think about what to do
+Action:
+` + "```json" + `
+$JSON_BLOB
+` + "```" + `
+Observation: the result of the action
+... (this Thought/Action/Observation can repeat N times)
+Thought: I now know the final answer
+Action:
+` + "```json" + `
+{
+  "action": "Final Answer",
+  "action_input": "the final answer to the original input question"
+}
+` + "```"
+
+	_defaultReActJSONSuffix = `Begin! Always respond with a single JSON blob in the action format.
+
+Question: {{.input}}
+{{.agent_scratchpad}}`
+)
+
+func createReActJSONPrompt(tools []tools.Tool, prefix, instructions, suffix string) prompts.PromptTemplate {
+	template := strings.Join([]string{prefix, instructions, suffix}, "\n\n")
+
+	return prompts.PromptTemplate{
+		Template:       template,
+		TemplateFormat: prompts.TemplateFormatGoTemplate,
+		InputVariables: []string{"input", "agent_scratchpad"},
+		PartialVariables: map[string]any{
+			"tool_names":        toolNames(tools),
+			"tool_descriptions": toolSchemaDescriptions(tools),
+		},
+	}
+}
+
+// toolSchemaDescriptions describes the tools along with the input they expect.
+func toolSchemaDescriptions(t []tools.Tool) string {
+	var ts strings.Builder
+	for _, tool := range t {
+		input := `a string`
+		if schemaTool, ok := tool.(tools.SchemaTool); ok {
+			if schema, err := json.Marshal(schemaTool.Schema()); err == nil {
+				input = "a JSON object matching the schema " + string(schema)
+			}
+		}
+		ts.WriteString(fmt.Sprintf("- %s: %s (action_input: %s)\n", tool.Name(), tool.Description(), input))
+	}
+
+	return ts.String()
+}
@@ -0,0 +1,96 @@
+package agents_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/devmiahub/langchaingo/agents"
+	"github.com/devmiahub/langchaingo/chains"
+	"github.com/devmiahub/langchaingo/jsonschema"
+	"github.com/devmiahub/langchaingo/llms/fake"
+	"github.com/devmiahub/langchaingo/tools"
+	"github.com/stretchr/testify/require"
+)
+
+type weatherTool struct {
+	input string
+}
+
+func (t *weatherTool) Name() string        { return "weather" }
+func (t *weatherTool) Description() string { return "returns the weather of a city" }
+
+func (t *weatherTool) Schema() jsonschema.Definition {
+	return jsonschema.Definition{
+		Type: jsonschema.Object,
+		Properties: map[string]jsonschema.Definition{
+			"city": {Type: jsonschema.String},
+		},
+		Required: []string{"city"},
+	}
+}
+
+func (t *weatherTool) Call(_ context.Context, input string) (string, error) {
+	t.input = input
+	return "sunny", nil
+}
+
+func TestReActJSONAgent(t *testing.T) {
+	t.Parallel()
+
+	llm := fake.NewFakeLLM([]string{
+		"Thought: I need the weather.\nAction:\n```json\n{\"action\": \"weather\", \"action_input\": {\"city\": \"Paris\"}}\n```",
+		"Thought: I now know the final answer\nAction:\n```json\n{\"action\": \"Final Answer\", \"action_input\": \"It is sunny.\"}\n```",
+	})
+	tool := &weatherTool{}
+	agent := agents.NewReActJSONAgent(llm, []tools.Tool{tool})
+	executor := agents.NewExecutor(agent)
+
+	answer, err := chains.Run(context.Background(), executor, "What is the weather in Paris?")
+	require.NoError(t, err)
+	require.Equal(t, "It is sunny.", answer)
+	require.JSONEq(t, `{"city": "Paris"}`, tool.input)
+}
+
+func TestReActJSONAgentRepromptsMalformedActions(t *testing.T) {
+	t.Parallel()
+
+	llm := fake.NewFakeLLM([]string{
+		"Action: weather\nAction Input: Paris",
+		`{"action": "weather", "action_input": {"town": "Paris"}}`,
+		`{"action": "Final Answer", "action_input": "done"}`,
+	})
+	agent := agents.NewReActJSONAgent(llm, []tools.Tool{&weatherTool{}})
+
+	actions, finish, err := agent.Plan(context.Background(), nil, map[string]string{"input": "weather?"})
+	require.NoError(t, err)
+	require.Empty(t, actions)
+	require.Equal(t, "done", finish.ReturnValues["output"])
+}
+
+func TestReActJSONAgentGivesUpAfterRetries(t *testing.T) {
+	t.Parallel()
+
+	llm := fake.NewFakeLLM([]string{
+		`{"action": "search", "action_input": "Paris"}`,
+		`{"action": "search", "action_input": "Paris"}`,
+	})
+	agent := agents.NewReActJSONAgent(llm, []tools.Tool{&weatherTool{}}, agents.WithMaxParseRetries(1))
+
+	_, _, err := agent.Plan(context.Background(), nil, map[string]string{"input": "weather?"})
+	require.ErrorIs(t, err, agents.ErrUnableToParseOutput)
+}
+
+func TestReActJSONAgentStringTools(t *testing.T) {
+	t.Parallel()
+
+	llm := fake.NewFakeLLM([]string{
+		`Thought: calculate. {"action": "calculator", "action_input": "2 + 2"}`,
+	})
+	agent := agents.NewReActJSONAgent(llm, []tools.Tool{tools.Calculator{}})
+
+	actions, _, err := agent.Plan(context.Background(), nil, map[string]string{"input": "2+2?"})
+	require.NoError(t, err)
+	require.Len(t, actions, 1)
+	require.Equal(t, "calculator", actions[0].Tool)
+	require.Equal(t, "2 + 2", actions[0].ToolInput)
+}
@@ -0,0 +1,89 @@
+// Package sqlite3 adds support for
+// checkpointing agent runs using sqlite3.
+package sqlite3
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/devmiahub/langchaingo/agents"
+	_ "github.com/mattn/go-sqlite3" // sqlite3 driver.
+)
+
+// SqliteCheckpointer is a checkpointer that stores agent runs in a sqlite3 table.
+type SqliteCheckpointer struct {
+	// DB is the database connection.
+	DB *sql.DB
+	// Ctx is a context that can be used for the schema exec.
+	//nolint:containedctx // This is used only when execing schema.
+	Ctx context.Context
+	// DBAddress is the address or file path for connecting the db.
+	DBAddress string
+	// TableName is the name of the checkpoints table.
+	TableName string
+	// Schema defines a initial schema to be run.
+	Schema []byte
+}
+
+// Statically assert that SqliteCheckpointer implement the checkpointer interface.
+var _ agents.Checkpointer = &SqliteCheckpointer{}
+
+// NewSqliteCheckpointer creates a new SqliteCheckpointer using checkpointer options.
+func NewSqliteCheckpointer(options ...SqliteCheckpointerOption) (*SqliteCheckpointer, error) {
+	return applyCheckpointerOptions(options...)
+}
+
+// Save stores the checkpoint, replacing the previous checkpoint of the run.
+func (c *SqliteCheckpointer) Save(ctx context.Context, checkpoint agents.Checkpoint) error {
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+
+	querytpl := []string{
+		"INSERT INTO ",
+		" (run_id, data, updated) VALUES (?, ?, ?) ON CONFLICT(run_id) DO UPDATE SET data = excluded.data, updated = excluded.updated;", //nolint:lll
+	}
+	query := strings.Join(querytpl, c.TableName)
+	_, err = c.DB.ExecContext(ctx, query, checkpoint.RunID, string(data), checkpoint.UpdatedAt)
+	return err
+}
+
+// Load returns the last checkpoint of a run.
+func (c *SqliteCheckpointer) Load(ctx context.Context, runID string) (*agents.Checkpoint, error) {
+	querytpl := []string{
+		"SELECT data FROM ",
+		" WHERE run_id = ?;",
+	}
+	query := strings.Join(querytpl, c.TableName)
+
+	var data string
+	err := c.DB.QueryRowContext(ctx, query, runID).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", agents.ErrCheckpointNotFound, runID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var checkpoint agents.Checkpoint
+	if err := json.Unmarshal([]byte(data), &checkpoint); err != nil {
+		return nil, err
+	}
+	return &checkpoint, nil
+}
+
+// Delete removes the checkpoint of a run.
+func (c *SqliteCheckpointer) Delete(ctx context.Context, runID string) error {
+	querytpl := []string{
+		"DELETE FROM ",
+		" WHERE run_id = ?;",
+	}
+	query := strings.Join(querytpl, c.TableName)
+	_, err := c.DB.ExecContext(ctx, query, runID)
+	return err
+}
@@ -0,0 +1,101 @@
+package sqlite3
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	_ "github.com/mattn/go-sqlite3" // sqlite3 driver.
+)
+
+// DefaultTableName sets a default table name.
+const DefaultTableName = "langchaingo_agent_checkpoints"
+
+// DefaultSchema sets a default schema to be run after connecting.
+const DefaultSchema = `CREATE TABLE IF NOT EXISTS %s (
+		run_id TEXT PRIMARY KEY,
+		data TEXT NOT NULL,
+		updated TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);`
+
+// SqliteCheckpointerOption is a function for creating a new
+// checkpointer with other than the default values.
+type SqliteCheckpointerOption func(c *SqliteCheckpointer)
+
+// WithDB is an option for NewSqliteCheckpointer for adding
+// a database connection.
+func WithDB(db *sql.DB) SqliteCheckpointerOption {
+	return func(c *SqliteCheckpointer) {
+		c.DB = db
+	}
+}
+
+// WithDBAddress is an option for NewSqliteCheckpointer for setting
+// the address or file path of the database to open.
+func WithDBAddress(address string) SqliteCheckpointerOption {
+	return func(c *SqliteCheckpointer) {
+		c.DBAddress = address
+	}
+}
+
+// WithContext is an option for NewSqliteCheckpointer
+// to use a context internally when running Schema.
+func WithContext(ctx context.Context) SqliteCheckpointerOption {
+	return func(c *SqliteCheckpointer) {
+		c.Ctx = ctx //nolint:fatcontext
+	}
+}
+
+// WithSchema is an option for NewSqliteCheckpointer for
+// running a schema when connected. Useful for migrations for example.
+func WithSchema(schema []byte) SqliteCheckpointerOption {
+	return func(c *SqliteCheckpointer) {
+		c.Schema = schema
+	}
+}
+
+// WithTableName is an option for NewSqliteCheckpointer for
+// setting the name of the checkpoints table.
+func WithTableName(name string) SqliteCheckpointerOption {
+	return func(c *SqliteCheckpointer) {
+		c.TableName = name
+	}
+}
+
+func applyCheckpointerOptions(options ...SqliteCheckpointerOption) (*SqliteCheckpointer, error) {
+	c := &SqliteCheckpointer{}
+
+	for _, option := range options {
+		option(c)
+	}
+
+	if c.TableName == "" {
+		c.TableName = DefaultTableName
+	}
+
+	if c.Schema == nil {
+		c.Schema = []byte(fmt.Sprintf(DefaultSchema, c.TableName))
+	}
+
+	if c.Ctx == nil {
+		c.Ctx = context.Background()
+	}
+
+	if c.DBAddress == "" {
+		c.DBAddress = ":memory:"
+	}
+
+	if c.DB == nil {
+		db, err := sql.Open("sqlite3", c.DBAddress)
+		if err != nil {
+			return nil, err
+		}
+		c.DB = db
+	}
+
+	if _, err := c.DB.ExecContext(c.Ctx, string(c.Schema)); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
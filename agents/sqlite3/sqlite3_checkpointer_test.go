@@ -0,0 +1,46 @@
+package sqlite3_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/devmiahub/langchaingo/agents"
+	"github.com/devmiahub/langchaingo/agents/sqlite3"
+	"github.com/devmiahub/langchaingo/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSqliteCheckpointer(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	c, err := sqlite3.NewSqliteCheckpointer(sqlite3.WithContext(ctx))
+	require.NoError(t, err)
+
+	_, err = c.Load(ctx, "run")
+	require.ErrorIs(t, err, agents.ErrCheckpointNotFound)
+
+	checkpoint := agents.Checkpoint{
+		RunID:  "run",
+		Inputs: map[string]string{"input": "foo"},
+		Steps: []schema.AgentStep{
+			{Action: schema.AgentAction{Tool: "calculator", ToolInput: "1+1"}, Observation: "2"},
+		},
+		Iteration: 1,
+		Status:    agents.CheckpointRunning,
+		UpdatedAt: time.Now().UTC(),
+	}
+	require.NoError(t, c.Save(ctx, checkpoint))
+
+	checkpoint.Iteration = 2
+	require.NoError(t, c.Save(ctx, checkpoint))
+
+	loaded, err := c.Load(ctx, "run")
+	require.NoError(t, err)
+	require.Equal(t, checkpoint, *loaded)
+
+	require.NoError(t, c.Delete(ctx, "run"))
+	_, err = c.Load(ctx, "run")
+	require.ErrorIs(t, err, agents.ErrCheckpointNotFound)
+}
@@ -0,0 +1,109 @@
+package agents
+
+import (
+	"context"
+
+	"github.com/devmiahub/langchaingo/chains"
+	"github.com/devmiahub/langchaingo/llms"
+	"github.com/devmiahub/langchaingo/schema"
+)
+
+// EventType is the type of an event emitted while streaming an executor run.
+type EventType string
+
+const (
+	// EventPlanStarted is emitted before the agent is asked to plan the next step.
+	EventPlanStarted EventType = "plan_started"
+	// EventTokenDelta is emitted for each chunk streamed by the model while planning.
+	EventTokenDelta EventType = "token_delta"
+	// EventToolCall is emitted before a tool is called.
+	EventToolCall EventType = "tool_call"
+	// EventToolResult is emitted after a tool returned its observation.
+	EventToolResult EventType = "tool_result"
+	// EventStepCompleted is emitted when a step has been added to the intermediate steps.
+	EventStepCompleted EventType = "step_completed"
+	// EventFinalAnswer is emitted with the outputs of the run once it finished.
+	EventFinalAnswer EventType = "final_answer"
+	// EventError is emitted if the run failed. No events follow it.
+	EventError EventType = "error"
+)
+
+// Event is an event emitted while streaming an executor run. Which fields are
+// set depends on the type of the event.
+type Event struct {
+	Type EventType
+	// Iteration is the iteration the agent is planning. Set for EventPlanStarted.
+	Iteration int
+	// Delta is the chunk streamed by the model. Set for EventTokenDelta.
+	Delta string
+	// Action is the action being executed. Set for EventToolCall and EventToolResult.
+	Action *schema.AgentAction
+	// Observation is the output of the tool. Set for EventToolResult.
+	Observation string
+	// Step is the completed step. Set for EventStepCompleted.
+	Step *schema.AgentStep
+	// Outputs are the outputs of the run. Set for EventFinalAnswer.
+	Outputs map[string]any
+	// Err is the error the run failed with. Set for EventError.
+	Err error
+}
+
+type eventsContextKey struct{}
+
+// Stream runs the executor like chains.Call and returns a channel of the
+// events of the run. The channel is closed after an EventFinalAnswer or
+// EventError event. The caller must read the channel until it is closed or
+// cancel ctx. A streaming func in options is still called for each chunk,
+// before its EventTokenDelta event.
+func (e *Executor) Stream(
+	ctx context.Context,
+	inputValues map[string]any,
+	options ...chains.ChainCallOption,
+) <-chan Event {
+	events := make(chan Event)
+
+	go func() {
+		defer close(events)
+
+		ctx := context.WithValue(ctx, eventsContextKey{}, events)
+		callOpts := llms.CallOptions{}
+		for _, opt := range chains.GetLLMCallOptions(options...) {
+			opt(&callOpts)
+		}
+		stream := func(ctx context.Context, chunk []byte) error {
+			if e.CallbacksHandler != nil {
+				e.CallbacksHandler.HandleStreamingFunc(ctx, chunk)
+			}
+			if callOpts.StreamingFunc != nil {
+				if err := callOpts.StreamingFunc(ctx, chunk); err != nil {
+					return err
+				}
+			}
+			emit(ctx, Event{Type: EventTokenDelta, Delta: string(chunk)})
+			return ctx.Err()
+		}
+		options := append(append([]chains.ChainCallOption{}, options...), chains.WithStreamingFunc(stream))
+
+		outputs, err := chains.Call(ctx, e, inputValues, options...)
+		if err != nil {
+			emit(ctx, Event{Type: EventError, Err: err})
+			return
+		}
+		emit(ctx, Event{Type: EventFinalAnswer, Outputs: outputs})
+	}()
+
+	return events
+}
+
+// emit sends the event to the channel of the stream ctx belongs to, if any.
+func emit(ctx context.Context, event Event) {
+	events, ok := ctx.Value(eventsContextKey{}).(chan Event)
+	if !ok {
+		return
+	}
+
+	select {
+	case events <- event:
+	case <-ctx.Done():
+	}
+}
@@ -0,0 +1,110 @@
+package agents_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/devmiahub/langchaingo/agents"
+	"github.com/devmiahub/langchaingo/chains"
+	"github.com/devmiahub/langchaingo/llms"
+	"github.com/devmiahub/langchaingo/schema"
+	"github.com/devmiahub/langchaingo/tools"
+	"github.com/stretchr/testify/require"
+)
+
+// streamingAgent streams "thinking" through the streaming function of the
+// call options before planning like a scriptedAgent.
+type streamingAgent struct {
+	scriptedAgent
+}
+
+func (a *streamingAgent) Plan(
+	ctx context.Context,
+	intermediateSteps []schema.AgentStep,
+	inputs map[string]string,
+	options ...chains.ChainCallOption,
+) ([]schema.AgentAction, *schema.AgentFinish, error) {
+	opts := llms.CallOptions{}
+	for _, opt := range chains.GetLLMCallOptions(options...) {
+		opt(&opts)
+	}
+	if opts.StreamingFunc != nil {
+		if err := opts.StreamingFunc(ctx, []byte("thinking")); err != nil {
+			return nil, nil, err
+		}
+	}
+	return a.scriptedAgent.Plan(ctx, intermediateSteps, inputs, options...)
+}
+
+func TestExecutorStream(t *testing.T) {
+	t.Parallel()
+
+	agent := &streamingAgent{scriptedAgent{
+		actions: []schema.AgentAction{{Tool: "echo", ToolInput: "hello"}},
+		tools:   []tools.Tool{&flakyTool{}},
+	}}
+	executor := agents.NewExecutor(agent)
+
+	var types []agents.EventType
+	var last agents.Event
+	for event := range executor.Stream(context.Background(), map[string]any{"input": "go"}) {
+		types = append(types, event.Type)
+		last = event
+	}
+
+	require.Equal(t, []agents.EventType{
+		agents.EventPlanStarted,
+		agents.EventTokenDelta,
+		agents.EventToolCall,
+		agents.EventToolResult,
+		agents.EventStepCompleted,
+		agents.EventPlanStarted,
+		agents.EventTokenDelta,
+		agents.EventFinalAnswer,
+	}, types)
+	require.Equal(t, "hello", last.Outputs["output"])
+}
+
+func TestExecutorStreamCallerStreamingFunc(t *testing.T) {
+	t.Parallel()
+
+	agent := &streamingAgent{scriptedAgent{
+		actions: []schema.AgentAction{{Tool: "echo", ToolInput: "hello"}},
+		tools:   []tools.Tool{&flakyTool{}},
+	}}
+	executor := agents.NewExecutor(agent)
+
+	var chunks []string
+	streamingFunc := func(_ context.Context, chunk []byte) error {
+		chunks = append(chunks, string(chunk))
+		return nil
+	}
+	var deltas []string
+	for event := range executor.Stream(
+		context.Background(),
+		map[string]any{"input": "go"},
+		chains.WithStreamingFunc(streamingFunc),
+	) {
+		if event.Type == agents.EventTokenDelta {
+			deltas = append(deltas, event.Delta)
+		}
+	}
+
+	require.Equal(t, []string{"thinking", "thinking"}, chunks)
+	require.Equal(t, chunks, deltas)
+}
+
+func TestExecutorStreamError(t *testing.T) {
+	t.Parallel()
+
+	executor := agents.NewExecutor(&testAgent{}, agents.WithMaxIterations(1))
+
+	var events []agents.Event
+	for event := range executor.Stream(context.Background(), nil) {
+		events = append(events, event)
+	}
+
+	require.Len(t, events, 2)
+	require.Equal(t, agents.EventError, events[1].Type)
+	require.ErrorIs(t, events[1].Err, agents.ErrAgentNoReturn)
+}
@@ -0,0 +1,30 @@
+package agents
+
+import (
+	"github.com/devmiahub/langchaingo/llms"
+	"github.com/devmiahub/langchaingo/tools"
+)
+
+const _defaultSupervisorPrefix = `You are a supervisor managing a team of agents to answer the question below.
+The agents you can delegate to are:
+
+{{.tool_descriptions}}
+Delegate one task at a time by giving an agent a self-contained task as action_input.
+The agents do not see the question or each other's results, so include everything they need to know.
+Once the agents have gathered everything needed, combine their results into the final answer.`
+
+// NewSupervisorAgent creates an agent routing between the given sub-agents.
+// Each step the supervisor delegates a task to one of the sub-agents by name
+// and, once it has all the results it needs, aggregates them into the final
+// answer. Actions are emitted as JSON blobs like the ReActJSONAgent.
+//
+// Whether sub-agents share memory is decided by the Memory of the agent tools.
+func NewSupervisorAgent(llm llms.Model, subAgents []*AgentTool, opts ...Option) *ReActJSONAgent {
+	agentTools := make([]tools.Tool, 0, len(subAgents))
+	for _, subAgent := range subAgents {
+		agentTools = append(agentTools, subAgent)
+	}
+
+	options := []Option{WithPromptPrefix(_defaultSupervisorPrefix)}
+	return NewReActJSONAgent(llm, agentTools, append(options, opts...)...)
+}
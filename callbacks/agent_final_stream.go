@@ -10,6 +10,9 @@ import (
 //nolint:all
 var DefaultKeywords = []string{"Final Answer:", "Final:", "AI:"}
 
+// AgentFinalStreamHandler streams the final answer of an agent by detecting its
+// prefix keywords in the streamed chunks. Use agents.Executor.Stream to get
+// structured events for every step of a run instead.
 type AgentFinalStreamHandler struct {
 	SimpleHandler
 	egress          chan []byte
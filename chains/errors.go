@@ -39,4 +39,7 @@ var (
 	ErrMultipleOutputsInPredict = errors.New("predict is not supported with a chain that returns multiple values")
 	// ErrChainInitialization is returned if a chain is not initialized appropriately.
 	ErrChainInitialization = errors.New("error initializing chain")
+	// ErrNoDestination is returned by router chains if an input is not routed
+	// to any destination and there is no default chain.
+	ErrNoDestination = errors.New("no destination chain for input")
 )
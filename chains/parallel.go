@@ -0,0 +1,238 @@
+package chains
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/devmiahub/langchaingo/internal/setutil"
+	"github.com/devmiahub/langchaingo/memory"
+	"github.com/devmiahub/langchaingo/schema"
+)
+
+// FailurePolicy decides what a ParallelChain does when a branch fails.
+type FailurePolicy int
+
+const (
+	// FailFast cancels the other branches and returns the first error.
+	FailFast FailurePolicy = iota
+	// CollectErrors waits for all branches and returns the merged outputs of
+	// the branches that succeeded along with the errors of those that failed.
+	CollectErrors
+)
+
+// BranchError is the error of a failed branch of a ParallelChain.
+type BranchError struct {
+	// Index is the index of the branch.
+	Index int
+	// Err is the error the branch failed with.
+	Err error
+}
+
+func (e *BranchError) Error() string {
+	return fmt.Sprintf("branch %d: %s", e.Index, e.Err)
+}
+
+func (e *BranchError) Unwrap() error {
+	return e.Err
+}
+
+// ParallelChain is a chain that runs several chains concurrently on the same
+// inputs and merges their outputs into one map.
+type ParallelChain struct {
+	chains        []Chain
+	memory        schema.Memory
+	branchTimeout time.Duration
+	failurePolicy FailurePolicy
+}
+
+var _ Chain = &ParallelChain{}
+
+// NewParallelChain creates a new ParallelChain running the chains. The output
+// keys of the chains must not overlap.
+func NewParallelChain(chains []Chain, opts ...ParallelChainOption) (*ParallelChain, error) {
+	c := &ParallelChain{
+		chains: chains,
+		memory: memory.NewSimple(),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if err := c.validateParallelChain(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (c *ParallelChain) validateParallelChain() error {
+	if len(c.chains) == 0 {
+		return fmt.Errorf("%w: no chains to run in parallel", ErrChainInitialization)
+	}
+
+	knownKeys := make(map[string]struct{})
+	for i, chain := range c.chains {
+		overlappingKeys := setutil.Intersection(chain.GetOutputKeys(), knownKeys)
+		if len(overlappingKeys) > 0 {
+			return fmt.Errorf(
+				"%w: chain at index %d has output keys that already exist: %v",
+				ErrChainInitialization, i, strings.Join(overlappingKeys, delimiter),
+			)
+		}
+		for _, key := range chain.GetOutputKeys() {
+			knownKeys[key] = struct{}{}
+		}
+	}
+
+	return nil
+}
+
+type branchResult struct {
+	outputs map[string]any
+	err     error
+}
+
+// Call runs the chains concurrently and returns their merged outputs. This
+// method should not be called directly. Use rather the Call function that
+// handles the memory and other aspects of the chain.
+func (c *ParallelChain) Call(ctx context.Context, inputs map[string]any, options ...ChainCallOption) (map[string]any, error) { //nolint:lll
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([]branchResult, len(c.chains))
+	var wg sync.WaitGroup
+	for i, chain := range c.chains {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			outputs, err := c.callBranch(ctx, chain, inputs, options...)
+			if err != nil {
+				err = &BranchError{Index: i, Err: err}
+				if c.failurePolicy == FailFast {
+					cancel()
+				}
+			}
+			results[i] = branchResult{outputs: outputs, err: err}
+		}()
+	}
+	wg.Wait()
+
+	merged := make(map[string]any)
+	mergedFrom := make(map[string]int)
+	var errs []error
+	for i, result := range results {
+		if result.err != nil {
+			errs = append(errs, result.err)
+			continue
+		}
+		for key, value := range result.outputs {
+			if branch, ok := mergedFrom[key]; ok {
+				return nil, fmt.Errorf("%w: output key %s returned by branches %d and %d",
+					ErrInvalidOutputValues, key, branch, i)
+			}
+			mergedFrom[key] = i
+			merged[key] = value
+		}
+	}
+
+	if len(errs) == 0 {
+		return merged, nil
+	}
+	if c.failurePolicy == FailFast {
+		return nil, firstBranchError(errs)
+	}
+	return merged, errors.Join(errs...)
+}
+
+func (c *ParallelChain) callBranch(
+	ctx context.Context,
+	chain Chain,
+	inputs map[string]any,
+	options ...ChainCallOption,
+) (map[string]any, error) {
+	if c.branchTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.branchTimeout)
+		defer cancel()
+	}
+
+	branchInputs := make(map[string]any, len(inputs))
+	for key, value := range inputs {
+		branchInputs[key] = value
+	}
+
+	outputs, err := Call(ctx, chain, branchInputs, options...)
+	if err == nil {
+		err = ctx.Err()
+	}
+	return outputs, err
+}
+
+// firstBranchError returns the error that caused the other branches to be
+// canceled.
+func firstBranchError(errs []error) error {
+	for _, err := range errs {
+		if !errors.Is(err, context.Canceled) {
+			return err
+		}
+	}
+	return errs[0]
+}
+
+// GetMemory gets the memory of the chain.
+func (c *ParallelChain) GetMemory() schema.Memory {
+	return c.memory
+}
+
+// GetInputKeys returns the input keys of all chains.
+func (c *ParallelChain) GetInputKeys() []string {
+	keys := make([]string, 0)
+	for _, chain := range c.chains {
+		for _, key := range chain.GetInputKeys() {
+			if !slices.Contains(keys, key) {
+				keys = append(keys, key)
+			}
+		}
+	}
+	return keys
+}
+
+// GetOutputKeys returns the output keys of all chains.
+func (c *ParallelChain) GetOutputKeys() []string {
+	keys := make([]string, 0)
+	for _, chain := range c.chains {
+		keys = append(keys, chain.GetOutputKeys()...)
+	}
+	return keys
+}
+
+type ParallelChainOption func(*ParallelChain)
+
+// WithParallelChainMemory is an option for setting the memory of the chain.
+func WithParallelChainMemory(memory schema.Memory) ParallelChainOption {
+	return func(c *ParallelChain) {
+		c.memory = memory
+	}
+}
+
+// WithBranchTimeout is an option for setting the time each branch may take
+// before it is canceled and fails.
+func WithBranchTimeout(timeout time.Duration) ParallelChainOption {
+	return func(c *ParallelChain) {
+		c.branchTimeout = timeout
+	}
+}
+
+// WithFailurePolicy is an option for setting what happens when a branch fails.
+// The default is FailFast.
+func WithFailurePolicy(policy FailurePolicy) ParallelChainOption {
+	return func(c *ParallelChain) {
+		c.failurePolicy = policy
+	}
+}
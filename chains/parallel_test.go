@@ -0,0 +1,113 @@
+package chains
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func upperChain(outputKey string) Transform {
+	return NewTransform(func(_ context.Context, inputs map[string]any, _ ...ChainCallOption) (map[string]any, error) {
+		return map[string]any{outputKey: strings.ToUpper(inputs["input"].(string))}, nil
+	}, []string{"input"}, []string{outputKey})
+}
+
+func failingChain(err error) Transform {
+	return NewTransform(func(_ context.Context, _ map[string]any, _ ...ChainCallOption) (map[string]any, error) {
+		return nil, err
+	}, []string{"input"}, []string{"failed"})
+}
+
+func blockingChain(outputKey string) Transform {
+	return NewTransform(func(ctx context.Context, _ map[string]any, _ ...ChainCallOption) (map[string]any, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}, []string{"input"}, []string{outputKey})
+}
+
+func TestParallelChain(t *testing.T) {
+	t.Parallel()
+
+	lengthChain := NewTransform(func(_ context.Context, inputs map[string]any, _ ...ChainCallOption) (map[string]any, error) {
+		return map[string]any{"length": len(inputs["input"].(string))}, nil
+	}, []string{"input"}, []string{"length"})
+
+	c, err := NewParallelChain([]Chain{upperChain("upper"), lengthChain})
+	require.NoError(t, err)
+	require.Equal(t, []string{"input"}, c.GetInputKeys())
+	require.Equal(t, []string{"upper", "length"}, c.GetOutputKeys())
+
+	outputs, err := Call(context.Background(), c, map[string]any{"input": "hello"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"upper": "HELLO", "length": 5}, outputs)
+}
+
+func TestParallelChainErrors(t *testing.T) {
+	t.Parallel()
+
+	errBranch := errors.New("branch failed")
+
+	testCases := []struct {
+		name        string
+		chains      []Chain
+		opts        []ParallelChainOption
+		expected    map[string]any
+		expectedErr error
+		initErr     bool
+	}{
+		{
+			name:    "no chains",
+			chains:  []Chain{},
+			initErr: true,
+		},
+		{
+			name:    "overlapping output keys",
+			chains:  []Chain{upperChain("text"), echoChain("echo")},
+			initErr: true,
+		},
+		{
+			name:        "fail fast",
+			chains:      []Chain{blockingChain("slow"), failingChain(errBranch)},
+			expectedErr: errBranch,
+		},
+		{
+			name:        "collect errors",
+			chains:      []Chain{upperChain("upper"), failingChain(errBranch)},
+			opts:        []ParallelChainOption{WithFailurePolicy(CollectErrors)},
+			expected:    map[string]any{"upper": "HELLO"},
+			expectedErr: errBranch,
+		},
+		{
+			name:        "branch timeout",
+			chains:      []Chain{upperChain("upper"), blockingChain("slow")},
+			opts:        []ParallelChainOption{WithBranchTimeout(10 * time.Millisecond), WithFailurePolicy(CollectErrors)},
+			expected:    map[string]any{"upper": "HELLO"},
+			expectedErr: context.DeadlineExceeded,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			c, err := NewParallelChain(tc.chains, tc.opts...)
+			if tc.initErr {
+				require.ErrorIs(t, err, ErrChainInitialization)
+				return
+			}
+			require.NoError(t, err)
+
+			outputs, err := c.Call(context.Background(), map[string]any{"input": "hello"})
+			require.ErrorIs(t, err, tc.expectedErr)
+			require.Equal(t, tc.expected, outputs)
+
+			var branchErr *BranchError
+			require.ErrorAs(t, err, &branchErr)
+			require.Equal(t, 1, branchErr.Index)
+		})
+	}
+}
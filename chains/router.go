@@ -0,0 +1,395 @@
+package chains
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/devmiahub/langchaingo/callbacks"
+	"github.com/devmiahub/langchaingo/embeddings"
+	"github.com/devmiahub/langchaingo/internal/codeblock"
+	"github.com/devmiahub/langchaingo/llms"
+	"github.com/devmiahub/langchaingo/memory"
+	"github.com/devmiahub/langchaingo/prompts"
+	"github.com/devmiahub/langchaingo/schema"
+)
+
+const (
+	_routerDefaultInputKey = "input"
+	// RouterDestinationOutputKey is the key under which router chains report
+	// the name of the destination an input was routed to.
+	RouterDestinationOutputKey = "destination"
+	// DefaultDestination is the name of the route to the default chain.
+	DefaultDestination = "DEFAULT"
+	// DefaultMinSimilarity is the default similarity an input must exceed to
+	// be routed to a destination by an EmbeddingRouterChain. Suitable values
+	// depend on the embedding model.
+	DefaultMinSimilarity = 0.5
+)
+
+//nolint:lll
+const _llmRouterTemplate = `Given a raw text input to a language model select the destination best suited for the input. You will be given the names of the available destinations and a description of what each destination is best suited for. You may also revise the original input if you think that revising it will ultimately lead to a better response.
+
+<< FORMATTING >>
+Return a markdown code snippet with a JSON object formatted to look like:
+` + "```json" + `
+{
+    "destination": string \ name of the destination to use or "DEFAULT"
+    "next_inputs": string \ a potentially modified version of the original input
+}
+` + "```" + `
+
+REMEMBER: "destination" MUST be one of the candidate destination names specified below OR it can be "DEFAULT" if the input is not well suited for any of the candidate destinations.
+REMEMBER: "next_inputs" can just be the original input if you don't think any modifications are needed.
+
+<< CANDIDATE DESTINATIONS >>
+{{.destinations}}
+
+<< INPUT >>
+{{.input}}
+
+<< OUTPUT >>
+`
+
+// Destination is a named chain a router chain can dispatch to.
+type Destination struct {
+	// Name identifies the destination.
+	Name string
+	// Description describes which inputs the destination is suited for.
+	Description string
+	// Chain handles the inputs routed to the destination.
+	Chain Chain
+}
+
+// LLMRouterChain is a chain that asks an LLM to choose the destination chain
+// best suited for the input, and calls it. Inputs not suited for any
+// destination go to the default chain.
+//
+// The outputs of the destination chain are returned along with the name of
+// the destination under RouterDestinationOutputKey, which callbacks also
+// receive in HandleChainEnd. The chain thus has several outputs, so use Call
+// rather than Run.
+type LLMRouterChain struct {
+	LLM              llms.Model
+	Prompt           prompts.FormatPrompter
+	Destinations     []Destination
+	DefaultChain     Chain
+	Memory           schema.Memory
+	CallbacksHandler callbacks.Handler
+
+	// InputKey is the input routed. The destination chain receives the input
+	// possibly revised by the LLM under the same key.
+	InputKey string
+}
+
+var (
+	_ Chain                  = &LLMRouterChain{}
+	_ callbacks.HandlerHaver = &LLMRouterChain{}
+)
+
+// NewLLMRouterChain creates a new LLMRouterChain routing between the
+// destinations. The default chain may be nil, in which case inputs not suited
+// for any destination result in ErrNoDestination.
+func NewLLMRouterChain(
+	llm llms.Model,
+	destinations []Destination,
+	defaultChain Chain,
+	opts ...ChainCallOption,
+) *LLMRouterChain {
+	opt := &chainCallOption{}
+	for _, o := range opts {
+		o(opt)
+	}
+
+	return &LLMRouterChain{
+		LLM:              llm,
+		Prompt:           prompts.NewPromptTemplate(_llmRouterTemplate, []string{"destinations", _routerDefaultInputKey}),
+		Destinations:     destinations,
+		DefaultChain:     defaultChain,
+		Memory:           memory.NewSimple(),
+		CallbacksHandler: opt.CallbackHandler,
+		InputKey:         _routerDefaultInputKey,
+	}
+}
+
+// Call asks the LLM for the destination of the input and calls it.
+func (c *LLMRouterChain) Call(ctx context.Context, values map[string]any, options ...ChainCallOption) (map[string]any, error) { //nolint:lll
+	input, ok := values[c.InputKey].(string)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrInputValuesWrongType, c.InputKey)
+	}
+
+	promptValue, err := c.Prompt.FormatPrompt(map[string]any{
+		"destinations":         formatDestinations(c.Destinations),
+		_routerDefaultInputKey: input,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := llms.GenerateFromSinglePrompt(ctx, c.LLM, promptValue.String(), getLLMCallOptions(options...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	route, err := parseRoute(output)
+	if err != nil {
+		return nil, err
+	}
+	if route.NextInputs == "" {
+		route.NextInputs = input
+	}
+
+	inputs := make(map[string]any, len(values))
+	for key, value := range values {
+		inputs[key] = value
+	}
+	inputs[c.InputKey] = route.NextInputs
+
+	return callDestination(ctx, route.Destination, c.Destinations, c.DefaultChain, inputs, options...)
+}
+
+// GetMemory returns the memory.
+func (c *LLMRouterChain) GetMemory() schema.Memory { //nolint:ireturn
+	return c.Memory
+}
+
+func (c *LLMRouterChain) GetCallbackHandler() callbacks.Handler { //nolint:ireturn
+	return c.CallbacksHandler
+}
+
+// GetInputKeys returns the expected input keys.
+func (c *LLMRouterChain) GetInputKeys() []string {
+	return []string{c.InputKey}
+}
+
+// GetOutputKeys returns the output keys all destinations have in common and
+// RouterDestinationOutputKey.
+func (c *LLMRouterChain) GetOutputKeys() []string {
+	return commonOutputKeys(c.Destinations, c.DefaultChain)
+}
+
+// EmbeddingRouterChain is a chain that routes the input to the destination
+// whose description is the most similar to the input, as measured by the
+// cosine similarity of their embeddings. Inputs not more similar than
+// MinSimilarity to any destination go to the default chain.
+//
+// The outputs of the destination chain are returned along with the name of
+// the destination under RouterDestinationOutputKey, which callbacks also
+// receive in HandleChainEnd. The chain thus has several outputs, so use Call
+// rather than Run.
+type EmbeddingRouterChain struct {
+	Embedder         embeddings.Embedder
+	Destinations     []Destination
+	DefaultChain     Chain
+	Memory           schema.Memory
+	CallbacksHandler callbacks.Handler
+
+	// InputKey is the input routed.
+	InputKey string
+	// MinSimilarity is the similarity to a destination an input must exceed
+	// to be routed to it. Defaults to DefaultMinSimilarity.
+	MinSimilarity float32
+
+	vectors [][]float32
+}
+
+var (
+	_ Chain                  = &EmbeddingRouterChain{}
+	_ callbacks.HandlerHaver = &EmbeddingRouterChain{}
+)
+
+// EmbeddingRouterChainOption is an option for NewEmbeddingRouterChain.
+type EmbeddingRouterChainOption func(*EmbeddingRouterChain)
+
+// WithMinSimilarity is an option for setting the similarity to a destination
+// an input must exceed to be routed to it.
+func WithMinSimilarity(minSimilarity float32) EmbeddingRouterChainOption {
+	return func(c *EmbeddingRouterChain) {
+		c.MinSimilarity = minSimilarity
+	}
+}
+
+// WithEmbeddingRouterCallback is an option for setting the callback handler
+// of the chain.
+func WithEmbeddingRouterCallback(handler callbacks.Handler) EmbeddingRouterChainOption {
+	return func(c *EmbeddingRouterChain) {
+		c.CallbacksHandler = handler
+	}
+}
+
+// NewEmbeddingRouterChain creates a new EmbeddingRouterChain routing between
+// the destinations. The descriptions of the destinations are embedded once
+// when creating the chain. The default chain may be nil, in which case inputs
+// not similar enough to any destination result in ErrNoDestination.
+func NewEmbeddingRouterChain(
+	ctx context.Context,
+	embedder embeddings.Embedder,
+	destinations []Destination,
+	defaultChain Chain,
+	opts ...EmbeddingRouterChainOption,
+) (*EmbeddingRouterChain, error) {
+	descriptions := make([]string, 0, len(destinations))
+	for _, destination := range destinations {
+		descriptions = append(descriptions, destination.Description)
+	}
+	vectors, err := embedder.EmbedDocuments(ctx, descriptions)
+	if err != nil {
+		return nil, err
+	}
+	if len(vectors) != len(destinations) {
+		return nil, fmt.Errorf("%w: got %d embeddings for %d destinations",
+			ErrChainInitialization, len(vectors), len(destinations))
+	}
+
+	c := &EmbeddingRouterChain{
+		Embedder:      embedder,
+		Destinations:  destinations,
+		DefaultChain:  defaultChain,
+		Memory:        memory.NewSimple(),
+		InputKey:      _routerDefaultInputKey,
+		MinSimilarity: DefaultMinSimilarity,
+		vectors:       vectors,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+// Call embeds the input and calls the most similar destination.
+func (c *EmbeddingRouterChain) Call(ctx context.Context, values map[string]any, options ...ChainCallOption) (map[string]any, error) { //nolint:lll
+	input, ok := values[c.InputKey].(string)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrInputValuesWrongType, c.InputKey)
+	}
+
+	vector, err := c.Embedder.EmbedQuery(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	destination := DefaultDestination
+	best := c.MinSimilarity
+	for i, destinationVector := range c.vectors {
+		similarity, err := embeddings.CosineSimilarity(vector, destinationVector)
+		if err != nil {
+			return nil, err
+		}
+		if similarity > best {
+			best = similarity
+			destination = c.Destinations[i].Name
+		}
+	}
+
+	return callDestination(ctx, destination, c.Destinations, c.DefaultChain, values, options...)
+}
+
+// GetMemory returns the memory.
+func (c *EmbeddingRouterChain) GetMemory() schema.Memory { //nolint:ireturn
+	return c.Memory
+}
+
+func (c *EmbeddingRouterChain) GetCallbackHandler() callbacks.Handler { //nolint:ireturn
+	return c.CallbacksHandler
+}
+
+// GetInputKeys returns the expected input keys.
+func (c *EmbeddingRouterChain) GetInputKeys() []string {
+	return []string{c.InputKey}
+}
+
+// GetOutputKeys returns the output keys all destinations have in common and
+// RouterDestinationOutputKey.
+func (c *EmbeddingRouterChain) GetOutputKeys() []string {
+	return commonOutputKeys(c.Destinations, c.DefaultChain)
+}
+
+type route struct {
+	Destination string `json:"destination"`
+	NextInputs  string `json:"next_inputs"`
+}
+
+func parseRoute(output string) (route, error) {
+	text, ok := codeblock.Object(output)
+	if !ok {
+		return route{}, fmt.Errorf("%w: no JSON object in router output %q", ErrInvalidOutputValues, output)
+	}
+
+	var r route
+	if err := json.Unmarshal([]byte(text), &r); err != nil {
+		return route{}, fmt.Errorf("%w: parsing router output %q: %w", ErrInvalidOutputValues, output, err)
+	}
+	return r, nil
+}
+
+func callDestination(
+	ctx context.Context,
+	name string,
+	destinations []Destination,
+	defaultChain Chain,
+	inputs map[string]any,
+	options ...ChainCallOption,
+) (map[string]any, error) {
+	chain, found := defaultChain, false
+	for _, destination := range destinations {
+		if destination.Name == name {
+			chain, found = destination.Chain, true
+			break
+		}
+	}
+	if !found {
+		name = DefaultDestination
+	}
+	if chain == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNoDestination, name)
+	}
+
+	outputs, err := Call(ctx, chain, inputs, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]any, len(outputs)+1)
+	for key, value := range outputs {
+		result[key] = value
+	}
+	result[RouterDestinationOutputKey] = name
+	return result, nil
+}
+
+func formatDestinations(destinations []Destination) string {
+	var b strings.Builder
+	for _, destination := range destinations {
+		fmt.Fprintf(&b, "%s: %s\n", destination.Name, destination.Description)
+	}
+	return b.String()
+}
+
+// commonOutputKeys returns the output keys all destinations have in common,
+// along with RouterDestinationOutputKey, which callDestination adds.
+func commonOutputKeys(destinations []Destination, defaultChain Chain) []string {
+	chains := make([]Chain, 0, len(destinations)+1)
+	for _, destination := range destinations {
+		chains = append(chains, destination.Chain)
+	}
+	if defaultChain != nil {
+		chains = append(chains, defaultChain)
+	}
+	if len(chains) == 0 {
+		return []string{RouterDestinationOutputKey}
+	}
+
+	keys := slices.Clone(chains[0].GetOutputKeys())
+	for _, chain := range chains[1:] {
+		keys = slices.DeleteFunc(keys, func(key string) bool {
+			return !slices.Contains(chain.GetOutputKeys(), key)
+		})
+	}
+	if !slices.Contains(keys, RouterDestinationOutputKey) {
+		keys = append(keys, RouterDestinationOutputKey)
+	}
+	return keys
+}
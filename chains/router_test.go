@@ -0,0 +1,137 @@
+package chains
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// keywordEmbedder embeds texts as the counts of a fixed set of keywords.
+type keywordEmbedder struct {
+	keywords []string
+}
+
+func (e keywordEmbedder) EmbedDocuments(ctx context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, 0, len(texts))
+	for _, text := range texts {
+		vector, err := e.EmbedQuery(ctx, text)
+		if err != nil {
+			return nil, err
+		}
+		vectors = append(vectors, vector)
+	}
+	return vectors, nil
+}
+
+func (e keywordEmbedder) EmbedQuery(_ context.Context, text string) ([]float32, error) {
+	vector := make([]float32, len(e.keywords))
+	for i, keyword := range e.keywords {
+		vector[i] = float32(strings.Count(strings.ToLower(text), keyword))
+	}
+	return vector, nil
+}
+
+func echoChain(name string) Transform {
+	return NewTransform(func(_ context.Context, inputs map[string]any, _ ...ChainCallOption) (map[string]any, error) {
+		return map[string]any{"text": name + ": " + inputs["input"].(string)}, nil
+	}, []string{"input"}, []string{"text"})
+}
+
+func testDestinations() []Destination {
+	return []Destination{
+		{Name: "physics", Description: "Good for answering questions about physics", Chain: echoChain("physics")},
+		{Name: "math", Description: "Good for answering questions about math", Chain: echoChain("math")},
+	}
+}
+
+func TestLLMRouterChain(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	llm := &testLanguageModel{
+		expResult: "```json\n{\"destination\": \"math\", \"next_inputs\": \"What is 2 + 2?\"}\n```",
+	}
+	c := NewLLMRouterChain(llm, testDestinations(), echoChain("default"))
+
+	outputs, err := Call(ctx, c, map[string]any{"input": "what's two plus two"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"text": "math: What is 2 + 2?", RouterDestinationOutputKey: "math"}, outputs)
+	require.Contains(t, llm.recordedPrompt[0].String(), "physics: Good for answering questions about physics")
+	require.Equal(t, []string{"text", RouterDestinationOutputKey}, c.GetOutputKeys())
+
+	// The destination is an output along with those of the destinations.
+	_, err = Run(ctx, c, "what's two plus two")
+	require.ErrorIs(t, err, ErrMultipleOutputsInRun)
+}
+
+func TestLLMRouterChainDefault(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	llm := &testLanguageModel{expResult: `{"destination": "DEFAULT", "next_inputs": ""}`}
+	c := NewLLMRouterChain(llm, testDestinations(), echoChain("default"))
+
+	outputs, err := Call(ctx, c, map[string]any{"input": "hello"})
+	require.NoError(t, err)
+	require.Equal(t, "default: hello", outputs["text"])
+	require.Equal(t, DefaultDestination, outputs[RouterDestinationOutputKey])
+
+	c.DefaultChain = nil
+	_, err = Call(ctx, c, map[string]any{"input": "hello"})
+	require.ErrorIs(t, err, ErrNoDestination)
+
+	llm.expResult = "No destination fits."
+	_, err = Call(ctx, c, map[string]any{"input": "hello"})
+	require.ErrorIs(t, err, ErrInvalidOutputValues)
+}
+
+func TestEmbeddingRouterChain(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	embedder := keywordEmbedder{keywords: []string{"physics", "math"}}
+	c, err := NewEmbeddingRouterChain(ctx, embedder, testDestinations(), echoChain("default"))
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"text", RouterDestinationOutputKey}, c.GetOutputKeys())
+
+	outputs, err := Call(ctx, c, map[string]any{"input": "a physics question"})
+	require.NoError(t, err)
+	require.Equal(t, "physics", outputs[RouterDestinationOutputKey])
+	require.Equal(t, "physics: a physics question", outputs["text"])
+
+	outputs, err = Call(ctx, c, map[string]any{"input": "what should I cook"})
+	require.NoError(t, err)
+	require.Equal(t, DefaultDestination, outputs[RouterDestinationOutputKey])
+}
+
+func TestEmbeddingRouterChainMinSimilarity(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	embedder := keywordEmbedder{keywords: []string{"physics", "math", "cook"}}
+	c, err := NewEmbeddingRouterChain(ctx, embedder, testDestinations(), echoChain("default"))
+	require.NoError(t, err)
+	require.InDelta(t, DefaultMinSimilarity, c.MinSimilarity, 0)
+
+	// The input has a similarity of about 0.32 to physics, below the default
+	// minimum similarity.
+	outputs, err := Call(ctx, c, map[string]any{"input": "cook like in physics, cook and cook"})
+	require.NoError(t, err)
+	require.Equal(t, DefaultDestination, outputs[RouterDestinationOutputKey])
+	require.Equal(t, "default: cook like in physics, cook and cook", outputs["text"])
+
+	// The input has a similarity of about 0.71 to both destinations.
+	outputs, err = Call(ctx, c, map[string]any{"input": "physics and math"})
+	require.NoError(t, err)
+	require.Equal(t, "physics", outputs[RouterDestinationOutputKey])
+
+	c, err = NewEmbeddingRouterChain(ctx, embedder, testDestinations(), echoChain("default"),
+		WithMinSimilarity(0.8))
+	require.NoError(t, err)
+	outputs, err = Call(ctx, c, map[string]any{"input": "physics and math"})
+	require.NoError(t, err)
+	require.Equal(t, DefaultDestination, outputs[RouterDestinationOutputKey])
+}
@@ -81,3 +81,22 @@ func getNorm(v []float32) float32 {
 
 	return float32(math.Sqrt(float64(sum)))
 }
+
+// CosineSimilarity returns the cosine similarity of two vectors of the same
+// size. It returns 0 if either vector has a norm of zero.
+func CosineSimilarity(a, b []float32) (float32, error) {
+	if len(a) != len(b) {
+		return 0, ErrVectorsNotSameSize
+	}
+
+	var dot float32
+	for i := range a {
+		dot += a[i] * b[i]
+	}
+
+	norms := getNorm(a) * getNorm(b)
+	if norms == 0 {
+		return 0, nil
+	}
+	return dot / norms, nil
+}
@@ -108,3 +108,34 @@ func TestGetNorm(t *testing.T) {
 		})
 	}
 }
+
+func TestCosineSimilarity(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		a, b     []float32
+		expected float32
+	}{
+		{name: "same direction", a: []float32{1, 2}, b: []float32{2, 4}, expected: 1},
+		{name: "orthogonal", a: []float32{1, 0}, b: []float32{0, 1}, expected: 0},
+		{name: "opposite", a: []float32{1, 1}, b: []float32{-1, -1}, expected: -1},
+		{name: "zero vector", a: []float32{0, 0}, b: []float32{1, 1}, expected: 0},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := CosineSimilarity(tc.a, tc.b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if delta := math.Abs(float64(tc.expected - got)); delta > 0.0001 {
+				t.Errorf("CosineSimilarity(%v, %v) = %v, want %v", tc.a, tc.b, got, tc.expected)
+			}
+		})
+	}
+
+	if _, err := CosineSimilarity([]float32{1}, []float32{1, 2}); err == nil {
+		t.Error("expected error for vectors of different sizes")
+	}
+}
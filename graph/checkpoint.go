@@ -0,0 +1,107 @@
+package graph
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/devmiahub/langchaingo/internal/checkpointstore"
+)
+
+// Checkpoint is the state of a graph run persisted after each step.
+type Checkpoint[S any] struct {
+	// RunID identifies the run.
+	RunID string `json:"run_id"`
+	// Step is the number of completed steps.
+	Step int `json:"step"`
+	// State is the state after the completed steps.
+	State S `json:"state"`
+	// Next are the nodes of the next step.
+	Next []string `json:"next"`
+	// Interrupted is true if the run is paused before the next step.
+	Interrupted bool `json:"interrupted,omitempty"`
+	// UpdatedAt is the time the checkpoint was saved.
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Checkpointer is the interface for persisting the state of graph runs so
+// that they can be resumed.
+type Checkpointer[S any] interface {
+	// Save stores the checkpoint, replacing any previous checkpoint of the run.
+	Save(ctx context.Context, checkpoint Checkpoint[S]) error
+	// Load returns the last checkpoint of a run. If the run has no checkpoint
+	// ErrCheckpointNotFound is returned.
+	Load(ctx context.Context, runID string) (*Checkpoint[S], error)
+	// Delete removes the checkpoint of a run.
+	Delete(ctx context.Context, runID string) error
+}
+
+// MemoryCheckpointer is a checkpointer that keeps checkpoints in memory. The
+// state is stored as is, so nodes must not modify states in place.
+type MemoryCheckpointer[S any] struct {
+	store *checkpointstore.Memory[Checkpoint[S]]
+}
+
+// NewMemoryCheckpointer creates a new in-memory checkpointer.
+func NewMemoryCheckpointer[S any]() *MemoryCheckpointer[S] {
+	return &MemoryCheckpointer[S]{
+		store: checkpointstore.NewMemory(copyCheckpoint[S], ErrCheckpointNotFound),
+	}
+}
+
+// Save stores the checkpoint.
+func (c *MemoryCheckpointer[S]) Save(_ context.Context, checkpoint Checkpoint[S]) error {
+	c.store.Save(checkpoint.RunID, checkpoint)
+	return nil
+}
+
+// Load returns the last checkpoint of a run.
+func (c *MemoryCheckpointer[S]) Load(_ context.Context, runID string) (*Checkpoint[S], error) {
+	return c.store.Load(runID)
+}
+
+// Delete removes the checkpoint of a run.
+func (c *MemoryCheckpointer[S]) Delete(_ context.Context, runID string) error {
+	c.store.Delete(runID)
+	return nil
+}
+
+func copyCheckpoint[S any](checkpoint Checkpoint[S]) Checkpoint[S] {
+	checkpoint.Next = append([]string(nil), checkpoint.Next...)
+	return checkpoint
+}
+
+// FileCheckpointer is a checkpointer that stores each run as a JSON file in a
+// directory. The state must be JSON serializable.
+type FileCheckpointer[S any] struct {
+	// Dir is the directory the checkpoint files are written to.
+	Dir string
+}
+
+// NewFileCheckpointer creates a new checkpointer storing checkpoints in dir.
+// The directory is created if it does not exist.
+func NewFileCheckpointer[S any](dir string) (FileCheckpointer[S], error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return FileCheckpointer[S]{}, err
+	}
+	return FileCheckpointer[S]{Dir: dir}, nil
+}
+
+// Save writes the checkpoint to the file of the run, replacing it atomically.
+func (c FileCheckpointer[S]) Save(_ context.Context, checkpoint Checkpoint[S]) error {
+	return c.store().Save(checkpoint.RunID, checkpoint)
+}
+
+// Load reads the checkpoint of a run.
+func (c FileCheckpointer[S]) Load(_ context.Context, runID string) (*Checkpoint[S], error) {
+	return c.store().Load(runID)
+}
+
+// Delete removes the checkpoint file of a run.
+func (c FileCheckpointer[S]) Delete(_ context.Context, runID string) error {
+	return c.store().Delete(runID)
+}
+
+func (c FileCheckpointer[S]) store() checkpointstore.File[Checkpoint[S]] {
+	return checkpointstore.NewFile[Checkpoint[S]](c.Dir, ErrCheckpointNotFound, ErrInvalidRunID)
+}
@@ -0,0 +1,19 @@
+// Package graph provides a workflow engine running a graph of nodes over a
+// typed state.
+//
+// Nodes are plain Go functions from state to state. Chains, including agent
+// executors, are turned into nodes with ChainNode or MapChainNode. Edges
+// between nodes are either static or conditional, decided by a function of the
+// state. Graphs may contain cycles, which are bounded by a maximum number of
+// steps.
+//
+// A graph runs in steps. Each step runs all active nodes concurrently on the
+// state produced by the previous step and merges their results. The nodes
+// reached by the edges of the active nodes make up the next step, so a node
+// with several outgoing edges fans out into concurrent branches that fan back
+// in when they reach a common node in the same step. A run finishes when no
+// node is active anymore, which happens once all branches reach End.
+//
+// With a Checkpointer the state is persisted after each step, so runs can be
+// paused before selected nodes and resumed later, also from another process.
+package graph
@@ -0,0 +1,42 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrInvalidGraph is returned by Compile if the graph is not valid.
+	ErrInvalidGraph = errors.New("invalid graph")
+	// ErrMaxStepsExceeded is returned if a run does not finish within the maximum number of steps.
+	ErrMaxStepsExceeded = errors.New("graph did not finish before max steps")
+	// ErrUnknownNode is returned if a conditional edge leads to a node that does not exist.
+	ErrUnknownNode = errors.New("unknown node")
+	// ErrNoCheckpointer is returned when resuming a run of a graph without a checkpointer.
+	ErrNoCheckpointer = errors.New("graph has no checkpointer")
+	// ErrCheckpointNotFound is returned by checkpointers if a run has no checkpoint.
+	ErrCheckpointNotFound = errors.New("checkpoint not found")
+	// ErrInvalidRunID is returned if a run id cannot be used by a checkpointer.
+	ErrInvalidRunID = errors.New("invalid run id")
+	// ErrInterrupted is returned if a run is paused before a node.
+	ErrInterrupted = errors.New("graph run interrupted")
+)
+
+// InterruptError is the error returned when a run is paused before one of the
+// nodes given to WithInterruptBefore. It matches ErrInterrupted with errors.Is.
+type InterruptError struct {
+	// RunID is the id to resume the run with.
+	RunID string
+	// Next are the nodes that run when the run is resumed.
+	Next []string
+}
+
+func (e *InterruptError) Error() string {
+	return fmt.Sprintf("%s: run %s before %s", ErrInterrupted, e.RunID, strings.Join(e.Next, ", "))
+}
+
+// Is reports whether target is ErrInterrupted.
+func (e *InterruptError) Is(target error) bool {
+	return target == ErrInterrupted //nolint:errorlint
+}
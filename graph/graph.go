@@ -0,0 +1,162 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// End is the name of the virtual node a branch of the graph ends in.
+const End = "__end__"
+
+// NodeFunc is a node of the graph. It returns the state after running the
+// node. Nodes running concurrently get the same state and must not modify it
+// in place.
+type NodeFunc[S any] func(ctx context.Context, state S) (S, error)
+
+// RouterFunc decides the node to continue with after a node with a
+// conditional edge. It may return End.
+type RouterFunc[S any] func(ctx context.Context, state S) (string, error)
+
+// MergeFunc merges the states returned by the nodes run concurrently in a step
+// into the state of the next step. The states are in the order the nodes were
+// added to the graph.
+type MergeFunc[S any] func(ctx context.Context, previous S, results []S) (S, error)
+
+// Graph is a builder for a workflow of nodes over a state of type S. Errors
+// made while building the graph are reported by Compile.
+type Graph[S any] struct {
+	nodes       map[string]NodeFunc[S]
+	order       []string
+	edges       map[string][]string
+	routers     map[string]RouterFunc[S]
+	entryPoint  string
+	buildErrors []error
+}
+
+// New creates a new empty graph.
+func New[S any]() *Graph[S] {
+	return &Graph[S]{
+		nodes:   make(map[string]NodeFunc[S]),
+		edges:   make(map[string][]string),
+		routers: make(map[string]RouterFunc[S]),
+	}
+}
+
+// AddNode adds a node with the given name.
+func (g *Graph[S]) AddNode(name string, node NodeFunc[S]) *Graph[S] {
+	switch {
+	case name == "" || name == End:
+		g.buildErrors = append(g.buildErrors, fmt.Errorf("node name %q is reserved", name))
+	case g.nodes[name] != nil:
+		g.buildErrors = append(g.buildErrors, fmt.Errorf("node %q added twice", name))
+	case node == nil:
+		g.buildErrors = append(g.buildErrors, fmt.Errorf("node %q is nil", name))
+	default:
+		g.nodes[name] = node
+		g.order = append(g.order, name)
+	}
+	return g
+}
+
+// AddEdge adds an edge from one node to another node or End. A node with
+// several edges fans out into concurrent branches.
+func (g *Graph[S]) AddEdge(from, to string) *Graph[S] {
+	if !slices.Contains(g.edges[from], to) {
+		g.edges[from] = append(g.edges[from], to)
+	}
+	return g
+}
+
+// AddConditionalEdge adds an edge from a node to the node returned by the
+// router. A node can have either a conditional edge or static edges.
+func (g *Graph[S]) AddConditionalEdge(from string, router RouterFunc[S]) *Graph[S] {
+	if g.routers[from] != nil {
+		g.buildErrors = append(g.buildErrors, fmt.Errorf("node %q has two conditional edges", from))
+	}
+	g.routers[from] = router
+	return g
+}
+
+// SetEntryPoint sets the node runs start with.
+func (g *Graph[S]) SetEntryPoint(name string) *Graph[S] {
+	g.entryPoint = name
+	return g
+}
+
+// Compile validates the graph and returns a runnable workflow.
+func (g *Graph[S]) Compile(opts ...Option[S]) (*Runnable[S], error) {
+	options := defaultOptions[S]()
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if err := g.validate(options); err != nil {
+		return nil, err
+	}
+
+	edges := make(map[string][]string, len(g.edges))
+	for from, to := range g.edges {
+		edges[from] = slices.Clone(to)
+	}
+	routers := make(map[string]RouterFunc[S], len(g.routers))
+	for from, router := range g.routers {
+		routers[from] = router
+	}
+	nodes := make(map[string]NodeFunc[S], len(g.nodes))
+	for name, node := range g.nodes {
+		nodes[name] = node
+	}
+
+	return &Runnable[S]{
+		nodes:      nodes,
+		order:      slices.Clone(g.order),
+		edges:      edges,
+		routers:    routers,
+		entryPoint: g.entryPoint,
+		options:    options,
+	}, nil
+}
+
+func (g *Graph[S]) validate(options Options[S]) error {
+	if len(g.buildErrors) > 0 {
+		return fmt.Errorf("%w: %w", ErrInvalidGraph, g.buildErrors[0])
+	}
+	if g.nodes[g.entryPoint] == nil {
+		return fmt.Errorf("%w: entry point %q is not a node", ErrInvalidGraph, g.entryPoint)
+	}
+
+	for from, to := range g.edges {
+		if g.nodes[from] == nil {
+			return fmt.Errorf("%w: edge from unknown node %q", ErrInvalidGraph, from)
+		}
+		if g.routers[from] != nil {
+			return fmt.Errorf("%w: node %q has both static and conditional edges", ErrInvalidGraph, from)
+		}
+		for _, name := range to {
+			if name != End && g.nodes[name] == nil {
+				return fmt.Errorf("%w: edge to unknown node %q", ErrInvalidGraph, name)
+			}
+		}
+		if len(to) > 1 && options.merge == nil {
+			return fmt.Errorf("%w: node %q fans out but no merge function is set", ErrInvalidGraph, from)
+		}
+	}
+	for from := range g.routers {
+		if g.nodes[from] == nil {
+			return fmt.Errorf("%w: conditional edge from unknown node %q", ErrInvalidGraph, from)
+		}
+	}
+	for _, name := range g.order {
+		if len(g.edges[name]) == 0 && g.routers[name] == nil {
+			return fmt.Errorf("%w: node %q has no outgoing edge", ErrInvalidGraph, name)
+		}
+	}
+	for _, name := range options.interruptBefore {
+		if g.nodes[name] == nil {
+			return fmt.Errorf("%w: interrupt before unknown node %q", ErrInvalidGraph, name)
+		}
+	}
+
+	return nil
+}
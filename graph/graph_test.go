@@ -0,0 +1,252 @@
+package graph_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/devmiahub/langchaingo/chains"
+	"github.com/devmiahub/langchaingo/graph"
+	"github.com/stretchr/testify/require"
+)
+
+type counter struct {
+	Count int      `json:"count"`
+	Log   []string `json:"log"`
+}
+
+func increment(name string) graph.NodeFunc[counter] {
+	return func(_ context.Context, state counter) (counter, error) {
+		return counter{
+			Count: state.Count + 1,
+			Log:   append(append([]string(nil), state.Log...), name),
+		}, nil
+	}
+}
+
+func loopUntil(limit int) graph.RouterFunc[counter] {
+	return func(_ context.Context, state counter) (string, error) {
+		if state.Count >= limit {
+			return graph.End, nil
+		}
+		return "loop", nil
+	}
+}
+
+func TestGraphCycle(t *testing.T) {
+	t.Parallel()
+
+	g, err := graph.New[counter]().
+		AddNode("start", increment("start")).
+		AddNode("loop", increment("loop")).
+		AddEdge("start", "loop").
+		AddConditionalEdge("loop", loopUntil(3)).
+		SetEntryPoint("start").
+		Compile()
+	require.NoError(t, err)
+
+	state, err := g.Run(context.Background(), counter{})
+	require.NoError(t, err)
+	require.Equal(t, counter{Count: 3, Log: []string{"start", "loop", "loop"}}, state)
+}
+
+func TestGraphMaxSteps(t *testing.T) {
+	t.Parallel()
+
+	g, err := graph.New[counter]().
+		AddNode("loop", increment("loop")).
+		AddConditionalEdge("loop", loopUntil(100)).
+		SetEntryPoint("loop").
+		Compile(graph.WithMaxSteps[counter](5))
+	require.NoError(t, err)
+
+	state, err := g.Run(context.Background(), counter{})
+	require.ErrorIs(t, err, graph.ErrMaxStepsExceeded)
+	require.Equal(t, 5, state.Count)
+}
+
+func TestGraphFanOutFanIn(t *testing.T) {
+	t.Parallel()
+
+	set := func(key, value string) graph.NodeFunc[map[string]any] {
+		return func(_ context.Context, state map[string]any) (map[string]any, error) {
+			result := map[string]any{key: value}
+			for k, v := range state {
+				result[k] = v
+			}
+			return result, nil
+		}
+	}
+	join := func(_ context.Context, state map[string]any) (map[string]any, error) {
+		return map[string]any{"joined": state["a"].(string) + state["b"].(string)}, nil
+	}
+
+	g, err := graph.New[map[string]any]().
+		AddNode("split", set("split", "done")).
+		AddNode("a", set("a", "A")).
+		AddNode("b", set("b", "B")).
+		AddNode("join", join).
+		AddEdge("split", "a").
+		AddEdge("split", "b").
+		AddEdge("a", "join").
+		AddEdge("b", "join").
+		AddEdge("join", graph.End).
+		SetEntryPoint("split").
+		Compile(graph.WithMerge(graph.MergeMaps))
+	require.NoError(t, err)
+
+	state, err := g.Run(context.Background(), map[string]any{})
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"joined": "AB"}, state)
+}
+
+func TestGraphMergeConflict(t *testing.T) {
+	t.Parallel()
+
+	_, err := graph.MergeMaps(context.Background(),
+		map[string]any{"x": 1},
+		[]map[string]any{{"x": 2}, {"x": 3}},
+	)
+	require.Error(t, err)
+}
+
+func TestGraphValidation(t *testing.T) {
+	t.Parallel()
+
+	noop := func(_ context.Context, state int) (int, error) { return state, nil }
+
+	tests := map[string]*graph.Graph[int]{
+		"no entry point":   graph.New[int]().AddNode("a", noop).AddEdge("a", graph.End),
+		"unknown target":   graph.New[int]().AddNode("a", noop).AddEdge("a", "b").SetEntryPoint("a"),
+		"no outgoing edge": graph.New[int]().AddNode("a", noop).SetEntryPoint("a"),
+		"reserved name":    graph.New[int]().AddNode(graph.End, noop),
+		"fan out no merge": graph.New[int]().AddNode("a", noop).AddEdge("a", graph.End).AddEdge("a", "a").SetEntryPoint("a"),
+		"duplicate node":   graph.New[int]().AddNode("a", noop).AddNode("a", noop),
+	}
+	for name, g := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			_, err := g.Compile()
+			require.ErrorIs(t, err, graph.ErrInvalidGraph)
+		})
+	}
+}
+
+func TestGraphInterruptAndResume(t *testing.T) {
+	t.Parallel()
+
+	checkpointer, err := graph.NewFileCheckpointer[counter](t.TempDir())
+	require.NoError(t, err)
+
+	build := func() *graph.Runnable[counter] {
+		g, err := graph.New[counter]().
+			AddNode("draft", increment("draft")).
+			AddNode("publish", increment("publish")).
+			AddEdge("draft", "publish").
+			AddEdge("publish", graph.End).
+			SetEntryPoint("draft").
+			Compile(
+				graph.WithCheckpointer[counter](checkpointer),
+				graph.WithInterruptBefore[counter]("publish"),
+			)
+		require.NoError(t, err)
+		return g
+	}
+
+	ctx := context.Background()
+	_, err = build().Run(ctx, counter{}, graph.WithRunID("run"))
+	var interrupt *graph.InterruptError
+	require.ErrorAs(t, err, &interrupt)
+	require.ErrorIs(t, err, graph.ErrInterrupted)
+	require.Equal(t, []string{"publish"}, interrupt.Next)
+
+	// Resume in a fresh graph, as another process would.
+	g := build()
+	require.NoError(t, g.UpdateState(ctx, "run", func(state counter) (counter, error) {
+		state.Log = append(state.Log, "approved")
+		return state, nil
+	}))
+
+	state, err := g.Resume(ctx, "run")
+	require.NoError(t, err)
+	require.Equal(t, counter{Count: 2, Log: []string{"draft", "approved", "publish"}}, state)
+
+	_, err = g.GetState(ctx, "run")
+	require.ErrorIs(t, err, graph.ErrCheckpointNotFound)
+}
+
+func TestGraphResumesAfterFailure(t *testing.T) {
+	t.Parallel()
+
+	fail := true
+	flaky := func(ctx context.Context, state counter) (counter, error) {
+		if fail {
+			return state, errors.New("deploy in progress")
+		}
+		return increment("flaky")(ctx, state)
+	}
+
+	g, err := graph.New[counter]().
+		AddNode("first", increment("first")).
+		AddNode("flaky", flaky).
+		AddEdge("first", "flaky").
+		AddEdge("flaky", graph.End).
+		SetEntryPoint("first").
+		Compile(graph.WithCheckpointer[counter](graph.NewMemoryCheckpointer[counter]()))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	state, err := g.Run(ctx, counter{}, graph.WithRunID("run"))
+	require.ErrorContains(t, err, "node flaky")
+	require.Equal(t, 1, state.Count)
+
+	fail = false
+	state, err = g.Resume(ctx, "run")
+	require.NoError(t, err)
+	require.Equal(t, []string{"first", "flaky"}, state.Log)
+}
+
+func TestGraphUnknownNodeInCheckpoint(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	called := false
+	checkpointer := graph.NewMemoryCheckpointer[counter]()
+	g, err := graph.New[counter]().
+		AddNode("a", func(_ context.Context, state counter) (counter, error) {
+			called = true
+			return state, nil
+		}).
+		AddEdge("a", graph.End).
+		SetEntryPoint("a").
+		Compile(graph.WithCheckpointer[counter](checkpointer), graph.WithMerge(graph.MergeFunc[counter](
+			func(_ context.Context, state counter, _ []counter) (counter, error) { return state, nil },
+		)))
+	require.NoError(t, err)
+
+	// No node starts when one of the nodes of the step is unknown.
+	require.NoError(t, checkpointer.Save(ctx, graph.Checkpoint[counter]{RunID: "run", Next: []string{"a", "removed"}}))
+	_, err = g.Resume(ctx, "run")
+	require.ErrorIs(t, err, graph.ErrUnknownNode)
+	require.False(t, called)
+}
+
+func TestMapChainNode(t *testing.T) {
+	t.Parallel()
+
+	upper := chains.NewTransform(func(_ context.Context, inputs map[string]any, _ ...chains.ChainCallOption) (map[string]any, error) { //nolint:lll
+		return map[string]any{"upper": strings.ToUpper(inputs["text"].(string))}, nil
+	}, []string{"text"}, []string{"upper"})
+
+	g, err := graph.New[map[string]any]().
+		AddNode("upper", graph.MapChainNode(upper)).
+		AddEdge("upper", graph.End).
+		SetEntryPoint("upper").
+		Compile()
+	require.NoError(t, err)
+
+	state, err := g.Run(context.Background(), map[string]any{"text": "hi", "other": 1})
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"text": "hi", "other": 1, "upper": "HI"}, state)
+}
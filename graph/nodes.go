@@ -0,0 +1,80 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+
+	"github.com/devmiahub/langchaingo/chains"
+)
+
+// ChainNode creates a node running a chain, such as an agent executor, with
+// chains.Call. The inputs of the chain are taken from the state by input and
+// its outputs are applied to the state by output.
+func ChainNode[S any](
+	chain chains.Chain,
+	input func(state S) map[string]any,
+	output func(state S, outputs map[string]any) (S, error),
+	options ...chains.ChainCallOption,
+) NodeFunc[S] {
+	return func(ctx context.Context, state S) (S, error) {
+		outputs, err := chains.Call(ctx, chain, input(state), options...)
+		if err != nil {
+			return state, err
+		}
+		return output(state, outputs)
+	}
+}
+
+// MapChainNode creates a node over a map state running a chain with the input
+// keys of the chain taken from the state. The outputs of the chain are added
+// to a copy of the state.
+func MapChainNode(chain chains.Chain, options ...chains.ChainCallOption) NodeFunc[map[string]any] {
+	return ChainNode(
+		chain,
+		func(state map[string]any) map[string]any {
+			inputs := make(map[string]any, len(chain.GetInputKeys()))
+			for _, key := range chain.GetInputKeys() {
+				if value, ok := state[key]; ok {
+					inputs[key] = value
+				}
+			}
+			return inputs
+		},
+		func(state map[string]any, outputs map[string]any) (map[string]any, error) {
+			result := maps.Clone(state)
+			if result == nil {
+				result = make(map[string]any, len(outputs))
+			}
+			maps.Copy(result, outputs)
+			return result, nil
+		},
+		options...,
+	)
+}
+
+// MergeMaps is a merge function for map states. The keys changed by each
+// branch are applied to the previous state. Branches changing the same key to
+// different values are an error.
+func MergeMaps(_ context.Context, previous map[string]any, results []map[string]any) (map[string]any, error) {
+	merged := maps.Clone(previous)
+	if merged == nil {
+		merged = make(map[string]any)
+	}
+
+	changedBy := make(map[string]int)
+	for i, result := range results {
+		for key, value := range result {
+			if old, ok := previous[key]; ok && reflect.DeepEqual(old, value) {
+				continue
+			}
+			if branch, ok := changedBy[key]; ok && !reflect.DeepEqual(merged[key], value) {
+				return nil, fmt.Errorf("key %q changed by branches %d and %d", key, branch, i)
+			}
+			changedBy[key] = i
+			merged[key] = value
+		}
+	}
+	return merged, nil
+}
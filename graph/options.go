@@ -0,0 +1,69 @@
+package graph
+
+const _defaultMaxSteps = 25
+
+// Options are the options of a compiled graph.
+type Options[S any] struct {
+	maxSteps        int
+	merge           MergeFunc[S]
+	checkpointer    Checkpointer[S]
+	interruptBefore []string
+}
+
+// Option is a function type that can be used to modify the compilation of a
+// graph.
+type Option[S any] func(*Options[S])
+
+func defaultOptions[S any]() Options[S] {
+	return Options[S]{
+		maxSteps: _defaultMaxSteps,
+	}
+}
+
+// WithMaxSteps is an option for setting the max number of steps a run can take
+// before ErrMaxStepsExceeded is returned. It bounds cycles in the graph.
+func WithMaxSteps[S any](steps int) Option[S] {
+	return func(o *Options[S]) {
+		o.maxSteps = steps
+	}
+}
+
+// WithMerge is an option for setting the function merging the states of nodes
+// running concurrently. It is required for graphs that fan out.
+func WithMerge[S any](merge MergeFunc[S]) Option[S] {
+	return func(o *Options[S]) {
+		o.merge = merge
+	}
+}
+
+// WithCheckpointer is an option for setting the checkpointer the state of runs
+// is persisted with after each step.
+func WithCheckpointer[S any](checkpointer Checkpointer[S]) Option[S] {
+	return func(o *Options[S]) {
+		o.checkpointer = checkpointer
+	}
+}
+
+// WithInterruptBefore is an option for pausing runs before the given nodes
+// run. Requires a checkpointer. Paused runs are continued with Resume.
+func WithInterruptBefore[S any](nodes ...string) Option[S] {
+	return func(o *Options[S]) {
+		o.interruptBefore = append(o.interruptBefore, nodes...)
+	}
+}
+
+// RunOptions are the options of a single run.
+type RunOptions struct {
+	runID string
+}
+
+// RunOption is a function type that can be used to modify a run.
+type RunOption func(*RunOptions)
+
+// WithRunID is an option for setting the id a run is checkpointed with. Runs
+// without an id get a generated one.
+func WithRunID(runID string) RunOption {
+	return func(o *RunOptions) {
+		o.runID = runID
+	}
+}
@@ -0,0 +1,222 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/sync/errgroup"
+)
+
+// Runnable is a compiled graph.
+type Runnable[S any] struct {
+	nodes      map[string]NodeFunc[S]
+	order      []string
+	edges      map[string][]string
+	routers    map[string]RouterFunc[S]
+	entryPoint string
+	options    Options[S]
+}
+
+// Run runs the graph from its entry point with the initial state and returns
+// the final state. If the run fails, the state after the last completed step
+// is returned along with the error.
+func (r *Runnable[S]) Run(ctx context.Context, state S, opts ...RunOption) (S, error) {
+	runOptions := RunOptions{}
+	for _, opt := range opts {
+		opt(&runOptions)
+	}
+	if runOptions.runID == "" {
+		runOptions.runID = uuid.NewString()
+	}
+
+	return r.run(ctx, &Checkpoint[S]{
+		RunID: runOptions.runID,
+		State: state,
+		Next:  []string{r.entryPoint},
+	})
+}
+
+// Resume continues a checkpointed run from its last checkpoint, running the
+// nodes it was interrupted before, if any.
+func (r *Runnable[S]) Resume(ctx context.Context, runID string) (S, error) {
+	checkpoint, err := r.loadCheckpoint(ctx, runID)
+	if err != nil {
+		var state S
+		return state, err
+	}
+
+	return r.run(ctx, checkpoint)
+}
+
+// GetState returns the last checkpoint of a run.
+func (r *Runnable[S]) GetState(ctx context.Context, runID string) (*Checkpoint[S], error) {
+	return r.loadCheckpoint(ctx, runID)
+}
+
+// UpdateState changes the checkpointed state of a run, for example to apply
+// human input to a run interrupted before a node.
+func (r *Runnable[S]) UpdateState(ctx context.Context, runID string, update func(state S) (S, error)) error {
+	checkpoint, err := r.loadCheckpoint(ctx, runID)
+	if err != nil {
+		return err
+	}
+
+	checkpoint.State, err = update(checkpoint.State)
+	if err != nil {
+		return err
+	}
+	return r.saveCheckpoint(ctx, checkpoint)
+}
+
+func (r *Runnable[S]) run(ctx context.Context, checkpoint *Checkpoint[S]) (S, error) {
+	resuming := checkpoint.Interrupted
+	for len(checkpoint.Next) > 0 {
+		if checkpoint.Step >= r.options.maxSteps {
+			return checkpoint.State, ErrMaxStepsExceeded
+		}
+
+		if !resuming && r.interrupts(checkpoint.Next) {
+			checkpoint.Interrupted = true
+			if err := r.saveCheckpoint(ctx, checkpoint); err != nil {
+				return checkpoint.State, err
+			}
+			return checkpoint.State, &InterruptError{
+				RunID: checkpoint.RunID,
+				Next:  slices.Clone(checkpoint.Next),
+			}
+		}
+		resuming = false
+
+		state, next, err := r.step(ctx, checkpoint.State, checkpoint.Next)
+		if err != nil {
+			return checkpoint.State, err
+		}
+
+		checkpoint.State = state
+		checkpoint.Next = next
+		checkpoint.Step++
+		checkpoint.Interrupted = false
+		if err := r.saveCheckpoint(ctx, checkpoint); err != nil {
+			return checkpoint.State, err
+		}
+	}
+
+	return checkpoint.State, r.deleteCheckpoint(ctx, checkpoint.RunID)
+}
+
+// step runs the active nodes concurrently and returns the merged state and
+// the nodes of the next step.
+func (r *Runnable[S]) step(ctx context.Context, state S, active []string) (S, []string, error) {
+	if len(active) > 1 && r.options.merge == nil {
+		return state, nil, fmt.Errorf("%w: %d nodes active but no merge function is set", ErrInvalidGraph, len(active))
+	}
+
+	// All the nodes are looked up before any is started.
+	nodes := make([]NodeFunc[S], len(active))
+	for i, name := range active {
+		node, ok := r.nodes[name]
+		if !ok {
+			return state, nil, fmt.Errorf("%w: %q", ErrUnknownNode, name)
+		}
+		nodes[i] = node
+	}
+
+	results := make([]S, len(active))
+	g, gctx := errgroup.WithContext(ctx)
+	for i, name := range active {
+		node := nodes[i]
+		g.Go(func() error {
+			result, err := node(gctx, state)
+			if err != nil {
+				return fmt.Errorf("node %s: %w", name, err)
+			}
+			results[i] = result
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return state, nil, err
+	}
+
+	merged := results[0]
+	if len(results) > 1 {
+		var err error
+		merged, err = r.options.merge(ctx, state, results)
+		if err != nil {
+			return state, nil, err
+		}
+	}
+
+	next, err := r.next(ctx, merged, active)
+	if err != nil {
+		return state, nil, err
+	}
+	return merged, next, nil
+}
+
+// next returns the nodes reached from the active nodes, in the order the nodes
+// were added to the graph.
+func (r *Runnable[S]) next(ctx context.Context, state S, active []string) ([]string, error) {
+	reached := make(map[string]bool)
+	for _, name := range active {
+		targets := r.edges[name]
+		if router := r.routers[name]; router != nil {
+			target, err := router(ctx, state)
+			if err != nil {
+				return nil, fmt.Errorf("edge from %s: %w", name, err)
+			}
+			if target != End && r.nodes[target] == nil {
+				return nil, fmt.Errorf("%w: %q reached from %s", ErrUnknownNode, target, name)
+			}
+			targets = []string{target}
+		}
+		for _, target := range targets {
+			reached[target] = true
+		}
+	}
+
+	next := make([]string, 0, len(reached))
+	for _, name := range r.order {
+		if reached[name] {
+			next = append(next, name)
+		}
+	}
+	return next, nil
+}
+
+func (r *Runnable[S]) interrupts(nodes []string) bool {
+	if r.options.checkpointer == nil {
+		return false
+	}
+	for _, name := range nodes {
+		if slices.Contains(r.options.interruptBefore, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Runnable[S]) loadCheckpoint(ctx context.Context, runID string) (*Checkpoint[S], error) {
+	if r.options.checkpointer == nil {
+		return nil, ErrNoCheckpointer
+	}
+	return r.options.checkpointer.Load(ctx, runID)
+}
+
+func (r *Runnable[S]) saveCheckpoint(ctx context.Context, checkpoint *Checkpoint[S]) error {
+	if r.options.checkpointer == nil {
+		return nil
+	}
+	checkpoint.UpdatedAt = time.Now()
+	return r.options.checkpointer.Save(ctx, *checkpoint)
+}
+
+func (r *Runnable[S]) deleteCheckpoint(ctx context.Context, runID string) error {
+	if r.options.checkpointer == nil {
+		return nil
+	}
+	return r.options.checkpointer.Delete(ctx, runID)
+}
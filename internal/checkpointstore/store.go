@@ -0,0 +1,161 @@
+// Package checkpointstore provides the in-memory and file stores shared by the
+// checkpointers of agent and graph runs.
+package checkpointstore
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Memory is a store keeping values in memory.
+type Memory[T any] struct {
+	mu     sync.Mutex
+	values map[string]T
+	clone  func(T) T
+	// errNotFound is wrapped in the error returned for a key without value.
+	errNotFound error
+}
+
+// NewMemory creates a new in-memory store. Values are copied with clone, if
+// not nil, when they are saved and loaded. The error returned for a key
+// without value wraps errNotFound.
+func NewMemory[T any](clone func(T) T, errNotFound error) *Memory[T] {
+	return &Memory[T]{
+		values:      make(map[string]T),
+		clone:       clone,
+		errNotFound: errNotFound,
+	}
+}
+
+// Save stores the value of the key.
+func (m *Memory[T]) Save(key string, value T) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.values[key] = m.copy(value)
+}
+
+// Load returns the value of the key.
+func (m *Memory[T]) Load(key string) (*T, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	value, ok := m.values[key]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", m.errNotFound, key)
+	}
+	value = m.copy(value)
+	return &value, nil
+}
+
+// Delete removes the value of the key.
+func (m *Memory[T]) Delete(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.values, key)
+}
+
+func (m *Memory[T]) copy(value T) T {
+	if m.clone == nil {
+		return value
+	}
+	return m.clone(value)
+}
+
+// File is a store writing each value as a JSON file in a directory.
+type File[T any] struct {
+	dir string
+	// errNotFound is wrapped in the error returned for a key without value.
+	errNotFound error
+	// errInvalidKey is wrapped in the error returned for a key that cannot be
+	// used as a file name.
+	errInvalidKey error
+}
+
+// NewFile creates a new store writing files to dir. The errors returned for a
+// key without value and for a key that cannot be used as a file name wrap
+// errNotFound and errInvalidKey.
+func NewFile[T any](dir string, errNotFound, errInvalidKey error) File[T] {
+	return File[T]{
+		dir:           dir,
+		errNotFound:   errNotFound,
+		errInvalidKey: errInvalidKey,
+	}
+}
+
+// Save writes the value to the file of the key. The file is replaced
+// atomically so a crash never leaves a partially written value behind.
+func (f File[T]) Save(key string, value T) error {
+	path, err := f.path(key)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(f.dir, ".checkpoint-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// Load reads the value of the key.
+func (f File[T]) Load(key string) (*T, error) {
+	path, err := f.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w: %s", f.errNotFound, key)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	return &value, nil
+}
+
+// Delete removes the file of the key.
+func (f File[T]) Delete(key string) error {
+	path, err := f.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (f File[T]) path(key string) (string, error) {
+	if key == "" || key == "." || key == ".." || strings.ContainsAny(key, `/\`) {
+		return "", fmt.Errorf("%w: %q", f.errInvalidKey, key)
+	}
+	return filepath.Join(f.dir, key+".json"), nil
+}
@@ -0,0 +1,67 @@
+package checkpointstore
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	errNotFound   = errors.New("not found")
+	errInvalidKey = errors.New("invalid key")
+)
+
+type value struct {
+	Name  string   `json:"name"`
+	Items []string `json:"items"`
+}
+
+func TestMemory(t *testing.T) {
+	t.Parallel()
+
+	store := NewMemory(func(v value) value {
+		v.Items = append([]string(nil), v.Items...)
+		return v
+	}, errNotFound)
+
+	saved := value{Name: "a", Items: []string{"x"}}
+	store.Save("run", saved)
+	saved.Items[0] = "changed"
+
+	loaded, err := store.Load("run")
+	require.NoError(t, err)
+	require.Equal(t, value{Name: "a", Items: []string{"x"}}, *loaded)
+
+	store.Delete("run")
+	_, err = store.Load("run")
+	require.ErrorIs(t, err, errNotFound)
+}
+
+func TestFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	store := NewFile[value](dir, errNotFound, errInvalidKey)
+
+	require.NoError(t, store.Save("run", value{Name: "a"}))
+	require.NoError(t, store.Save("run", value{Name: "b"}))
+	loaded, err := store.Load("run")
+	require.NoError(t, err)
+	require.Equal(t, value{Name: "b"}, *loaded)
+
+	// Only the file of the key is left, the temporary files are removed.
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	require.NoError(t, store.Delete("run"))
+	require.NoError(t, store.Delete("run"))
+	_, err = store.Load("run")
+	require.ErrorIs(t, err, errNotFound)
+
+	for _, key := range []string{"", ".", "..", "a/b", `a\b`} {
+		require.ErrorIs(t, store.Save(key, value{}), errInvalidKey)
+	}
+}
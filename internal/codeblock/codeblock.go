@@ -0,0 +1,87 @@
+// Package codeblock extracts the fenced code blocks and the JSON of the
+// outputs of models.
+package codeblock
+
+import (
+	"slices"
+	"strings"
+)
+
+const _fence = "```"
+
+// Find returns the content of the first fenced code block of the text whose
+// language is one of languages, or which has no language; any block matches
+// when no languages are given. The closing fence may be missing, as when the
+// output was cut off or is still streaming. It reports whether the text has
+// such a code block.
+func Find(text string, languages ...string) (string, bool) {
+	rest := text
+	for {
+		start := strings.Index(rest, _fence)
+		if start < 0 {
+			return "", false
+		}
+		rest = rest[start+len(_fence):]
+
+		// The language is on the line of the opening fence.
+		newline := strings.IndexByte(rest, '\n')
+		if newline < 0 {
+			return "", false
+		}
+		language := strings.ToLower(strings.TrimSpace(rest[:newline]))
+		content := rest[newline+1:]
+		end := strings.Index(content, _fence)
+		if end < 0 {
+			end = len(content)
+		}
+
+		if len(languages) == 0 || language == "" || slices.Contains(languages, language) {
+			return strings.TrimSpace(content[:end]), true
+		}
+		if end == len(content) {
+			return "", false
+		}
+		rest = content[end+len(_fence):]
+	}
+}
+
+// Extract returns the content of the code block found by Find, or else the
+// trimmed text.
+func Extract(text string, languages ...string) string {
+	if content, ok := Find(text, languages...); ok {
+		return content
+	}
+	return strings.TrimSpace(text)
+}
+
+// JSON returns the JSON object or array of the text, from the first brace or
+// bracket to the last matching one, looking in the json code block of the text
+// if any. It reports whether the text has a JSON object or array.
+func JSON(text string) (string, bool) {
+	return extractJSON(text, "{[")
+}
+
+// Object returns the JSON object of the text, as JSON does for objects and
+// arrays.
+func Object(text string) (string, bool) {
+	return extractJSON(text, "{")
+}
+
+func extractJSON(text, openings string) (string, bool) {
+	if content, ok := Find(text, "json"); ok && strings.ContainsAny(content, openings) {
+		text = content
+	}
+
+	start := strings.IndexAny(text, openings)
+	if start < 0 {
+		return "", false
+	}
+	closing := "}"
+	if text[start] == '[' {
+		closing = "]"
+	}
+	if end := strings.LastIndex(text, closing); end > start {
+		return text[start : end+1], true
+	}
+	return text[start:], true
+}
@@ -0,0 +1,67 @@
+package codeblock
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtract(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		text      string
+		languages []string
+		want      string
+	}{
+		{"no block", "  foo: bar\n", []string{"yaml"}, "foo: bar"},
+		{"language", "Here:\n```yaml\nfoo: bar\n```\nDone.", []string{"yaml"}, "foo: bar"},
+		{"language case", "```YAML\nfoo: bar\n```", []string{"yaml"}, "foo: bar"},
+		{"no language", "```\nfoo: bar\n```", []string{"yaml"}, "foo: bar"},
+		{"unterminated", "```yaml\nfoo: bar\n", []string{"yaml"}, "foo: bar"},
+		{"skips other languages", "```json\n{}\n```\n```yml\nfoo: bar\n```", []string{"yaml", "yml"}, "foo: bar"},
+		{"only other languages", "```json\n{}\n```", []string{"yaml"}, "```json\n{}\n```"},
+		{"any language", "```text\nfoo\n```", nil, "foo"},
+		{"fence without newline", "```foo```", nil, "```foo```"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.want, Extract(tt.text, tt.languages...))
+		})
+	}
+}
+
+func TestJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		text   string
+		want   string
+		object string
+	}{
+		{"plain", `{"a": 1}`, `{"a": 1}`, `{"a": 1}`},
+		{"surrounded", "Sure: {\"a\": {\"b\": 1}} hope it helps", `{"a": {"b": 1}}`, `{"a": {"b": 1}}`},
+		{"array", `Here: [1, 2]`, `[1, 2]`, ""},
+		{"block", "```json\n[{\"a\": 1}]\n```\nsee {note}", `[{"a": 1}]`, `{"a": 1}`},
+		{"block without language", "```\n{\"a\": 1}\n```", `{"a": 1}`, `{"a": 1}`},
+		{"block not json", "```json\nnothing\n```\n{\"a\": 1}", `{"a": 1}`, `{"a": 1}`},
+		{"object after brackets", "Thought: see [docs]\n{\"a\": 1}", `[docs]`, `{"a": 1}`},
+		{"unterminated", `{"a": [1, 2`, `{"a": [1, 2`, `{"a": [1, 2`},
+		{"none", "no json here", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, ok := JSON(tt.text)
+			require.Equal(t, tt.want, got)
+			require.Equal(t, tt.want != "", ok)
+
+			got, ok = Object(tt.text)
+			require.Equal(t, tt.object, got)
+			require.Equal(t, tt.object != "", ok)
+		})
+	}
+}
@@ -2,6 +2,10 @@
 // (nested) struct. This struct can be used with the chat completion "function call" feature.
 // For more complicated schemas, it is recommended to use a dedicated JSON schema library
 // and/or pass in the schema in []byte format.
+//
+// Schemas can be derived from Go types with For, validated against with Validate, and
+// converted to the subsets supported by OpenAI strict mode and Gemini with
+// Definition.OpenAIStrict and Definition.Gemini.
 package jsonschema
 
 import "encoding/json"
@@ -21,6 +25,11 @@ const (
 // Definition is a struct for describing a JSON Schema.
 // It is fairly limited, and you may have better luck using a third-party library.
 type Definition struct {
+	// Ref references another schema, such as "#/$defs/Node" for a definition
+	// in Defs of the root schema, or "#" for the root schema itself.
+	Ref string `json:"$ref,omitempty"`
+	// Defs holds the definitions referenced with Ref, in the root schema.
+	Defs map[string]Definition `json:"$defs,omitempty"`
 	// Type specifies the data type of the schema.
 	Type DataType `json:"type,omitempty"`
 	// Description is the description of the schema.
@@ -28,22 +37,51 @@ type Definition struct {
 	// Enum is used to restrict a value to a fixed set of values. It must be an array with at least
 	// one element, where each element is unique. You will probably only use this with strings.
 	Enum []string `json:"enum,omitempty"`
+	// Format is the format of a string, such as "date-time", "email" or "uuid".
+	Format string `json:"format,omitempty"`
+	// Pattern is a regular expression a string must match.
+	Pattern string `json:"pattern,omitempty"`
+	// MinLength and MaxLength bound the length of a string.
+	MinLength *int `json:"minLength,omitempty"`
+	MaxLength *int `json:"maxLength,omitempty"`
+	// Minimum and Maximum bound a number.
+	Minimum *float64 `json:"minimum,omitempty"`
+	Maximum *float64 `json:"maximum,omitempty"`
+	// Default is the value used when the value is missing.
+	Default any `json:"default,omitempty"`
 	// Properties describes the properties of an object, if the schema type is Object.
 	Properties map[string]Definition `json:"properties"`
 	// Required specifies which properties are required, if the schema type is Object.
 	Required []string `json:"required,omitempty"`
+	// AdditionalProperties describes the properties of an object that are not
+	// in Properties. It is either a bool, where false forbids them, or a
+	// Definition or *Definition they must match.
+	AdditionalProperties any `json:"additionalProperties,omitempty"`
 	// Items specifies which data type an array contains, if the schema type is Array.
 	Items *Definition `json:"items,omitempty"`
+	// MinItems and MaxItems bound the length of an array.
+	MinItems *int `json:"minItems,omitempty"`
+	MaxItems *int `json:"maxItems,omitempty"`
+	// AnyOf requires a value to match at least one of the schemas.
+	AnyOf []Definition `json:"anyOf,omitempty"`
+	// OneOf requires a value to match exactly one of the schemas.
+	OneOf []Definition `json:"oneOf,omitempty"`
 }
 
 func (d Definition) MarshalJSON() ([]byte, error) {
-	if d.Properties == nil {
+	// References, combinations and nulls have no properties.
+	if d.Properties == nil && d.Ref == "" && d.AnyOf == nil && d.OneOf == nil && d.Type != Null {
 		d.Properties = make(map[string]Definition)
 	}
 	type Alias Definition
-	return json.Marshal(struct {
+	aux := struct {
 		Alias
-	}{
-		Alias: (Alias)(d),
-	})
+		// Properties shadows the properties of the alias to omit them only when
+		// nil: an interface holding an empty map is not omitted.
+		Properties any `json:"properties,omitempty"`
+	}{Alias: (Alias)(d)}
+	if d.Properties != nil {
+		aux.Properties = d.Properties
+	}
+	return json.Marshal(aux)
 }
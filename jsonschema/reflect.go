@@ -0,0 +1,414 @@
+package jsonschema
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrUnsupportedType is returned when no schema can be derived from a Go type,
+// such as a channel or a function.
+var ErrUnsupportedType = errors.New("unsupported type")
+
+// _defsPrefix is the prefix of the references to the definitions of the root
+// schema.
+const _defsPrefix = "#/$defs/"
+
+//nolint:gochecknoglobals
+var (
+	_timeType          = reflect.TypeFor[time.Time]()
+	_rawMessageType    = reflect.TypeFor[json.RawMessage]()
+	_numberType        = reflect.TypeFor[json.Number]()
+	_jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+	_textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+
+	_invalidDefNameChars = regexp.MustCompile(`[^a-zA-Z0-9_.-]+`)
+)
+
+// For derives the schema of the Go type T, as encoded by encoding/json.
+//
+// Struct fields are named after their json tag, and are required unless
+// tagged omitempty or omitzero, required pointer fields being nullable as nil
+// pointers are encoded as null. Fields tagged with json:"-" and unexported
+// fields are skipped, and embedded structs are flattened. Fields may also be
+// tagged with:
+//
+//   - description: the description of the field.
+//   - enum: the allowed values, separated by commas.
+//   - jsonschema: comma-separated keywords, among format=, pattern=,
+//     minLength=, maxLength=, minimum=, maximum=, minItems=, maxItems= and
+//     default=, whose value is JSON or else a string. The value of pattern=
+//     is the rest of the tag, commas included, so it must come last.
+//
+// Recursive types are described once in the $defs of the root schema and
+// referenced with $ref; a reference to the type of the root itself is "#".
+// time.Time is a date-time string, maps are objects whose additional
+// properties are described by the schema of the values, and interfaces accept
+// any value.
+//
+// Example:
+//
+//	type Person struct {
+//		Name    string   `json:"name" description:"full name"`
+//		Age     int      `json:"age,omitempty" jsonschema:"minimum=0"`
+//		Role    string   `json:"role" enum:"admin,user"`
+//		Friends []Person `json:"friends,omitempty"`
+//	}
+//
+//	schema, err := jsonschema.For[Person]()
+func For[T any]() (*Definition, error) {
+	return ForType(reflect.TypeFor[T]())
+}
+
+// ForType derives the schema of a Go type, as For does.
+func ForType(typ reflect.Type) (*Definition, error) {
+	r := &reflector{
+		root:      derefType(typ),
+		names:     map[reflect.Type]string{},
+		visiting:  map[reflect.Type]bool{},
+		recursive: map[reflect.Type]bool{},
+	}
+	def, err := r.schema(typ)
+	if err != nil {
+		return nil, err
+	}
+	if len(r.defs) > 0 {
+		def.Defs = r.defs
+	}
+	return &def, nil
+}
+
+type reflector struct {
+	root reflect.Type
+	defs map[string]Definition
+	// names are the names of the definitions of the recursive types.
+	names map[reflect.Type]string
+	// visiting are the struct types being derived, to detect recursion.
+	visiting map[reflect.Type]bool
+	// recursive are the struct types that reference themselves.
+	recursive map[reflect.Type]bool
+}
+
+func derefType(typ reflect.Type) reflect.Type {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	return typ
+}
+
+func (r *reflector) schema(typ reflect.Type) (Definition, error) {
+	typ = derefType(typ)
+
+	switch {
+	case typ == _timeType:
+		return Definition{Type: String, Format: "date-time"}, nil
+	case typ == _rawMessageType:
+		return Definition{}, nil
+	case typ == _numberType:
+		return Definition{Type: Number}, nil
+	case typ.Implements(_jsonMarshalerType) || reflect.PointerTo(typ).Implements(_jsonMarshalerType):
+		// The encoding is custom, so any value is accepted.
+		return Definition{}, nil
+	case typ.Implements(_textMarshalerType) || reflect.PointerTo(typ).Implements(_textMarshalerType):
+		return Definition{Type: String}, nil
+	}
+
+	switch typ.Kind() { //nolint:exhaustive
+	case reflect.Bool:
+		return Definition{Type: Boolean}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return Definition{Type: Integer}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return Definition{Type: Integer, Minimum: ptr(0.0)}, nil
+	case reflect.Float32, reflect.Float64:
+		return Definition{Type: Number}, nil
+	case reflect.String:
+		return Definition{Type: String}, nil
+	case reflect.Interface:
+		return Definition{}, nil
+	case reflect.Slice, reflect.Array:
+		return r.arraySchema(typ)
+	case reflect.Map:
+		return r.mapSchema(typ)
+	case reflect.Struct:
+		return r.structSchema(typ)
+	default:
+		return Definition{}, fmt.Errorf("%w: %s", ErrUnsupportedType, typ)
+	}
+}
+
+func (r *reflector) arraySchema(typ reflect.Type) (Definition, error) {
+	// Byte slices are encoded as base64 strings, unlike byte arrays.
+	if typ.Kind() == reflect.Slice && typ.Elem().Kind() == reflect.Uint8 &&
+		!reflect.PointerTo(typ.Elem()).Implements(_textMarshalerType) {
+		return Definition{Type: String, Description: "base64 encoded data"}, nil
+	}
+	items, err := r.schema(typ.Elem())
+	if err != nil {
+		return Definition{}, err
+	}
+	def := Definition{Type: Array, Items: &items}
+	if typ.Kind() == reflect.Array {
+		def.MinItems, def.MaxItems = ptr(typ.Len()), ptr(typ.Len())
+	}
+	return def, nil
+}
+
+func (r *reflector) mapSchema(typ reflect.Type) (Definition, error) {
+	switch typ.Key().Kind() { //nolint:exhaustive
+	case reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+	default:
+		if !typ.Key().Implements(_textMarshalerType) {
+			return Definition{}, fmt.Errorf("%w: map with %s keys", ErrUnsupportedType, typ.Key())
+		}
+	}
+	values, err := r.schema(typ.Elem())
+	if err != nil {
+		return Definition{}, err
+	}
+	return Definition{Type: Object, AdditionalProperties: &values}, nil
+}
+
+func (r *reflector) structSchema(typ reflect.Type) (Definition, error) {
+	if r.visiting[typ] {
+		r.recursive[typ] = true
+		return r.ref(typ), nil
+	}
+	r.visiting[typ] = true
+	defer delete(r.visiting, typ)
+
+	def := Definition{Type: Object, Properties: map[string]Definition{}}
+	if err := r.addFields(&def, typ, map[reflect.Type]bool{}); err != nil {
+		return Definition{}, err
+	}
+
+	// The root is referenced as "#", the other recursive types are moved to
+	// the definitions.
+	if r.recursive[typ] && typ != r.root {
+		if r.defs == nil {
+			r.defs = map[string]Definition{}
+		}
+		ref := r.ref(typ)
+		r.defs[r.names[typ]] = def
+		return ref, nil
+	}
+	return def, nil
+}
+
+// ref returns the reference to the definition of a recursive type.
+func (r *reflector) ref(typ reflect.Type) Definition {
+	if typ == r.root {
+		return Definition{Ref: "#"}
+	}
+	name, ok := r.names[typ]
+	if !ok {
+		name = r.defName(typ)
+		r.names[typ] = name
+	}
+	return Definition{Ref: _defsPrefix + name}
+}
+
+// defName returns a unique name for the definition of the type.
+func (r *reflector) defName(typ reflect.Type) string {
+	base := strings.Trim(_invalidDefNameChars.ReplaceAllString(typ.Name(), "_"), "_")
+	if base == "" {
+		base = "def"
+	}
+	name := base
+	for i := 2; r.nameTaken(name); i++ {
+		name = base + strconv.Itoa(i)
+	}
+	return name
+}
+
+func (r *reflector) nameTaken(name string) bool {
+	for _, taken := range r.names {
+		if taken == name {
+			return true
+		}
+	}
+	return false
+}
+
+// addFields adds the fields of the struct to the properties of def. The
+// fields of embedded structs are promoted, like with encoding/json, unless a
+// field of the outer struct has the same name.
+func (r *reflector) addFields(def *Definition, typ reflect.Type, embedding map[reflect.Type]bool) error {
+	embedding[typ] = true
+	defer delete(embedding, typ)
+
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, tagOptions, _ := strings.Cut(tag, ",")
+
+		fieldType := derefType(field.Type)
+		if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			if embedding[fieldType] {
+				continue
+			}
+			if err := r.addFields(def, fieldType, embedding); err != nil {
+				return err
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		property, err := r.fieldSchema(field, tagOptions)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+
+		if _, ok := def.Properties[name]; ok {
+			// The fields of the outer struct shadow the fields of the embedded
+			// structs, wherever they are declared.
+			if len(embedding) > 1 {
+				continue
+			}
+			def.Required = removeString(def.Required, name)
+		}
+		def.Properties[name] = property
+		if !hasTagOption(tagOptions, "omitempty") && !hasTagOption(tagOptions, "omitzero") {
+			def.Required = append(def.Required, name)
+		}
+	}
+	return nil
+}
+
+func (r *reflector) fieldSchema(field reflect.StructField, tagOptions string) (Definition, error) {
+	def, err := r.schema(field.Type)
+	if err != nil {
+		return Definition{}, err
+	}
+
+	// The string option encodes numbers and booleans as strings.
+	if hasTagOption(tagOptions, "string") {
+		switch def.Type { //nolint:exhaustive
+		case Integer, Number, Boolean:
+			def = Definition{Type: String}
+		}
+	}
+
+	if description := field.Tag.Get("description"); description != "" {
+		def.Description = description
+	}
+	if enum := field.Tag.Get("enum"); enum != "" {
+		def.Enum = strings.Split(enum, ",")
+	}
+	if keywords := field.Tag.Get("jsonschema"); keywords != "" {
+		if err := def.applyKeywords(keywords); err != nil {
+			return Definition{}, err
+		}
+	}
+
+	// A nil pointer is encoded as null, unless the field is omitted.
+	if field.Type.Kind() == reflect.Pointer &&
+		!hasTagOption(tagOptions, "omitempty") && !hasTagOption(tagOptions, "omitzero") {
+		def = nullable(def)
+	}
+	return def, nil
+}
+
+// applyKeywords sets the keywords of a jsonschema struct tag.
+func (d *Definition) applyKeywords(keywords string) error {
+	for keywords != "" {
+		keyword, rest, _ := strings.Cut(keywords, ",")
+		key, value, ok := strings.Cut(strings.TrimSpace(keyword), "=")
+		if !ok {
+			return fmt.Errorf("invalid jsonschema tag keyword %q", keyword)
+		}
+		if key == "pattern" {
+			// The pattern may contain commas, so it is the rest of the tag.
+			_, value, _ = strings.Cut(keywords, "=")
+			value, rest = strings.TrimSpace(value), ""
+		}
+		keywords = rest
+
+		var err error
+		switch key {
+		case "format":
+			d.Format = value
+		case "pattern":
+			d.Pattern = value
+		case "minLength":
+			d.MinLength, err = parseInt(value)
+		case "maxLength":
+			d.MaxLength, err = parseInt(value)
+		case "minItems":
+			d.MinItems, err = parseInt(value)
+		case "maxItems":
+			d.MaxItems, err = parseInt(value)
+		case "minimum":
+			d.Minimum, err = parseFloat(value)
+		case "maximum":
+			d.Maximum, err = parseFloat(value)
+		case "default":
+			var v any
+			if json.Unmarshal([]byte(value), &v) != nil {
+				v = value
+			}
+			d.Default = v
+		default:
+			return fmt.Errorf("unknown jsonschema tag keyword %q", key)
+		}
+		if err != nil {
+			return fmt.Errorf("jsonschema tag keyword %q: %w", key, err)
+		}
+	}
+	return nil
+}
+
+func parseInt(value string) (*int, error) {
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, err
+	}
+	return &i, nil
+}
+
+func parseFloat(value string) (*float64, error) {
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+func hasTagOption(options, option string) bool {
+	for _, o := range strings.Split(options, ",") {
+		if o == option {
+			return true
+		}
+	}
+	return false
+}
+
+func removeString(values []string, value string) []string {
+	result := values[:0]
+	for _, v := range values {
+		if v != value {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,219 @@
+package jsonschema_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/devmiahub/langchaingo/jsonschema"
+)
+
+type address struct {
+	City    string `json:"city"`
+	Country string `json:"country,omitempty" description:"ISO country code" jsonschema:"minLength=2,maxLength=2"`
+}
+
+type base struct {
+	ID      string `json:"id" jsonschema:"format=uuid"`
+	Ignored string `json:"name"`
+}
+
+type person struct {
+	base
+	Name      string            `json:"name" description:"full name"`
+	Age       uint8             `json:"age,omitempty" jsonschema:"maximum=150,default=18"`
+	Role      string            `json:"role" enum:"admin,user"`
+	Score     int               `json:"score,string"`
+	Born      time.Time         `json:"born"`
+	Address   *address          `json:"address,omitempty"`
+	Tags      []string          `json:"tags"`
+	Point     [2]float64        `json:"point"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Extra     any               `json:"extra,omitempty"`
+	Avatar    []byte            `json:"avatar,omitempty"`
+	Friends   []person          `json:"friends,omitempty"`
+	Secret    string            `json:"-"`
+	unexposed string
+}
+
+type tree struct {
+	Root *node `json:"root"`
+}
+
+type node struct {
+	Value    string  `json:"value"`
+	Children []*node `json:"children,omitempty"`
+}
+
+func TestFor(t *testing.T) {
+	t.Parallel()
+
+	got, err := jsonschema.For[person]()
+	if err != nil {
+		t.Fatalf("For() error = %v", err)
+	}
+
+	wantRequired := []string{"id", "name", "role", "score", "born", "tags", "point"}
+	if !reflect.DeepEqual(got.Required, wantRequired) {
+		t.Errorf("Required = %v, want %v", got.Required, wantRequired)
+	}
+
+	properties := got.Properties
+	for _, name := range []string{"Secret", "unexposed", "base"} {
+		if _, ok := properties[name]; ok {
+			t.Errorf("property %q should be skipped", name)
+		}
+	}
+
+	tests := []struct {
+		name string
+		got  jsonschema.Definition
+		want string
+	}{
+		{"promoted", properties["id"], `{"type":"string","format":"uuid","properties":{}}`},
+		{"shadowed", properties["name"], `{"type":"string","description":"full name","properties":{}}`},
+		{"bounds", properties["age"], `{"type":"integer","minimum":0,"maximum":150,"default":18,"properties":{}}`},
+		{"enum", properties["role"], `{"type":"string","enum":["admin","user"],"properties":{}}`},
+		{"string option", properties["score"], `{"type":"string","properties":{}}`},
+		{"time", properties["born"], `{"type":"string","format":"date-time","properties":{}}`},
+		{"array", properties["point"], `{
+			"type":"array","items":{"type":"number","properties":{}},"minItems":2,"maxItems":2,"properties":{}
+		}`},
+		{"map", properties["labels"], `{
+			"type":"object","additionalProperties":{"type":"string","properties":{}},"properties":{}
+		}`},
+		{"interface", properties["extra"], `{"properties":{}}`},
+		{"bytes", properties["avatar"], `{"type":"string","description":"base64 encoded data","properties":{}}`},
+		{"nested", properties["address"], `{
+			"type":"object",
+			"properties":{
+				"city":{"type":"string","properties":{}},
+				"country":{"type":"string","description":"ISO country code","minLength":2,"maxLength":2,"properties":{}}
+			},
+			"required":["city"]
+		}`},
+		{"recursive root", properties["friends"], `{"type":"array","items":{"$ref":"#"},"properties":{}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assertJSONEqual(t, tt.got, tt.want)
+		})
+	}
+
+	if got.Defs != nil {
+		t.Errorf("Defs = %v, want none", got.Defs)
+	}
+}
+
+func TestForRecursiveDefs(t *testing.T) {
+	t.Parallel()
+
+	got, err := jsonschema.For[tree]()
+	if err != nil {
+		t.Fatalf("For() error = %v", err)
+	}
+	assertJSONEqual(t, got, `{
+		"type":"object",
+		"properties":{"root":{"anyOf":[{"$ref":"#/$defs/node"},{"type":"null"}]}},
+		"required":["root"],
+		"$defs":{
+			"node":{
+				"type":"object",
+				"properties":{
+					"value":{"type":"string","properties":{}},
+					"children":{"type":"array","items":{"$ref":"#/$defs/node"},"properties":{}}
+				},
+				"required":["value"]
+			}
+		}
+	}`)
+
+	valid := `{"root":{"value":"a","children":[{"value":"b","children":[{"value":"c"}]}]}}`
+	if err := got.ValidateJSON([]byte(valid)); err != nil {
+		t.Errorf("ValidateJSON() error = %v", err)
+	}
+	if err := got.ValidateJSON([]byte(`{"root":null}`)); err != nil {
+		t.Errorf("ValidateJSON() error = %v", err)
+	}
+	invalid := `{"root":{"value":"a","children":[{"children":[]}]}}`
+	if err := got.ValidateJSON([]byte(invalid)); !errors.Is(err, jsonschema.ErrValidation) {
+		t.Errorf("ValidateJSON() error = %v, want %v", err, jsonschema.ErrValidation)
+	}
+}
+
+func TestForTags(t *testing.T) {
+	t.Parallel()
+
+	type tagged struct {
+		Code     string  `json:"code" jsonschema:"minLength=1,pattern=^[a-z]{1,3}(,[a-z]{1,3})*$"`
+		Nickname *string `json:"nickname" description:"nickname if any"`
+		Email    *string `json:"email,omitempty" jsonschema:"format=email"`
+	}
+
+	got, err := jsonschema.For[tagged]()
+	if err != nil {
+		t.Fatalf("For() error = %v", err)
+	}
+	assertJSONEqual(t, got, `{
+		"type":"object",
+		"properties":{
+			"code":{"type":"string","pattern":"^[a-z]{1,3}(,[a-z]{1,3})*$","minLength":1,"properties":{}},
+			"nickname":{"description":"nickname if any","anyOf":[{"type":"string","properties":{}},{"type":"null"}]},
+			"email":{"type":"string","format":"email","properties":{}}
+		},
+		"required":["code","nickname"]
+	}`)
+
+	// A nil pointer is encoded as null.
+	data, err := json.Marshal(tagged{Code: "ab,cd"})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if err := got.ValidateJSON(data); err != nil {
+		t.Errorf("ValidateJSON(%s) error = %v", data, err)
+	}
+}
+
+func TestForErrors(t *testing.T) {
+	t.Parallel()
+
+	type withChannel struct {
+		C chan int `json:"c"`
+	}
+	type withBadTag struct {
+		N int `json:"n" jsonschema:"minimum=low"`
+	}
+
+	if _, err := jsonschema.For[withChannel](); !errors.Is(err, jsonschema.ErrUnsupportedType) {
+		t.Errorf("For() error = %v, want %v", err, jsonschema.ErrUnsupportedType)
+	}
+	if _, err := jsonschema.For[map[bool]string](); !errors.Is(err, jsonschema.ErrUnsupportedType) {
+		t.Errorf("For() error = %v, want %v", err, jsonschema.ErrUnsupportedType)
+	}
+	if _, err := jsonschema.For[withBadTag](); err == nil {
+		t.Error("For() error = nil, want an error for the invalid tag")
+	}
+}
+
+func assertJSONEqual(t *testing.T, got any, want string) {
+	t.Helper()
+
+	var wantValue any
+	if err := json.Unmarshal([]byte(want), &wantValue); err != nil {
+		t.Fatalf("Failed to Unmarshal JSON: error = %v", err)
+	}
+	gotBytes, err := json.Marshal(got)
+	if err != nil {
+		t.Fatalf("Failed to Marshal JSON: error = %v", err)
+	}
+	var gotValue any
+	if err := json.Unmarshal(gotBytes, &gotValue); err != nil {
+		t.Fatalf("Failed to Unmarshal JSON: error = %v", err)
+	}
+	if !reflect.DeepEqual(gotValue, wantValue) {
+		t.Errorf("got %s, want %s", gotBytes, want)
+	}
+}
@@ -0,0 +1,260 @@
+package jsonschema
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// ErrIncompatibleSchema is returned when a schema cannot be expressed in the
+// subset of JSON schema supported by a provider.
+var ErrIncompatibleSchema = errors.New("schema not supported")
+
+//nolint:gochecknoglobals
+var (
+	// _openAIStrictFormats are the string formats supported by OpenAI strict
+	// mode.
+	_openAIStrictFormats = []string{
+		"date-time", "time", "date", "duration", "email", "hostname", "ipv4", "ipv6", "uuid",
+	}
+	// _geminiFormats are the string formats supported by Gemini.
+	_geminiFormats = []string{"date-time"}
+)
+
+// OpenAIStrict returns the schema in the subset of JSON schema supported by
+// the strict mode of OpenAI structured outputs and function calling:
+//
+//   - objects forbid additional properties, and all their properties are
+//     required, the optional ones becoming nullable with anyOf;
+//   - oneOf becomes anyOf;
+//   - minLength, maxLength, default and unsupported formats are removed.
+//
+// It fails for objects with free-form properties, such as maps, and for
+// schemas without a type, such as those of interfaces.
+func (d Definition) OpenAIStrict() (Definition, error) {
+	return d.openAIStrict("$")
+}
+
+func (d Definition) openAIStrict(path string) (Definition, error) {
+	if d.Type == "" && d.Ref == "" && d.AnyOf == nil && d.OneOf == nil && d.Enum == nil {
+		return Definition{}, fmt.Errorf("%w in OpenAI strict mode: %s has no type", ErrIncompatibleSchema, path)
+	}
+
+	d.MinLength, d.MaxLength, d.Default = nil, nil, nil
+	if d.Format != "" && !slices.Contains(_openAIStrictFormats, d.Format) {
+		d.Format = ""
+	}
+	d.AnyOf = append(slices.Clone(d.AnyOf), d.OneOf...)
+	d.OneOf = nil
+
+	var err error
+	if d.Defs, err = mapDefinitions(d.Defs, path+".$defs", Definition.openAIStrict); err != nil {
+		return Definition{}, err
+	}
+	if d.AnyOf, err = mapDefinitionList(d.AnyOf, path+".anyOf", Definition.openAIStrict); err != nil {
+		return Definition{}, err
+	}
+	if d.Items != nil {
+		items, err := d.Items.openAIStrict(path + "[]")
+		if err != nil {
+			return Definition{}, err
+		}
+		d.Items = &items
+	}
+
+	if d.Type != Object {
+		return d, nil
+	}
+	if d.AdditionalProperties != nil && d.AdditionalProperties != false {
+		return Definition{}, fmt.Errorf("%w in OpenAI strict mode: %s has additional properties",
+			ErrIncompatibleSchema, path)
+	}
+	d.AdditionalProperties = false
+
+	properties := make(map[string]Definition, len(d.Properties))
+	required := slices.Clone(d.Required)
+	for _, name := range sortedKeys(d.Properties) {
+		property, err := d.Properties[name].openAIStrict(path + "." + name)
+		if err != nil {
+			return Definition{}, err
+		}
+		if !slices.Contains(required, name) {
+			property = nullable(property)
+			required = append(required, name)
+		}
+		properties[name] = property
+	}
+	d.Properties = properties
+	d.Required = required
+	return d, nil
+}
+
+// nullable returns a schema also accepting null.
+func nullable(d Definition) Definition {
+	if slices.ContainsFunc(d.AnyOf, func(def Definition) bool { return def.Type == Null }) {
+		return d
+	}
+	description := d.Description
+	d.Description = ""
+	return Definition{Description: description, AnyOf: []Definition{d, {Type: Null}}}
+}
+
+// RemoveOptionalNulls returns the value decoded from JSON without the null
+// properties the definition does not require, so that it validates against the
+// definition. Such nulls are sent for the optional properties the strict mode
+// of OpenAI made nullable. The value is left unchanged.
+func (d Definition) RemoveOptionalNulls(value any) any {
+	v := validator{root: &d}
+	return v.removeOptionalNulls(d, value, 0)
+}
+
+func (v validator) removeOptionalNulls(d Definition, value any, refDepth int) any {
+	if d.Ref != "" {
+		ref, err := v.resolve(d.Ref)
+		if err != nil || refDepth >= _maxRefDepth {
+			return value
+		}
+		return v.removeOptionalNulls(ref, value, refDepth+1)
+	}
+
+	// The value follows the first schema of the combination it matches.
+	for _, def := range append(slices.Clone(d.AnyOf), d.OneOf...) {
+		if cleaned := v.removeOptionalNulls(def, value, refDepth); v.validate(def, "$", cleaned, 0) == nil {
+			return cleaned
+		}
+	}
+
+	switch value := value.(type) {
+	case map[string]any:
+		cleaned := make(map[string]any, len(value))
+		for name, property := range value {
+			def, ok := d.Properties[name]
+			if !ok {
+				def, _ = d.additionalProperties()
+			} else if property == nil && !slices.Contains(d.Required, name) {
+				continue
+			}
+			cleaned[name] = v.removeOptionalNulls(def, property, 0)
+		}
+		return cleaned
+	case []any:
+		if d.Items == nil {
+			return value
+		}
+		cleaned := make([]any, len(value))
+		for i, item := range value {
+			cleaned[i] = v.removeOptionalNulls(*d.Items, item, 0)
+		}
+		return cleaned
+	}
+	return value
+}
+
+// Gemini returns the schema in the subset of JSON schema supported by the
+// response schemas and function declarations of Gemini:
+//
+//   - references are inlined, and definitions removed;
+//   - anyOf and oneOf of a schema and null become the schema;
+//   - patterns, bounds, defaults, additional properties and unsupported
+//     formats are removed.
+//
+// It fails for recursive schemas, for other anyOf and oneOf, and for schemas
+// without a type, such as those of interfaces.
+func (d Definition) Gemini() (Definition, error) {
+	return d.gemini(&d, "$", nil)
+}
+
+func (d Definition) gemini(root *Definition, path string, refs []string) (Definition, error) {
+	if d.Ref != "" {
+		if slices.Contains(refs, d.Ref) {
+			return Definition{}, fmt.Errorf("%w by Gemini: %s is recursive", ErrIncompatibleSchema, path)
+		}
+		ref, err := validator{root: root}.resolve(d.Ref)
+		if err != nil {
+			return Definition{}, fmt.Errorf("%w by Gemini: %s: %w", ErrIncompatibleSchema, path, err)
+		}
+		if d.Description != "" {
+			ref.Description = d.Description
+		}
+		ref.Defs = nil
+		return ref.gemini(root, path, append(refs, d.Ref))
+	}
+
+	if combination := append(slices.Clone(d.AnyOf), d.OneOf...); len(combination) > 0 {
+		combination = slices.DeleteFunc(combination, func(def Definition) bool { return def.Type == Null })
+		if len(combination) != 1 {
+			return Definition{}, fmt.Errorf("%w by Gemini: %s has anyOf or oneOf", ErrIncompatibleSchema, path)
+		}
+		if d.Description != "" {
+			combination[0].Description = d.Description
+		}
+		return combination[0].gemini(root, path, refs)
+	}
+
+	if d.Type == "" {
+		return Definition{}, fmt.Errorf("%w by Gemini: %s has no type", ErrIncompatibleSchema, path)
+	}
+
+	gemini := Definition{
+		Type:        d.Type,
+		Description: d.Description,
+		Enum:        d.Enum,
+		Required:    d.Required,
+	}
+	if slices.Contains(_geminiFormats, d.Format) {
+		gemini.Format = d.Format
+	}
+
+	var err error
+	if gemini.Properties, err = mapDefinitions(d.Properties, path, func(def Definition, path string) (Definition, error) {
+		return def.gemini(root, path, refs)
+	}); err != nil {
+		return Definition{}, err
+	}
+	if d.Items != nil {
+		items, err := d.Items.gemini(root, path+"[]", refs)
+		if err != nil {
+			return Definition{}, err
+		}
+		gemini.Items = &items
+	}
+	return gemini, nil
+}
+
+func mapDefinitions(
+	defs map[string]Definition, path string, f func(Definition, string) (Definition, error),
+) (map[string]Definition, error) {
+	if defs == nil {
+		return nil, nil
+	}
+	mapped := make(map[string]Definition, len(defs))
+	for _, name := range sortedKeys(defs) {
+		def, err := f(defs[name], path+"."+name)
+		if err != nil {
+			return nil, err
+		}
+		mapped[name] = def
+	}
+	return mapped, nil
+}
+
+func mapDefinitionList(
+	defs []Definition, path string, f func(Definition, string) (Definition, error),
+) ([]Definition, error) {
+	if len(defs) == 0 {
+		return nil, nil
+	}
+	mapped := make([]Definition, len(defs))
+	for i, def := range defs {
+		var err error
+		if mapped[i], err = f(def, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+			return nil, err
+		}
+	}
+	return mapped, nil
+}
+
+func sortedKeys(defs map[string]Definition) []string {
+	return slices.Sorted(maps.Keys(defs))
+}
@@ -0,0 +1,193 @@
+package jsonschema_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/devmiahub/langchaingo/jsonschema"
+)
+
+type event struct {
+	Title    string    `json:"title" jsonschema:"minLength=1"`
+	Kind     string    `json:"kind" enum:"meeting,call"`
+	Start    string    `json:"start" jsonschema:"format=date-time"`
+	Location *location `json:"location,omitempty" description:"where it happens"`
+	Guests   []string  `json:"guests,omitempty" jsonschema:"minItems=1,default=[]"`
+}
+
+type location struct {
+	Name string `json:"name"`
+	URL  string `json:"url,omitempty" jsonschema:"format=uri"`
+}
+
+func TestDefinition_OpenAIStrict(t *testing.T) {
+	t.Parallel()
+
+	schema, err := jsonschema.For[event]()
+	if err != nil {
+		t.Fatalf("For() error = %v", err)
+	}
+	got, err := schema.OpenAIStrict()
+	if err != nil {
+		t.Fatalf("OpenAIStrict() error = %v", err)
+	}
+	assertJSONEqual(t, got, `{
+		"type":"object",
+		"properties":{
+			"title":{"type":"string","properties":{}},
+			"kind":{"type":"string","enum":["meeting","call"],"properties":{}},
+			"start":{"type":"string","format":"date-time","properties":{}},
+			"location":{
+				"description":"where it happens",
+				"anyOf":[
+					{
+						"type":"object",
+						"properties":{
+							"name":{"type":"string","properties":{}},
+							"url":{"anyOf":[{"type":"string","properties":{}},{"type":"null"}]}
+						},
+						"required":["name","url"],
+						"additionalProperties":false
+					},
+					{"type":"null"}
+				]
+			},
+			"guests":{"anyOf":[
+				{"type":"array","items":{"type":"string","properties":{}},"minItems":1,"properties":{}},
+				{"type":"null"}
+			]}
+		},
+		"required":["title","kind","start","guests","location"],
+		"additionalProperties":false
+	}`)
+
+	if err := got.ValidateJSON([]byte(`{"title":"Standup","kind":"call","start":"2024-05-01T09:00:00Z",` +
+		`"location":null,"guests":null}`)); err != nil {
+		t.Errorf("ValidateJSON() error = %v", err)
+	}
+	if err := got.ValidateJSON([]byte(`{"title":"Standup","kind":"call","start":"2024-05-01T09:00:00Z",` +
+		`"location":null,"guests":null,"room":"A"}`)); !errors.Is(err, jsonschema.ErrValidation) {
+		t.Errorf("ValidateJSON() error = %v, want %v", err, jsonschema.ErrValidation)
+	}
+}
+
+func TestDefinition_RemoveOptionalNulls(t *testing.T) {
+	t.Parallel()
+
+	schema, err := jsonschema.For[event]()
+	if err != nil {
+		t.Fatalf("For() error = %v", err)
+	}
+
+	// The nulls of a response in strict mode are removed, nested ones too.
+	data := []byte(`{"title":"Standup","kind":"call","start":"2024-05-01T09:00:00Z",` +
+		`"location":{"name":"Room A","url":null},"guests":null}`)
+	if err := schema.ValidateJSON(data); !errors.Is(err, jsonschema.ErrValidation) {
+		t.Errorf("ValidateJSON() error = %v, want %v", err, jsonschema.ErrValidation)
+	}
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	cleaned := schema.RemoveOptionalNulls(value)
+	assertJSONEqual(t, cleaned, `{"title":"Standup","kind":"call","start":"2024-05-01T09:00:00Z",`+
+		`"location":{"name":"Room A"}}`)
+	if err := schema.Validate(cleaned); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	assertJSONEqual(t, value, string(data))
+
+	// Required nulls are kept, and still fail.
+	value = map[string]any{"title": nil, "kind": "call", "start": "2024-05-01T09:00:00Z"}
+	if err := schema.Validate(schema.RemoveOptionalNulls(value)); !errors.Is(err, jsonschema.ErrValidation) {
+		t.Errorf("Validate() error = %v, want %v", err, jsonschema.ErrValidation)
+	}
+}
+
+func TestDefinition_Gemini(t *testing.T) {
+	t.Parallel()
+
+	schema, err := jsonschema.For[event]()
+	if err != nil {
+		t.Fatalf("For() error = %v", err)
+	}
+	got, err := schema.Gemini()
+	if err != nil {
+		t.Fatalf("Gemini() error = %v", err)
+	}
+	assertJSONEqual(t, got, `{
+		"type":"object",
+		"properties":{
+			"title":{"type":"string","properties":{}},
+			"kind":{"type":"string","enum":["meeting","call"],"properties":{}},
+			"start":{"type":"string","format":"date-time","properties":{}},
+			"location":{
+				"type":"object",
+				"description":"where it happens",
+				"properties":{
+					"name":{"type":"string","properties":{}},
+					"url":{"type":"string","properties":{}}
+				},
+				"required":["name"]
+			},
+			"guests":{"type":"array","items":{"type":"string","properties":{}},"properties":{}}
+		},
+		"required":["title","kind","start"]
+	}`)
+
+	// References are inlined, and nullable combinations unwrapped.
+	strict, err := schema.OpenAIStrict()
+	if err != nil {
+		t.Fatalf("OpenAIStrict() error = %v", err)
+	}
+	if _, err := strict.Gemini(); err != nil {
+		t.Errorf("Gemini() error = %v", err)
+	}
+	withRef := jsonschema.Definition{
+		Type:       jsonschema.Object,
+		Properties: map[string]jsonschema.Definition{"a": {Ref: "#/$defs/a"}},
+		Defs:       map[string]jsonschema.Definition{"a": {Type: jsonschema.String}},
+	}
+	got, err = withRef.Gemini()
+	if err != nil {
+		t.Fatalf("Gemini() error = %v", err)
+	}
+	assertJSONEqual(t, got, `{"type":"object","properties":{"a":{"type":"string","properties":{}}}}`)
+}
+
+func TestDefinition_SubsetsErrors(t *testing.T) {
+	t.Parallel()
+
+	withMap, err := jsonschema.For[map[string]int]()
+	if err != nil {
+		t.Fatalf("For() error = %v", err)
+	}
+	if _, err := withMap.OpenAIStrict(); !errors.Is(err, jsonschema.ErrIncompatibleSchema) {
+		t.Errorf("OpenAIStrict() error = %v, want %v", err, jsonschema.ErrIncompatibleSchema)
+	}
+
+	withAny, err := jsonschema.For[struct {
+		V any `json:"v"`
+	}]()
+	if err != nil {
+		t.Fatalf("For() error = %v", err)
+	}
+	if _, err := withAny.OpenAIStrict(); !errors.Is(err, jsonschema.ErrIncompatibleSchema) {
+		t.Errorf("OpenAIStrict() error = %v, want %v", err, jsonschema.ErrIncompatibleSchema)
+	}
+	if _, err := withAny.Gemini(); !errors.Is(err, jsonschema.ErrIncompatibleSchema) {
+		t.Errorf("Gemini() error = %v, want %v", err, jsonschema.ErrIncompatibleSchema)
+	}
+
+	recursive, err := jsonschema.For[tree]()
+	if err != nil {
+		t.Fatalf("For() error = %v", err)
+	}
+	if _, err := recursive.Gemini(); !errors.Is(err, jsonschema.ErrIncompatibleSchema) {
+		t.Errorf("Gemini() error = %v, want %v", err, jsonschema.ErrIncompatibleSchema)
+	}
+	if _, err := recursive.OpenAIStrict(); err != nil {
+		t.Errorf("OpenAIStrict() error = %v", err)
+	}
+}
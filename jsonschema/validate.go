@@ -0,0 +1,248 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ErrValidation is returned if a value does not match a definition.
+var ErrValidation = errors.New("value does not match schema")
+
+// _timeFormats are the layouts of the formats of dates and times.
+var _timeFormats = map[string]string{ //nolint:gochecknoglobals
+	"date-time": time.RFC3339,
+	"date":      time.DateOnly,
+	"time":      "15:04:05Z07:00",
+}
+
+// Validate checks that a value decoded from JSON with encoding/json matches the
+// definition. It checks types, enums, formats of dates and times, bounds,
+// required, nested and additional properties, items, anyOf, oneOf and $ref
+// references to the root schema and its $defs.
+func (d Definition) Validate(value any) error {
+	v := validator{root: &d}
+	return v.validate(d, "$", value, 0)
+}
+
+// ValidateJSON decodes data and validates it against the definition.
+func (d Definition) ValidateJSON(data []byte) error {
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	return d.Validate(value)
+}
+
+// _maxRefDepth limits the references followed without consuming the value,
+// which only happens with a schema referencing itself.
+const _maxRefDepth = 32
+
+type validator struct {
+	root *Definition
+}
+
+func (v validator) validate(d Definition, path string, value any, refDepth int) error {
+	if d.Ref != "" {
+		if refDepth >= _maxRefDepth {
+			return fmt.Errorf("%w: %s: $ref %q is circular", ErrValidation, path, d.Ref)
+		}
+		ref, err := v.resolve(d.Ref)
+		if err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrValidation, path, err)
+		}
+		if err := v.validate(ref, path, value, refDepth+1); err != nil {
+			return err
+		}
+	}
+
+	if err := d.validateType(path, value); err != nil {
+		return err
+	}
+
+	if len(d.Enum) > 0 {
+		s, ok := value.(string)
+		if !ok || !slices.Contains(d.Enum, s) {
+			return fmt.Errorf("%w: %s must be one of %q", ErrValidation, path, d.Enum)
+		}
+	}
+
+	if err := v.validateCombinations(d, path, value, refDepth); err != nil {
+		return err
+	}
+
+	switch value := value.(type) {
+	case string:
+		return d.validateString(path, value)
+	case float64:
+		return d.validateNumber(path, value)
+	case map[string]any:
+		return v.validateObject(d, path, value)
+	case []any:
+		return v.validateArray(d, path, value)
+	}
+
+	return nil
+}
+
+// resolve returns the definition referenced by ref, which is either the root
+// schema or one of its definitions.
+func (v validator) resolve(ref string) (Definition, error) {
+	if ref == "#" {
+		return *v.root, nil
+	}
+	name, ok := strings.CutPrefix(ref, _defsPrefix)
+	if !ok {
+		return Definition{}, fmt.Errorf("unsupported $ref %q", ref)
+	}
+	def, ok := v.root.Defs[name]
+	if !ok {
+		return Definition{}, fmt.Errorf("unknown $ref %q", ref)
+	}
+	return def, nil
+}
+
+func (v validator) validateCombinations(d Definition, path string, value any, refDepth int) error {
+	if len(d.AnyOf) > 0 && !slices.ContainsFunc(d.AnyOf, func(def Definition) bool {
+		return v.validate(def, path, value, refDepth) == nil
+	}) {
+		return fmt.Errorf("%w: %s must match at least one schema of anyOf", ErrValidation, path)
+	}
+
+	if len(d.OneOf) > 0 {
+		matches := 0
+		for _, def := range d.OneOf {
+			if v.validate(def, path, value, refDepth) == nil {
+				matches++
+			}
+		}
+		if matches != 1 {
+			return fmt.Errorf("%w: %s must match exactly one schema of oneOf, matches %d", ErrValidation, path, matches)
+		}
+	}
+	return nil
+}
+
+func (d Definition) validateString(path string, value string) error {
+	length := utf8.RuneCountInString(value)
+	if d.MinLength != nil && length < *d.MinLength {
+		return fmt.Errorf("%w: %s must be at least %d characters long", ErrValidation, path, *d.MinLength)
+	}
+	if d.MaxLength != nil && length > *d.MaxLength {
+		return fmt.Errorf("%w: %s must be at most %d characters long", ErrValidation, path, *d.MaxLength)
+	}
+	if d.Pattern != "" {
+		re, err := regexp.Compile(d.Pattern)
+		if err != nil {
+			return fmt.Errorf("%w: %s: invalid pattern: %w", ErrValidation, path, err)
+		}
+		if !re.MatchString(value) {
+			return fmt.Errorf("%w: %s must match pattern %q", ErrValidation, path, d.Pattern)
+		}
+	}
+	if layout, ok := _timeFormats[d.Format]; ok {
+		if _, err := time.Parse(layout, value); err != nil {
+			return fmt.Errorf("%w: %s must be in %s format", ErrValidation, path, d.Format)
+		}
+	}
+	return nil
+}
+
+func (d Definition) validateNumber(path string, value float64) error {
+	if d.Minimum != nil && value < *d.Minimum {
+		return fmt.Errorf("%w: %s must be at least %v", ErrValidation, path, *d.Minimum)
+	}
+	if d.Maximum != nil && value > *d.Maximum {
+		return fmt.Errorf("%w: %s must be at most %v", ErrValidation, path, *d.Maximum)
+	}
+	return nil
+}
+
+func (v validator) validateObject(d Definition, path string, value map[string]any) error {
+	for _, name := range d.Required {
+		if _, ok := value[name]; !ok {
+			return fmt.Errorf("%w: %s is missing required property %q", ErrValidation, path, name)
+		}
+	}
+	for name, property := range value {
+		def, ok := d.Properties[name]
+		if !ok {
+			def, ok = d.additionalProperties()
+			if !ok {
+				return fmt.Errorf("%w: %s has unexpected property %q", ErrValidation, path, name)
+			}
+		}
+		if err := v.validate(def, path+"."+name, property, 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// additionalProperties returns the definition of the properties not in
+// Properties, and false if they are forbidden.
+func (d Definition) additionalProperties() (Definition, bool) {
+	switch additional := d.AdditionalProperties.(type) {
+	case bool:
+		return Definition{}, additional
+	case Definition:
+		return additional, true
+	case *Definition:
+		if additional != nil {
+			return *additional, true
+		}
+	}
+	return Definition{}, true
+}
+
+func (v validator) validateArray(d Definition, path string, value []any) error {
+	if d.MinItems != nil && len(value) < *d.MinItems {
+		return fmt.Errorf("%w: %s must have at least %d items", ErrValidation, path, *d.MinItems)
+	}
+	if d.MaxItems != nil && len(value) > *d.MaxItems {
+		return fmt.Errorf("%w: %s must have at most %d items", ErrValidation, path, *d.MaxItems)
+	}
+	if d.Items == nil {
+		return nil
+	}
+	for i, item := range value {
+		if err := v.validate(*d.Items, fmt.Sprintf("%s[%d]", path, i), item, 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d Definition) validateType(path string, value any) error {
+	var ok bool
+	switch d.Type {
+	case "":
+		return nil
+	case Object:
+		_, ok = value.(map[string]any)
+	case Array:
+		_, ok = value.([]any)
+	case String:
+		_, ok = value.(string)
+	case Number:
+		_, ok = value.(float64)
+	case Integer:
+		f, isNumber := value.(float64)
+		ok = isNumber && f == math.Trunc(f)
+	case Boolean:
+		_, ok = value.(bool)
+	case Null:
+		ok = value == nil
+	}
+
+	if !ok {
+		return fmt.Errorf("%w: %s must be of type %s", ErrValidation, path, d.Type)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package jsonschema_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/devmiahub/langchaingo/jsonschema"
+)
+
+func TestDefinition_ValidateJSON(t *testing.T) {
+	t.Parallel()
+
+	def := jsonschema.Definition{
+		Type: jsonschema.Object,
+		Properties: map[string]jsonschema.Definition{
+			"city":  {Type: jsonschema.String},
+			"unit":  {Type: jsonschema.String, Enum: []string{"celsius", "fahrenheit"}},
+			"days":  {Type: jsonschema.Integer},
+			"hours": {Type: jsonschema.Array, Items: &jsonschema.Definition{Type: jsonschema.Number}},
+		},
+		Required: []string{"city"},
+	}
+
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{name: "valid", data: `{"city":"Paris","unit":"celsius","days":3,"hours":[1.5,2]}`},
+		{name: "missing required", data: `{"unit":"celsius"}`, wantErr: true},
+		{name: "wrong type", data: `{"city":3}`, wantErr: true},
+		{name: "not in enum", data: `{"city":"Paris","unit":"kelvin"}`, wantErr: true},
+		{name: "not an integer", data: `{"city":"Paris","days":1.5}`, wantErr: true},
+		{name: "wrong item type", data: `{"city":"Paris","hours":["one"]}`, wantErr: true},
+		{name: "not an object", data: `"Paris"`, wantErr: true},
+		{name: "unknown properties are allowed", data: `{"city":"Paris","country":"France"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := def.ValidateJSON([]byte(tt.data))
+			if tt.wantErr != errors.Is(err, jsonschema.ErrValidation) {
+				t.Errorf("ValidateJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDefinition_ValidateKeywords(t *testing.T) {
+	t.Parallel()
+
+	one, three := 1, 3
+	zero, ten := 0.0, 10.0
+	def := jsonschema.Definition{
+		Type: jsonschema.Object,
+		Properties: map[string]jsonschema.Definition{
+			"code":  {Type: jsonschema.String, Pattern: `^[A-Z]+$`, MinLength: &one, MaxLength: &three},
+			"score": {Type: jsonschema.Number, Minimum: &zero, Maximum: &ten},
+			"tags":  {Type: jsonschema.Array, Items: &jsonschema.Definition{Type: jsonschema.String}, MaxItems: &three},
+			"at":    {Type: jsonschema.String, Format: "date-time"},
+			"id":    {AnyOf: []jsonschema.Definition{{Type: jsonschema.String}, {Type: jsonschema.Integer}}},
+			"value": {OneOf: []jsonschema.Definition{{Type: jsonschema.Number}, {Type: jsonschema.Integer}}},
+			"meta":  {Type: jsonschema.Object, AdditionalProperties: &jsonschema.Definition{Type: jsonschema.Integer}},
+			"next":  {Ref: "#"},
+			"unit":  {Ref: "#/$defs/unit"},
+		},
+		Defs: map[string]jsonschema.Definition{
+			"unit": {Type: jsonschema.String, Enum: []string{"m", "s"}},
+		},
+		AdditionalProperties: false,
+	}
+
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{name: "valid", data: `{"code":"AB","score":9.5,"tags":["a"],"at":"2024-05-01T09:00:00+02:00",` +
+			`"id":"x","value":1.5,"meta":{"a":1},"next":{"code":"C"},"unit":"m"}`},
+		{name: "pattern", data: `{"code":"ab"}`, wantErr: true},
+		{name: "too short", data: `{"code":""}`, wantErr: true},
+		{name: "too long", data: `{"code":"ABCD"}`, wantErr: true},
+		{name: "below minimum", data: `{"score":-1}`, wantErr: true},
+		{name: "above maximum", data: `{"score":11}`, wantErr: true},
+		{name: "too many items", data: `{"tags":["a","b","c","d"]}`, wantErr: true},
+		{name: "format", data: `{"at":"yesterday"}`, wantErr: true},
+		{name: "anyOf", data: `{"id":true}`, wantErr: true},
+		{name: "oneOf matches both", data: `{"value":1}`, wantErr: true},
+		{name: "additional property schema", data: `{"meta":{"a":"one"}}`, wantErr: true},
+		{name: "additional property forbidden", data: `{"other":1}`, wantErr: true},
+		{name: "root reference", data: `{"next":{"next":{"score":20}}}`, wantErr: true},
+		{name: "definition reference", data: `{"unit":"kg"}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := def.ValidateJSON([]byte(tt.data))
+			if tt.wantErr != errors.Is(err, jsonschema.ErrValidation) {
+				t.Errorf("ValidateJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
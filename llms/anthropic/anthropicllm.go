@@ -37,8 +37,9 @@ type LLM struct {
 }
 
 var (
-	_ llms.Model          = (*LLM)(nil)
-	_ llms.ReasoningModel = (*LLM)(nil)
+	_ llms.Model           = (*LLM)(nil)
+	_ llms.ReasoningModel  = (*LLM)(nil)
+	_ llms.ToolChoiceModel = (*LLM)(nil)
 )
 
 // New returns a new Anthropic LLM.
@@ -154,6 +155,7 @@ func generateMessagesContent(ctx context.Context, o *LLM, messages []llms.Messag
 		Temperature:            opts.Temperature,
 		TopP:                   opts.TopP,
 		Tools:                  tools,
+		ToolChoice:             toolChoiceToToolChoice(opts.ToolChoice),
 		Thinking:               thinking,
 		BetaHeaders:            betaHeaders,
 		StreamingFunc:          opts.StreamingFunc,
@@ -264,6 +266,28 @@ func toolsToTools(tools []llms.Tool) []anthropicclient.Tool {
 	return toolReq
 }
 
+// toolChoiceToToolChoice converts the tool choice of the call options, which
+// can be a string such as "auto" or "required", or a llms.ToolChoice naming a
+// function.
+func toolChoiceToToolChoice(toolChoice any) *anthropicclient.ToolChoice {
+	switch choice := toolChoice.(type) {
+	case string:
+		switch choice {
+		case "auto":
+			return &anthropicclient.ToolChoice{Type: "auto"}
+		case "any", "required":
+			return &anthropicclient.ToolChoice{Type: "any"}
+		}
+	case llms.ToolChoice:
+		return toolChoiceToToolChoice(&choice)
+	case *llms.ToolChoice:
+		if choice != nil && choice.Function != nil {
+			return &anthropicclient.ToolChoice{Type: "tool", Name: choice.Function.Name}
+		}
+	}
+	return nil
+}
+
 func processMessages(messages []llms.MessageContent) ([]anthropicclient.ChatMessage, string, error) {
 	chatMessages := make([]anthropicclient.ChatMessage, 0, len(messages))
 	systemPrompt := ""
@@ -436,6 +460,11 @@ func handleToolMessage(msg llms.MessageContent) (anthropicclient.ChatMessage, er
 	return anthropicclient.ChatMessage{}, fmt.Errorf("anthropic: %w for tool message", ErrInvalidContentType)
 }
 
+// SupportsToolChoice implements the ToolChoiceModel interface.
+func (o *LLM) SupportsToolChoice() bool {
+	return true
+}
+
 // SupportsReasoning implements the ReasoningModel interface.
 // Returns true if the current model supports extended thinking capabilities.
 func (o *LLM) SupportsReasoning() bool {
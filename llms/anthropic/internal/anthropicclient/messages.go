@@ -42,12 +42,13 @@ type messagePayload struct {
 	Stream      bool          `json:"stream,omitempty"`
 	Temperature float64       `json:"temperature"`
 	Tools       []Tool        `json:"tools,omitempty"`
+	ToolChoice  *ToolChoice   `json:"tool_choice,omitempty"`
 	TopP        float64       `json:"top_p,omitempty"`
 
 	// Extended thinking parameters (Claude 3.7+)
 	Thinking *ThinkingConfig `json:"thinking,omitempty"`
 
-	StreamingFunc          func(ctx context.Context, chunk []byte) error                      `json:"-"`
+	StreamingFunc          func(ctx context.Context, chunk []byte) error                 `json:"-"`
 	StreamingReasoningFunc func(ctx context.Context, reasoningChunk, chunk []byte) error `json:"-"`
 }
 
@@ -64,6 +65,14 @@ type Tool struct {
 	InputSchema any    `json:"input_schema,omitempty"`
 }
 
+// ToolChoice controls how the model uses the tools of the request.
+type ToolChoice struct {
+	// Type is one of "auto", "any" or "tool".
+	Type string `json:"type"`
+	// Name is the name of the tool to use, when Type is "tool".
+	Name string `json:"name,omitempty"`
+}
+
 // CacheControl represents Anthropic's prompt caching configuration.
 type CacheControl struct {
 	Type string `json:"type"`
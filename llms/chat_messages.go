@@ -212,3 +212,80 @@ func ConvertChatMessageToModel(m ChatMessage) ChatMessageModel {
 		},
 	}
 }
+
+// MessageContentFromChatMessage converts a chat message to a message content.
+// The tool calls and thinking of AI messages and the tool call IDs of tool
+// messages are kept as parts of their own.
+func MessageContentFromChatMessage(m ChatMessage) MessageContent {
+	mc := MessageContent{Role: m.GetType()}
+
+	switch m := m.(type) {
+	case AIChatMessage:
+		if m.ThinkingContent != "" || m.ThoughtSignature != "" {
+			mc.Parts = append(mc.Parts, ThinkingPartWithSignature(m.ThinkingContent, m.ThoughtSignature))
+		}
+		if m.Content != "" {
+			mc.Parts = append(mc.Parts, TextContent{Text: m.Content})
+		}
+		for _, toolCall := range m.ToolCalls {
+			mc.Parts = append(mc.Parts, toolCall)
+		}
+	case ToolChatMessage:
+		mc.Parts = append(mc.Parts, ToolCallResponse{ToolCallID: m.ID, Content: m.Content})
+	case FunctionChatMessage:
+		mc.Parts = append(mc.Parts, ToolCallResponse{Name: m.Name, Content: m.Content})
+	default:
+		mc.Parts = append(mc.Parts, TextContent{Text: m.GetContent()})
+	}
+
+	return mc
+}
+
+// ChatMessageFromMessageContent converts a message content to a chat message.
+// Text parts are joined into the content of the message. Parts a chat message
+// cannot represent, like images, are dropped.
+func ChatMessageFromMessageContent(mc MessageContent) ChatMessage { //nolint:ireturn
+	var (
+		texts     []string
+		toolCalls []ToolCall
+		thinking  ThinkingContent
+		response  ToolCallResponse
+	)
+	for _, part := range mc.Parts {
+		if cached, ok := part.(CachedContent); ok {
+			part = cached.ContentPart
+		}
+		switch part := part.(type) {
+		case TextContent:
+			texts = append(texts, part.Text)
+		case ToolCall:
+			toolCalls = append(toolCalls, part)
+		case ThinkingContent:
+			thinking = part
+		case ToolCallResponse:
+			response = part
+			texts = append(texts, part.Content)
+		}
+	}
+	content := strings.Join(texts, "")
+
+	switch mc.Role {
+	case ChatMessageTypeAI:
+		return AIChatMessage{
+			Content:          content,
+			ToolCalls:        toolCalls,
+			ThinkingContent:  thinking.Thinking,
+			ThoughtSignature: thinking.Signature,
+		}
+	case ChatMessageTypeHuman:
+		return HumanChatMessage{Content: content}
+	case ChatMessageTypeSystem:
+		return SystemChatMessage{Content: content}
+	case ChatMessageTypeTool:
+		return ToolChatMessage{ID: response.ToolCallID, Content: content}
+	case ChatMessageTypeFunction:
+		return FunctionChatMessage{Name: response.Name, Content: content}
+	default:
+		return GenericChatMessage{Role: string(mc.Role), Content: content}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/devmiahub/langchaingo/llms"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetBufferString(t *testing.T) {
@@ -68,3 +69,37 @@ type unsupportedChatMessage struct{}
 
 func (m unsupportedChatMessage) GetType() llms.ChatMessageType { return "unsupported" }
 func (m unsupportedChatMessage) GetContent() string            { return "Unsupported message" }
+
+func TestChatMessageMessageContentConversion(t *testing.T) {
+	t.Parallel()
+
+	toolCall := llms.ToolCall{ID: "call_1", Type: "function", FunctionCall: &llms.FunctionCall{Name: "search", Arguments: "{}"}}
+	messages := []llms.ChatMessage{
+		llms.SystemChatMessage{Content: "be helpful"},
+		llms.HumanChatMessage{Content: "hi"},
+		llms.AIChatMessage{
+			Content:          "let me search",
+			ToolCalls:        []llms.ToolCall{toolCall},
+			ThinkingContent:  "thinking",
+			ThoughtSignature: "sig",
+		},
+		llms.ToolChatMessage{ID: "call_1", Content: "result"},
+		llms.FunctionChatMessage{Name: "search", Content: "result"},
+	}
+
+	for _, message := range messages {
+		mc := llms.MessageContentFromChatMessage(message)
+		require.Equal(t, message.GetType(), mc.Role)
+		require.Equal(t, message, llms.ChatMessageFromMessageContent(mc))
+	}
+
+	mc := llms.MessageContent{
+		Role: llms.ChatMessageTypeHuman,
+		Parts: []llms.ContentPart{
+			llms.TextContent{Text: "what is "},
+			llms.ImageURLContent{URL: "https://example.com/cat.png"},
+			llms.WithCacheControl(llms.TextContent{Text: "this?"}, &llms.CacheControl{Type: "ephemeral"}),
+		},
+	}
+	require.Equal(t, llms.HumanChatMessage{Content: "what is this?"}, llms.ChatMessageFromMessageContent(mc))
+}
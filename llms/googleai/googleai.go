@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/devmiahub/langchaingo/internal/imageutil"
+	"github.com/devmiahub/langchaingo/jsonschema"
 	"github.com/devmiahub/langchaingo/llms"
 	"github.com/google/generative-ai-go/genai"
 	"google.golang.org/api/iterator"
@@ -123,6 +124,13 @@ func (g *GoogleAI) GenerateContent(
 		model.ResponseMIMEType = ResponseMIMETypeJson
 	}
 
+	if opts.ResponseSchema != nil {
+		if model.ResponseSchema, err = convertResponseSchema(opts.ResponseSchema); err != nil {
+			return nil, err
+		}
+		model.ResponseMIMEType = ResponseMIMETypeJson
+	}
+
 	var response *llms.ContentResponse
 
 	if len(messages) == 1 {
@@ -431,6 +439,10 @@ func convertSchemaRecursive(schemaMap map[string]any, toolIndex int, propertyPat
 		schema.Type = convertToolSchemaType(tyString)
 	}
 
+	if format, ok := schemaMap["format"].(string); ok {
+		schema.Format = format
+	}
+
 	if desc, ok := schemaMap["description"]; ok {
 		descString, ok := desc.(string)
 		if !ok {
@@ -439,6 +451,20 @@ func convertSchemaRecursive(schemaMap map[string]any, toolIndex int, propertyPat
 		schema.Description = descString
 	}
 
+	if enum, ok := schemaMap["enum"]; ok {
+		values, ok := enum.([]any)
+		if !ok {
+			return nil, fmt.Errorf("tool [%d], property [%s]: expected array for enum", toolIndex, propertyPath)
+		}
+		for _, value := range values {
+			valueString, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("tool [%d], property [%s]: expected string for enum", toolIndex, propertyPath)
+			}
+			schema.Enum = append(schema.Enum, valueString)
+		}
+	}
+
 	// Handle object properties recursively
 	if properties, ok := schemaMap["properties"]; ok {
 		propMap, ok := properties.(map[string]any)
@@ -506,6 +532,44 @@ func convertSchemaRecursive(schemaMap map[string]any, toolIndex int, propertyPat
 	return schema, nil
 }
 
+// convertResponseSchema converts a response schema to a genai schema.
+// A *jsonschema.Definition is first converted to the subset supported by
+// Gemini, inlining its references.
+func convertResponseSchema(responseSchema *llms.ResponseSchema) (*genai.Schema, error) {
+	jsonSchema := responseSchema.Schema
+	var definition *jsonschema.Definition
+	switch s := jsonSchema.(type) {
+	case jsonschema.Definition:
+		definition = &s
+	case *jsonschema.Definition:
+		definition = s
+	}
+	if definition != nil {
+		gemini, err := definition.Gemini()
+		if err != nil {
+			return nil, fmt.Errorf("converting response schema: %w", err)
+		}
+		jsonSchema = gemini
+	}
+
+	data, err := json.Marshal(jsonSchema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal response schema: %w", err)
+	}
+	var schemaMap map[string]any
+	if err := json.Unmarshal(data, &schemaMap); err != nil {
+		return nil, fmt.Errorf("response schema should be a JSON object: %w", err)
+	}
+	schema, err := convertSchemaRecursive(schemaMap, 0, "")
+	if err != nil {
+		return nil, fmt.Errorf("converting response schema: %w", err)
+	}
+	if schema.Description == "" {
+		schema.Description = responseSchema.Description
+	}
+	return schema, nil
+}
+
 // convertTools converts from a list of langchaingo tools to a list of genai
 // tools.
 func convertTools(tools []llms.Tool) ([]*genai.Tool, error) {
@@ -3,9 +3,11 @@ package googleai
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/google/generative-ai-go/genai"
 	"github.com/stretchr/testify/assert"
+	"github.com/devmiahub/langchaingo/jsonschema"
 	"github.com/devmiahub/langchaingo/llms"
 )
 
@@ -538,3 +540,33 @@ func TestSafetySettings(t *testing.T) {
 		assert.Equal(t, genai.HarmBlockThreshold(harmThreshold), setting.Threshold)
 	}
 }
+
+func TestConvertResponseSchema(t *testing.T) {
+	t.Parallel()
+
+	type item struct {
+		Name string `json:"name" description:"name of the item"`
+		Kind string `json:"kind,omitempty" enum:"book,film"`
+	}
+	definition, err := jsonschema.For[struct {
+		Items []item    `json:"items"`
+		At    time.Time `json:"at"`
+	}]()
+	assert.NoError(t, err)
+
+	schema, err := convertResponseSchema(&llms.ResponseSchema{Name: "list", Description: "A list.", Schema: definition})
+	assert.NoError(t, err)
+	assert.Equal(t, genai.TypeObject, schema.Type)
+	assert.Equal(t, "A list.", schema.Description)
+	assert.ElementsMatch(t, []string{"items", "at"}, schema.Required)
+	assert.Equal(t, "date-time", schema.Properties["at"].Format)
+
+	items := schema.Properties["items"].Items
+	assert.Equal(t, genai.TypeObject, items.Type)
+	assert.Equal(t, "name of the item", items.Properties["name"].Description)
+	assert.Equal(t, []string{"book", "film"}, items.Properties["kind"].Enum)
+	assert.Equal(t, []string{"name"}, items.Required)
+
+	_, err = convertResponseSchema(&llms.ResponseSchema{Schema: map[string]any{"type": "array", "items": "string"}})
+	assert.Error(t, err)
+}
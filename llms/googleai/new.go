@@ -22,8 +22,9 @@ type GoogleAI struct {
 }
 
 var (
-	_ llms.Model          = &GoogleAI{}
-	_ llms.ReasoningModel = &GoogleAI{}
+	_ llms.Model                 = &GoogleAI{}
+	_ llms.ReasoningModel        = &GoogleAI{}
+	_ llms.StructuredOutputModel = &GoogleAI{}
 )
 
 // New creates a new GoogleAI client.
@@ -64,6 +65,12 @@ func (g *GoogleAI) Close() error {
 	return nil
 }
 
+// SupportsStructuredOutput implements the StructuredOutputModel interface.
+// The response schema is sent as the response schema of the generation config.
+func (g *GoogleAI) SupportsStructuredOutput() bool {
+	return true
+}
+
 // SupportsReasoning implements the ReasoningModel interface.
 // Returns true if the current model supports reasoning/thinking tokens.
 func (g *GoogleAI) SupportsReasoning() bool {
@@ -4,6 +4,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"time"
 )
 
 func (mc MessageContent) MarshalJSON() ([]byte, error) {
@@ -31,7 +32,7 @@ func (mc MessageContent) MarshalJSON() ([]byte, error) {
 func (mc *MessageContent) UnmarshalJSON(data []byte) error {
 	var m struct {
 		Role  ChatMessageType `json:"role"`
-		Text  string          `json:"text"`
+		Text  *string         `json:"text"`
 		Parts []struct {
 			Type     string `json:"type"`
 			Text     string `json:"text,omitempty"`
@@ -49,9 +50,10 @@ func (mc *MessageContent) UnmarshalJSON(data []byte) error {
 			} `json:"file_data,omitempty"`
 			ID       string `json:"id"`
 			ToolCall struct {
-				ID           string        `json:"id"`
-				Type         string        `json:"type"`
-				FunctionCall *FunctionCall `json:"function"`
+				ID               string        `json:"id"`
+				Type             string        `json:"type"`
+				FunctionCall     *FunctionCall `json:"function"`
+				ThoughtSignature string        `json:"thought_signature,omitempty"`
 			} `json:"tool_call"`
 			ToolResponse struct {
 				ToolCallID string `json:"tool_call_id"`
@@ -62,6 +64,7 @@ func (mc *MessageContent) UnmarshalJSON(data []byte) error {
 				Thinking  string `json:"thinking"`
 				Signature string `json:"signature,omitempty"`
 			} `json:"thinking,omitempty"`
+			CacheControl *cacheControlJSON `json:"cache_control,omitempty"`
 		} `json:"parts"`
 	}
 	if err := json.Unmarshal(data, &m); err != nil {
@@ -70,49 +73,60 @@ func (mc *MessageContent) UnmarshalJSON(data []byte) error {
 	mc.Role = m.Role
 
 	for _, part := range m.Parts {
+		var contentPart ContentPart
 		switch part.Type {
 		case "text", "":
-			mc.Parts = append(mc.Parts, TextContent{Text: part.Text})
+			contentPart = TextContent{Text: part.Text}
 		case "image_url":
-			mc.Parts = append(mc.Parts, ImageURLContent{
+			contentPart = ImageURLContent{
 				URL:    part.ImageURL.URL,
 				Detail: part.ImageURL.Detail,
-			})
+			}
 		case "binary":
 			decoded, err := base64.StdEncoding.DecodeString(part.Binary.Data)
 			if err != nil {
 				return fmt.Errorf("failed to decode binary data: %w", err)
 			}
-			mc.Parts = append(mc.Parts, BinaryContent{MIMEType: part.Binary.MIMEType, Data: decoded})
+			contentPart = BinaryContent{MIMEType: part.Binary.MIMEType, Data: decoded}
 		case "tool_call":
-			mc.Parts = append(mc.Parts, ToolCall{
-				ID:           part.ToolCall.ID,
-				Type:         part.ToolCall.Type,
-				FunctionCall: part.ToolCall.FunctionCall,
-			})
+			contentPart = ToolCall{
+				ID:               part.ToolCall.ID,
+				Type:             part.ToolCall.Type,
+				FunctionCall:     part.ToolCall.FunctionCall,
+				ThoughtSignature: part.ToolCall.ThoughtSignature,
+			}
 		case "tool_response":
-			mc.Parts = append(mc.Parts, ToolCallResponse{
+			contentPart = ToolCallResponse{
 				ToolCallID: part.ToolResponse.ToolCallID,
 				Name:       part.ToolResponse.Name,
 				Content:    part.ToolResponse.Content,
-			})
+			}
 		case "thinking":
-			mc.Parts = append(mc.Parts, ThinkingContent{
+			contentPart = ThinkingContent{
 				Thinking:  part.Thinking.Thinking,
 				Signature: part.Thinking.Signature,
-			})
+			}
 		case "file_data":
-			mc.Parts = append(mc.Parts, FileContent{
+			contentPart = FileContent{
 				MIMEType: part.FileData.MIMEType,
 				URI:      part.FileData.URI,
-			})
+			}
 		default:
 			return fmt.Errorf("unknown content type: '%s'", part.Type)
 		}
+
+		if part.CacheControl != nil {
+			cacheControl, err := part.CacheControl.toCacheControl()
+			if err != nil {
+				return err
+			}
+			contentPart = CachedContent{ContentPart: contentPart, CacheControl: cacheControl}
+		}
+		mc.Parts = append(mc.Parts, contentPart)
 	}
 	// Special case: handle single text part directly:
-	if len(mc.Parts) == 0 && m.Text != "" {
-		mc.Parts = []ContentPart{TextContent{Text: m.Text}}
+	if len(mc.Parts) == 0 && m.Text != nil {
+		mc.Parts = []ContentPart{TextContent{Text: *m.Text}}
 	}
 	return nil
 }
@@ -237,6 +251,9 @@ func (tc ToolCall) MarshalJSON() ([]byte, error) {
 			"function": json.RawMessage(fc),
 		},
 	}
+	if tc.ThoughtSignature != "" {
+		m.ToolCall["thought_signature"] = tc.ThoughtSignature
+	}
 	return json.Marshal(m)
 }
 
@@ -271,6 +288,9 @@ func (tc *ToolCall) UnmarshalJSON(data []byte) error {
 	tc.ID = id
 	tc.Type = typ
 	tc.FunctionCall = &fc
+	if signature, ok := toolCall["thought_signature"].(string); ok {
+		tc.ThoughtSignature = signature
+	}
 	return nil
 }
 
@@ -396,3 +416,47 @@ func (tc *ThinkingContent) UnmarshalJSON(data []byte) error {
 	}
 	return nil
 }
+
+// cacheControlJSON is the JSON form of CacheControl within a cached part,
+// which, unlike CacheControl itself, keeps the duration.
+type cacheControlJSON struct {
+	Type     string `json:"type,omitempty"`
+	Duration string `json:"duration,omitempty"`
+}
+
+func (cc cacheControlJSON) toCacheControl() (*CacheControl, error) {
+	cacheControl := &CacheControl{Type: cc.Type}
+	if cc.Duration != "" {
+		duration, err := time.ParseDuration(cc.Duration)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cache control duration: %w", err)
+		}
+		cacheControl.Duration = duration
+	}
+	return cacheControl, nil
+}
+
+// MarshalJSON marshals the cached part as the part it wraps with an
+// additional "cache_control" field.
+func (cc CachedContent) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(cc.ContentPart)
+	if err != nil {
+		return nil, err
+	}
+	if cc.CacheControl == nil {
+		return data, nil
+	}
+
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	cacheControl := cacheControlJSON{Type: cc.CacheControl.Type}
+	if cc.CacheControl.Duration != 0 {
+		cacheControl.Duration = cc.CacheControl.Duration.String()
+	}
+	if m["cache_control"], err = json.Marshal(cacheControl); err != nil {
+		return nil, err
+	}
+	return json.Marshal(m)
+}
@@ -3,6 +3,7 @@ package llms
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"sigs.k8s.io/yaml"
@@ -485,6 +486,35 @@ role: assistant
 				},
 			},
 		},
+		{
+			name: "empty text part",
+			in: MessageContent{
+				Role:  "user",
+				Parts: []ContentPart{TextContent{Text: ""}},
+			},
+			assertedJSON: `{"role":"user","text":""}`,
+		},
+		{
+			name: "tool use with thought signature",
+			in: MessageContent{
+				Role: "assistant",
+				Parts: []ContentPart{
+					ThinkingPartWithSignature("Let me check the weather.", "sig01"),
+					ToolCall{Type: "function", ID: "tc01", FunctionCall: &FunctionCall{Name: "get_current_weather", Arguments: `{}`}, ThoughtSignature: "sig02"},
+				},
+			},
+		},
+		{
+			name: "cached content",
+			in: MessageContent{
+				Role: "user",
+				Parts: []ContentPart{
+					WithCacheControl(TextContent{Text: "A long document."}, &CacheControl{Type: "ephemeral", Duration: time.Hour}),
+					TextContent{Text: "Summarize it."},
+				},
+			},
+			assertedJSON: `{"role":"user","parts":[{"cache_control":{"type":"ephemeral","duration":"1h0m0s"},"text":"A long document.","type":"text"},{"text":"Summarize it.","type":"text"}]}`,
+		},
 	}
 
 	// Round-trip both JSON and YAML:
@@ -188,9 +188,25 @@ type ResponseFormatJSONSchemaProperty struct {
 }
 
 type ResponseFormatJSONSchema struct {
-	Name   string                            `json:"name"`
-	Strict bool                              `json:"strict"`
-	Schema *ResponseFormatJSONSchemaProperty `json:"schema"`
+	Name        string                            `json:"name"`
+	Description string                            `json:"description,omitempty"`
+	Strict      bool                              `json:"strict"`
+	Schema      *ResponseFormatJSONSchemaProperty `json:"schema"`
+	// RawSchema is a JSON schema used instead of Schema when set.
+	RawSchema json.RawMessage `json:"-"`
+}
+
+// MarshalJSON implements json.Marshaler, sending RawSchema as the schema when
+// it is set.
+func (s ResponseFormatJSONSchema) MarshalJSON() ([]byte, error) {
+	type alias ResponseFormatJSONSchema
+	if len(s.RawSchema) == 0 {
+		return json.Marshal(alias(s))
+	}
+	return json.Marshal(struct {
+		alias
+		Schema json.RawMessage `json:"schema"`
+	}{alias: alias(s), Schema: s.RawSchema})
 }
 
 // ResponseFormat is the format of the response.
@@ -2,11 +2,14 @@ package openai
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/devmiahub/langchaingo/callbacks"
+	"github.com/devmiahub/langchaingo/jsonschema"
 	"github.com/devmiahub/langchaingo/llms"
 	"github.com/devmiahub/langchaingo/llms/openai/internal/openaiclient"
 )
@@ -17,6 +20,9 @@ type LLM struct {
 	CallbacksHandler callbacks.Handler
 	client           *openaiclient.Client
 	model            string // Track current model for reasoning detection
+	// structuredOutput overrides whether the model supports JSON schema
+	// response formats when not nil.
+	structuredOutput *bool
 }
 
 const (
@@ -78,8 +84,10 @@ func getModelCapabilities(model string) ModelCapability {
 }
 
 var (
-	_ llms.Model          = (*LLM)(nil)
-	_ llms.ReasoningModel = (*LLM)(nil)
+	_ llms.Model                 = (*LLM)(nil)
+	_ llms.ReasoningModel        = (*LLM)(nil)
+	_ llms.StructuredOutputModel = (*LLM)(nil)
+	_ llms.ToolChoiceModel       = (*LLM)(nil)
 )
 
 // New returns a new OpenAI LLM.
@@ -88,10 +96,18 @@ func New(opts ...Option) (*LLM, error) {
 	if err != nil {
 		return nil, err
 	}
+	structuredOutput := opt.structuredOutput
+	if structuredOutput == nil && !opt.isOpenAIEndpoint() {
+		// Servers reached through another base URL, such as Ollama or Groq,
+		// and Azure deployments may serve any model.
+		unsupported := false
+		structuredOutput = &unsupported
+	}
 	return &LLM{
 		client:           c,
 		CallbacksHandler: opt.callbackHandler,
 		model:            c.Model, // Store the model for reasoning detection
+		structuredOutput: structuredOutput,
 	}, err
 }
 
@@ -323,6 +339,13 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 	if o.client.ResponseFormat != nil {
 		req.ResponseFormat = o.client.ResponseFormat
 	}
+	if opts.ResponseSchema != nil {
+		responseFormat, err := responseFormatFromSchema(opts.ResponseSchema)
+		if err != nil {
+			return nil, err
+		}
+		req.ResponseFormat = responseFormat
+	}
 
 	result, err := o.client.CreateChat(ctx, req)
 	if err != nil {
@@ -422,6 +445,85 @@ func (o *LLM) SupportsReasoning() bool {
 	return false
 }
 
+// structuredOutputModels are the prefixes of the names of the OpenAI models
+// supporting JSON schema response formats.
+//
+//nolint:gochecknoglobals
+var structuredOutputModels = []string{"gpt-4o", "gpt-4.1", "gpt-4.5", "gpt-5", "o1-2024-12-17", "o3", "o4-mini"}
+
+// SupportsStructuredOutput implements the StructuredOutputModel interface.
+// Returns true for the OpenAI models known to support JSON schema response
+// formats, unless set otherwise with WithStructuredOutput. Models reached
+// through a custom base URL or Azure are not considered to support them by
+// default.
+func (o *LLM) SupportsStructuredOutput() bool {
+	if o.structuredOutput != nil {
+		return *o.structuredOutput
+	}
+
+	model := o.model
+	if model == "" {
+		model = o.client.Model
+	}
+
+	modelLower := strings.ToLower(model)
+	if modelLower == "o1" {
+		return true
+	}
+	// The first gpt-4o snapshot predates structured outputs.
+	if strings.HasPrefix(modelLower, "gpt-4o-2024-05-13") {
+		return false
+	}
+	return slices.ContainsFunc(structuredOutputModels, func(prefix string) bool {
+		return strings.HasPrefix(modelLower, prefix)
+	})
+}
+
+// SupportsToolChoice implements the ToolChoiceModel interface.
+func (o *LLM) SupportsToolChoice() bool {
+	return true
+}
+
+func definitionFromSchema(schema any) (jsonschema.Definition, bool) {
+	switch schema := schema.(type) {
+	case jsonschema.Definition:
+		return schema, true
+	case *jsonschema.Definition:
+		if schema != nil {
+			return *schema, true
+		}
+	}
+	return jsonschema.Definition{}, false
+}
+
+// responseFormatFromSchema converts a response schema to a JSON schema
+// response format.
+// Strict schemas given as a jsonschema.Definition are converted to the subset
+// supported by the strict mode.
+func responseFormatFromSchema(schema *llms.ResponseSchema) (*ResponseFormat, error) {
+	jsonSchema := schema.Schema
+	if definition, ok := definitionFromSchema(jsonSchema); ok && schema.Strict {
+		strict, err := definition.OpenAIStrict()
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert response schema: %w", err)
+		}
+		jsonSchema = strict
+	}
+	rawSchema, err := json.Marshal(jsonSchema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal response schema: %w", err)
+	}
+	return &ResponseFormat{
+		Type: "json_schema",
+		JSONSchema: &ResponseFormatJSONSchema{
+			Name:        schema.Name,
+			Description: schema.Description,
+			Strict:      schema.Strict,
+			RawSchema:   rawSchema,
+		},
+	}, nil
+}
+
 // CreateEmbedding creates embeddings for the given input texts.
 func (o *LLM) CreateEmbedding(ctx context.Context, inputTexts []string) ([][]float32, error) {
 	embeddings, err := o.client.CreateEmbedding(ctx, &openaiclient.EmbeddingRequest{
@@ -460,16 +562,26 @@ func ExtractToolParts(msg *ChatMessage) ([]llms.ContentPart, []llms.ToolCall) {
 }
 
 // toolFromTool converts an llms.Tool to a Tool.
+// Strict parameters given as a jsonschema.Definition are converted to the
+// subset supported by the strict mode.
 func toolFromTool(t llms.Tool) (openaiclient.Tool, error) {
 	tool := openaiclient.Tool{
 		Type: openaiclient.ToolType(t.Type),
 	}
 	switch t.Type {
 	case string(openaiclient.ToolTypeFunction):
+		parameters := t.Function.Parameters
+		if definition, ok := definitionFromSchema(parameters); ok && t.Function.Strict {
+			strict, err := definition.OpenAIStrict()
+			if err != nil {
+				return openaiclient.Tool{}, fmt.Errorf("failed to convert parameters of tool %s: %w", t.Function.Name, err)
+			}
+			parameters = strict
+		}
 		tool.Function = openaiclient.FunctionDefinition{
 			Name:        t.Function.Name,
 			Description: t.Function.Description,
-			Parameters:  t.Function.Parameters,
+			Parameters:  parameters,
 			Strict:      t.Function.Strict,
 		}
 	default:
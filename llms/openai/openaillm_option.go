@@ -1,6 +1,8 @@
 package openai
 
 import (
+	"net/url"
+
 	"github.com/devmiahub/langchaingo/callbacks"
 	"github.com/devmiahub/langchaingo/llms/openai/internal/openaiclient"
 )
@@ -41,6 +43,10 @@ type options struct {
 	embeddingDimensions int
 
 	callbackHandler callbacks.Handler
+
+	// structuredOutput overrides whether the model supports JSON schema
+	// response formats when not nil.
+	structuredOutput *bool
 }
 
 // Option is a functional option for the OpenAI client.
@@ -101,6 +107,28 @@ func WithBaseURL(baseURL string) Option {
 	}
 }
 
+// WithStructuredOutput sets whether the model supports JSON schema response
+// formats, which llms.GenerateStructured then uses. If not set, only the OpenAI
+// models known to support them do, and models reached through a base URL other
+// than OpenAI's or Azure do not.
+func WithStructuredOutput(supported bool) Option {
+	return func(opts *options) {
+		opts.structuredOutput = &supported
+	}
+}
+
+// isOpenAIEndpoint reports whether the client calls the OpenAI API itself.
+func (opts *options) isOpenAIEndpoint() bool {
+	if opts.apiType != APITypeOpenAI {
+		return false
+	}
+	if opts.baseURL == "" {
+		return true
+	}
+	u, err := url.Parse(opts.baseURL)
+	return err == nil && u.Host == "api.openai.com"
+}
+
 // WithOrganization passes the OpenAI organization to the client. If not set, the
 // organization is read from the OPENAI_ORGANIZATION.
 func WithOrganization(organization string) Option {
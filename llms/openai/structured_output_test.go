@@ -8,6 +8,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/devmiahub/langchaingo/jsonschema"
 	"github.com/devmiahub/langchaingo/llms"
 	"github.com/devmiahub/langchaingo/llms/openai/internal/openaiclient"
 )
@@ -162,3 +163,135 @@ func TestStructuredOutputFunctionCalling(t *testing.T) {
 	assert.Regexp(t, "\"search_engine\":", c1.ToolCalls[0].FunctionCall.Arguments)
 	assert.Regexp(t, "\"search_query\":", c1.ToolCalls[0].FunctionCall.Arguments)
 }
+
+func TestResponseFormatFromSchema(t *testing.T) {
+	t.Parallel()
+	responseFormat, err := responseFormatFromSchema(&llms.ResponseSchema{
+		Name:        "answer",
+		Description: "The final answer.",
+		Schema: map[string]any{
+			"type":       "object",
+			"properties": map[string]any{"final_answer": map[string]any{"type": "string"}},
+		},
+		Strict: true,
+	})
+	require.NoError(t, err)
+
+	data, err := json.Marshal(responseFormat)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"type": "json_schema",
+		"json_schema": {
+			"name": "answer",
+			"description": "The final answer.",
+			"strict": true,
+			"schema": {"type": "object", "properties": {"final_answer": {"type": "string"}}}
+		}
+	}`, string(data))
+}
+
+func TestResponseFormatFromStrictDefinition(t *testing.T) {
+	t.Parallel()
+	definition, err := jsonschema.For[struct {
+		Answer string `json:"answer"`
+		Notes  string `json:"notes,omitempty"`
+	}]()
+	require.NoError(t, err)
+
+	responseFormat, err := responseFormatFromSchema(&llms.ResponseSchema{
+		Name:   "answer",
+		Schema: definition,
+		Strict: true,
+	})
+	require.NoError(t, err)
+
+	var schema map[string]any
+	require.NoError(t, json.Unmarshal(responseFormat.JSONSchema.RawSchema, &schema))
+	require.Equal(t, false, schema["additionalProperties"])
+	require.Equal(t, []any{"answer", "notes"}, schema["required"])
+
+	_, err = responseFormatFromSchema(&llms.ResponseSchema{
+		Name:   "labels",
+		Schema: &jsonschema.Definition{Type: jsonschema.Object, AdditionalProperties: true},
+		Strict: true,
+	})
+	require.ErrorIs(t, err, jsonschema.ErrIncompatibleSchema)
+}
+
+func TestToolFromStrictTool(t *testing.T) {
+	t.Parallel()
+	definition, err := jsonschema.For[struct {
+		Query string `json:"query"`
+		Limit int    `json:"limit,omitempty"`
+	}]()
+	require.NoError(t, err)
+
+	tool, err := toolFromTool(llms.Tool{
+		Type:     "function",
+		Function: &llms.FunctionDefinition{Name: "search", Parameters: definition, Strict: true},
+	})
+	require.NoError(t, err)
+	require.True(t, tool.Function.Strict)
+
+	data, err := json.Marshal(tool.Function.Parameters)
+	require.NoError(t, err)
+	var parameters map[string]any
+	require.NoError(t, json.Unmarshal(data, &parameters))
+	require.Equal(t, false, parameters["additionalProperties"])
+	require.Equal(t, []any{"query", "limit"}, parameters["required"])
+
+	// Parameters are left alone without strict mode.
+	tool, err = toolFromTool(llms.Tool{
+		Type:     "function",
+		Function: &llms.FunctionDefinition{Name: "search", Parameters: definition},
+	})
+	require.NoError(t, err)
+	require.Equal(t, definition, tool.Function.Parameters)
+
+	_, err = toolFromTool(llms.Tool{
+		Type: "function",
+		Function: &llms.FunctionDefinition{
+			Name:       "labels",
+			Parameters: &jsonschema.Definition{Type: jsonschema.Object, AdditionalProperties: true},
+			Strict:     true,
+		},
+	})
+	require.ErrorIs(t, err, jsonschema.ErrIncompatibleSchema)
+}
+
+func TestSupportsStructuredOutput(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		model   string
+		options []Option
+		want    bool
+	}{
+		{"gpt-4o", "gpt-4o", nil, true},
+		{"gpt-4o-mini", "gpt-4o-mini", nil, true},
+		{"first gpt-4o snapshot", "gpt-4o-2024-05-13", nil, false},
+		{"gpt-4.1", "gpt-4.1-mini", nil, true},
+		{"o1", "o1", nil, true},
+		{"o1-mini", "o1-mini", nil, false},
+		{"o3-mini", "o3-mini", nil, true},
+		{"gpt-4", "gpt-4-turbo", nil, false},
+		{"gpt-3.5", "gpt-3.5-turbo", nil, false},
+		{"unknown", "my-model", nil, false},
+		{"openai base url", "gpt-4o", []Option{WithBaseURL("https://api.openai.com/v1/")}, true},
+		{"custom base url", "gpt-4o", []Option{WithBaseURL("http://localhost:11434/v1")}, false},
+		{"custom base url opt-in", "llama3", []Option{
+			WithBaseURL("http://localhost:11434/v1"), WithStructuredOutput(true),
+		}, true},
+		{"opt-out", "gpt-4o", []Option{WithStructuredOutput(false)}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			options := append([]Option{WithToken("token"), WithBaseURL(""), WithModel(tt.model)}, tt.options...)
+			llm, err := New(options...)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, llm.SupportsStructuredOutput())
+		})
+	}
+}
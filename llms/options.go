@@ -69,6 +69,10 @@ type CallOptions struct {
 	// Supported MIME types are: text/plain: (default) Text output.
 	// application/json: JSON response in the response candidates.
 	ResponseMIMEType string `json:"response_mime_type,omitempty"`
+
+	// ResponseSchema is the JSON schema the response must match.
+	// Provider support varies, see StructuredOutputModel.
+	ResponseSchema *ResponseSchema `json:"response_schema,omitempty"`
 }
 
 // Tool is a tool that can be used by the model.
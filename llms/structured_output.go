@@ -0,0 +1,358 @@
+package llms
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/devmiahub/langchaingo/internal/codeblock"
+	"github.com/devmiahub/langchaingo/jsonschema"
+)
+
+// ErrInvalidStructuredOutput is returned when the response of the model does
+// not match the schema of the structured output.
+var ErrInvalidStructuredOutput = errors.New("invalid structured output")
+
+//nolint:lll
+const _structuredOutputInstructions = `Respond only with a JSON value that matches the following JSON schema, without any other text:
+` + "```json\n%s\n```"
+
+// _wrappedResultKey is the property holding the value of a schema that is not
+// an object, for the providers requiring an object.
+const _wrappedResultKey = "result"
+
+// ResponseSchema is a JSON schema the response of a model must match. It is
+// honored by the models implementing StructuredOutputModel.
+type ResponseSchema struct {
+	// Name is the name of the schema.
+	Name string `json:"name"`
+	// Description is the description of the schema.
+	Description string `json:"description,omitempty"`
+	// Schema is the JSON schema, such as a *jsonschema.Definition or a
+	// map[string]any.
+	Schema any `json:"schema"`
+	// Strict requests the response to adhere strictly to the schema. Provider
+	// support varies.
+	Strict bool `json:"strict,omitempty"`
+}
+
+// WithResponseSchema will add an option to constrain the response to a JSON
+// schema. Provider support varies, see StructuredOutputModel.
+func WithResponseSchema(schema *ResponseSchema) CallOption {
+	return func(o *CallOptions) {
+		o.ResponseSchema = schema
+	}
+}
+
+// StructuredOutputModel is an interface for models that can constrain their
+// response to the JSON schema given with WithResponseSchema.
+type StructuredOutputModel interface {
+	Model
+
+	// SupportsStructuredOutput returns true if the model honors WithResponseSchema.
+	SupportsStructuredOutput() bool
+}
+
+// ToolChoiceModel is an interface for models that can be forced to call a
+// specific tool with WithToolChoice.
+type ToolChoiceModel interface {
+	Model
+
+	// SupportsToolChoice returns true if the model honors a ToolChoice naming a
+	// function.
+	SupportsToolChoice() bool
+}
+
+// StructuredOutputMode is the way GenerateStructured gets a structured output
+// from a model.
+type StructuredOutputMode string
+
+const (
+	// StructuredOutputAuto uses the native structured output of the model if it
+	// supports it, then a forced tool call, and else instructions in the prompt.
+	StructuredOutputAuto StructuredOutputMode = "auto"
+	// StructuredOutputNative uses WithResponseSchema.
+	StructuredOutputNative StructuredOutputMode = "native"
+	// StructuredOutputToolCall forces the model to call a tool whose parameters
+	// are the schema.
+	StructuredOutputToolCall StructuredOutputMode = "tool_call"
+	// StructuredOutputPrompt adds the schema to the messages and parses the JSON
+	// of the response.
+	StructuredOutputPrompt StructuredOutputMode = "prompt"
+)
+
+// StructuredOptions is a set of options for GenerateStructured.
+type StructuredOptions struct {
+	// Mode is the way to get the structured output. Defaults to
+	// StructuredOutputAuto.
+	Mode StructuredOutputMode
+	// Name is the name of the schema, or of the tool. Defaults to the name of
+	// the type.
+	Name string
+	// Description is the description of the schema, or of the tool.
+	Description string
+	// Strict requests the response schema, or the parameters of the tool, to
+	// be adhered to strictly, as with the strict mode of OpenAI. Provider
+	// support varies.
+	Strict bool
+	// CallOptions are the options of the call to the model.
+	CallOptions []CallOption
+}
+
+// StructuredOption is a function that configures StructuredOptions.
+type StructuredOption func(*StructuredOptions)
+
+// WithStructuredOutputMode sets the way to get the structured output.
+func WithStructuredOutputMode(mode StructuredOutputMode) StructuredOption {
+	return func(o *StructuredOptions) {
+		o.Mode = mode
+	}
+}
+
+// WithSchemaName sets the name of the schema, or of the tool.
+func WithSchemaName(name string) StructuredOption {
+	return func(o *StructuredOptions) {
+		o.Name = name
+	}
+}
+
+// WithSchemaDescription sets the description of the schema, or of the tool.
+func WithSchemaDescription(description string) StructuredOption {
+	return func(o *StructuredOptions) {
+		o.Description = description
+	}
+}
+
+// WithStrictSchema requests the response schema, or the parameters of the
+// tool, to be adhered to strictly.
+func WithStrictSchema() StructuredOption {
+	return func(o *StructuredOptions) {
+		o.Strict = true
+	}
+}
+
+// WithStructuredCallOptions sets the options of the call to the model.
+func WithStructuredCallOptions(options ...CallOption) StructuredOption {
+	return func(o *StructuredOptions) {
+		o.CallOptions = append(o.CallOptions, options...)
+	}
+}
+
+// GenerateStructured asks the model to respond with a value of type T. A JSON
+// schema is derived from T with jsonschema.For, and the response is validated against it before being
+// decoded into T.
+//
+// Example:
+//
+//	type Recipe struct {
+//		Name        string   `json:"name" description:"name of the dish"`
+//		Ingredients []string `json:"ingredients"`
+//		Difficulty  string   `json:"difficulty" enum:"easy,medium,hard"`
+//	}
+//
+//	recipe, err := llms.GenerateStructured[Recipe](ctx, model, []llms.MessageContent{
+//		llms.TextParts(llms.ChatMessageTypeHuman, "Give me a pancake recipe."),
+//	})
+func GenerateStructured[T any](
+	ctx context.Context, model Model, messages []MessageContent, options ...StructuredOption,
+) (T, error) {
+	var result T
+	opts := StructuredOptions{Mode: StructuredOutputAuto}
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	typ := reflect.TypeFor[T]()
+	schema, err := jsonschema.ForType(typ)
+	if err != nil {
+		return result, err
+	}
+	if opts.Name == "" {
+		opts.Name = schemaName(typ)
+	}
+
+	var data []byte
+	switch mode := structuredOutputMode(model, opts.Mode); mode {
+	case StructuredOutputNative:
+		data, err = generateNative(ctx, model, messages, schema, opts)
+	case StructuredOutputToolCall:
+		data, err = generateToolCall(ctx, model, messages, schema, opts)
+	case StructuredOutputPrompt:
+		data, err = generatePrompted(ctx, model, messages, schema, opts)
+	default:
+		return result, fmt.Errorf("unknown structured output mode %q", mode)
+	}
+	if err != nil {
+		return result, err
+	}
+
+	if err := decodeStructured(data, schema, &result); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func structuredOutputMode(model Model, mode StructuredOutputMode) StructuredOutputMode {
+	if mode != StructuredOutputAuto && mode != "" {
+		return mode
+	}
+	if m, ok := model.(StructuredOutputModel); ok && m.SupportsStructuredOutput() {
+		return StructuredOutputNative
+	}
+	if m, ok := model.(ToolChoiceModel); ok && m.SupportsToolChoice() {
+		return StructuredOutputToolCall
+	}
+	return StructuredOutputPrompt
+}
+
+// objectSchema returns the schema as an object, as required by the providers
+// for response schemas and tool parameters, and reports whether the schema
+// was wrapped in the result property of an object.
+func objectSchema(schema *jsonschema.Definition) (*jsonschema.Definition, bool) {
+	if schema.Type == jsonschema.Object {
+		return schema, false
+	}
+	// The definitions stay at the root, where the references point.
+	result := *schema
+	result.Defs = nil
+	return &jsonschema.Definition{
+		Type:       jsonschema.Object,
+		Properties: map[string]jsonschema.Definition{_wrappedResultKey: result},
+		Required:   []string{_wrappedResultKey},
+		Defs:       schema.Defs,
+	}, true
+}
+
+func generateNative(
+	ctx context.Context, model Model, messages []MessageContent, schema *jsonschema.Definition, opts StructuredOptions,
+) ([]byte, error) {
+	object, wrapped := objectSchema(schema)
+	callOptions := append(opts.CallOptions, WithResponseSchema(&ResponseSchema{ //nolint:gocritic
+		Name:        opts.Name,
+		Description: opts.Description,
+		Schema:      object,
+		Strict:      opts.Strict,
+	}))
+	resp, err := model.GenerateContent(ctx, messages, callOptions...)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Choices) == 0 {
+		return nil, fmt.Errorf("%w: empty response from model", ErrInvalidStructuredOutput)
+	}
+	return unwrapResult([]byte(extractJSON(resp.Choices[0].Content)), wrapped)
+}
+
+func generateToolCall(
+	ctx context.Context, model Model, messages []MessageContent, schema *jsonschema.Definition, opts StructuredOptions,
+) ([]byte, error) {
+	object, wrapped := objectSchema(schema)
+	callOptions := append(opts.CallOptions, //nolint:gocritic
+		WithTools([]Tool{{
+			Type: "function",
+			Function: &FunctionDefinition{
+				Name:        opts.Name,
+				Description: opts.Description,
+				Parameters:  object,
+				Strict:      opts.Strict,
+			},
+		}}),
+		WithToolChoice(ToolChoice{Type: "function", Function: &FunctionReference{Name: opts.Name}}),
+	)
+	resp, err := model.GenerateContent(ctx, messages, callOptions...)
+	if err != nil {
+		return nil, err
+	}
+	for _, choice := range resp.Choices {
+		for _, toolCall := range choice.ToolCalls {
+			if toolCall.FunctionCall != nil && toolCall.FunctionCall.Name == opts.Name {
+				return unwrapResult([]byte(toolCall.FunctionCall.Arguments), wrapped)
+			}
+		}
+	}
+	return nil, fmt.Errorf("%w: model did not call tool %q", ErrInvalidStructuredOutput, opts.Name)
+}
+
+func generatePrompted(
+	ctx context.Context, model Model, messages []MessageContent, schema *jsonschema.Definition, opts StructuredOptions,
+) ([]byte, error) {
+	schemaJSON, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	instructions := fmt.Sprintf(_structuredOutputInstructions, schemaJSON)
+	if opts.Description != "" {
+		instructions = opts.Description + "\n\n" + instructions
+	}
+
+	prompted := append(append(make([]MessageContent, 0, len(messages)+1), messages...),
+		TextParts(ChatMessageTypeHuman, instructions))
+	resp, err := model.GenerateContent(ctx, prompted, opts.CallOptions...)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Choices) == 0 {
+		return nil, fmt.Errorf("%w: empty response from model", ErrInvalidStructuredOutput)
+	}
+	return []byte(extractJSON(resp.Choices[0].Content)), nil
+}
+
+func unwrapResult(data []byte, wrapped bool) ([]byte, error) {
+	if !wrapped {
+		return data, nil
+	}
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidStructuredOutput, err)
+	}
+	result, ok := object[_wrappedResultKey]
+	if !ok {
+		return nil, fmt.Errorf("%w: missing %q property", ErrInvalidStructuredOutput, _wrappedResultKey)
+	}
+	return result, nil
+}
+
+// decodeStructured validates the JSON against the schema and decodes it into
+// result. The null optional properties, which providers send in strict mode,
+// are not validated, and are decoded as zero values.
+func decodeStructured(data []byte, schema *jsonschema.Definition, result any) error {
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidStructuredOutput, err)
+	}
+	if err := schema.Validate(schema.RemoveOptionalNulls(value)); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidStructuredOutput, err)
+	}
+	if err := json.Unmarshal(data, result); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidStructuredOutput, err)
+	}
+	return nil
+}
+
+// extractJSON returns the JSON of a response, which may be in a fenced code
+// block or surrounded by text.
+func extractJSON(text string) string {
+	if data, ok := codeblock.JSON(text); ok {
+		return data
+	}
+	return strings.TrimSpace(text)
+}
+
+var _invalidSchemaNameChars = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// schemaName returns the name of the schema of the type, which providers
+// restrict to letters, digits, underscores and dashes.
+func schemaName(typ reflect.Type) string {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	name := strings.Trim(_invalidSchemaNameChars.ReplaceAllString(typ.Name(), "_"), "_")
+	if name == "" {
+		return "response"
+	}
+	return name
+}
@@ -0,0 +1,230 @@
+package llms_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/devmiahub/langchaingo/jsonschema"
+	"github.com/devmiahub/langchaingo/llms"
+	"github.com/stretchr/testify/require"
+)
+
+type recipe struct {
+	Name        string   `json:"name" description:"name of the dish"`
+	Ingredients []string `json:"ingredients"`
+	Difficulty  string   `json:"difficulty" enum:"easy,medium,hard"`
+	Notes       string   `json:"notes,omitempty"`
+}
+
+// structuredModel records the options it is given and answers with a fixed
+// response.
+type structuredModel struct {
+	native     bool
+	toolChoice bool
+	response   *llms.ContentResponse
+	options    llms.CallOptions
+}
+
+func (m *structuredModel) GenerateContent(
+	_ context.Context, _ []llms.MessageContent, options ...llms.CallOption,
+) (*llms.ContentResponse, error) {
+	for _, opt := range options {
+		opt(&m.options)
+	}
+	return m.response, nil
+}
+
+func (m *structuredModel) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, m, prompt, options...)
+}
+
+func (m *structuredModel) SupportsStructuredOutput() bool { return m.native }
+
+func (m *structuredModel) SupportsToolChoice() bool { return m.toolChoice }
+
+func textResponse(content string) *llms.ContentResponse {
+	return &llms.ContentResponse{Choices: []*llms.ContentChoice{{Content: content}}}
+}
+
+var structuredMessages = []llms.MessageContent{ //nolint:gochecknoglobals
+	llms.TextParts(llms.ChatMessageTypeHuman, "Give me a pancake recipe."),
+}
+
+func TestGenerateStructuredNative(t *testing.T) {
+	t.Parallel()
+	model := &structuredModel{
+		native:     true,
+		toolChoice: true,
+		response:   textResponse(`{"name":"Pancakes","ingredients":["flour","milk"],"difficulty":"easy"}`),
+	}
+
+	got, err := llms.GenerateStructured[recipe](context.Background(), model, structuredMessages)
+	require.NoError(t, err)
+	require.Equal(t, recipe{Name: "Pancakes", Ingredients: []string{"flour", "milk"}, Difficulty: "easy"}, got)
+
+	require.NotNil(t, model.options.ResponseSchema)
+	require.Equal(t, "recipe", model.options.ResponseSchema.Name)
+	schema, ok := model.options.ResponseSchema.Schema.(*jsonschema.Definition)
+	require.True(t, ok)
+	require.Equal(t, []string{"name", "ingredients", "difficulty"}, schema.Required)
+	require.Equal(t, "name of the dish", schema.Properties["name"].Description)
+	require.Equal(t, []string{"easy", "medium", "hard"}, schema.Properties["difficulty"].Enum)
+	require.Empty(t, model.options.Tools)
+}
+
+func TestGenerateStructuredOptionalNull(t *testing.T) {
+	t.Parallel()
+	// Strict mode makes the optional properties nullable.
+	model := &structuredModel{
+		native:   true,
+		response: textResponse(`{"name":"Pancakes","ingredients":[],"difficulty":"easy","notes":null}`),
+	}
+
+	got, err := llms.GenerateStructured[recipe](context.Background(), model, structuredMessages)
+	require.NoError(t, err)
+	require.Equal(t, recipe{Name: "Pancakes", Ingredients: []string{}, Difficulty: "easy"}, got)
+
+	model.response = textResponse(`{"name":null,"ingredients":[],"difficulty":"easy"}`)
+	_, err = llms.GenerateStructured[recipe](context.Background(), model, structuredMessages)
+	require.ErrorIs(t, err, llms.ErrInvalidStructuredOutput)
+}
+
+func TestGenerateStructuredStrict(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	model := &structuredModel{
+		native:   true,
+		response: textResponse(`{"name":"Pancakes","ingredients":[],"difficulty":"easy"}`),
+	}
+	_, err := llms.GenerateStructured[recipe](ctx, model, structuredMessages)
+	require.NoError(t, err)
+	require.False(t, model.options.ResponseSchema.Strict)
+
+	model = &structuredModel{native: true, response: model.response}
+	_, err = llms.GenerateStructured[recipe](ctx, model, structuredMessages, llms.WithStrictSchema())
+	require.NoError(t, err)
+	require.True(t, model.options.ResponseSchema.Strict)
+
+	model = &structuredModel{toolChoice: true, response: &llms.ContentResponse{Choices: []*llms.ContentChoice{{
+		ToolCalls: []llms.ToolCall{{
+			Type: "function",
+			FunctionCall: &llms.FunctionCall{
+				Name:      "recipe",
+				Arguments: `{"name":"Pancakes","ingredients":[],"difficulty":"easy","notes":null}`,
+			},
+		}},
+	}}}}
+	got, err := llms.GenerateStructured[recipe](ctx, model, structuredMessages, llms.WithStrictSchema())
+	require.NoError(t, err)
+	require.Equal(t, "Pancakes", got.Name)
+	require.True(t, model.options.Tools[0].Function.Strict)
+}
+
+func TestGenerateStructuredToolCall(t *testing.T) {
+	t.Parallel()
+	model := &structuredModel{
+		toolChoice: true,
+		response: &llms.ContentResponse{Choices: []*llms.ContentChoice{{
+			ToolCalls: []llms.ToolCall{{
+				ID:   "call_1",
+				Type: "function",
+				FunctionCall: &llms.FunctionCall{
+					Name:      "recipe",
+					Arguments: `{"name":"Pancakes","ingredients":[],"difficulty":"medium"}`,
+				},
+			}},
+		}}},
+	}
+
+	got, err := llms.GenerateStructured[recipe](context.Background(), model, structuredMessages,
+		llms.WithSchemaDescription("A recipe."))
+	require.NoError(t, err)
+	require.Equal(t, "medium", got.Difficulty)
+
+	require.Nil(t, model.options.ResponseSchema)
+	require.Len(t, model.options.Tools, 1)
+	require.Equal(t, "A recipe.", model.options.Tools[0].Function.Description)
+	require.Equal(t, llms.ToolChoice{Type: "function", Function: &llms.FunctionReference{Name: "recipe"}},
+		model.options.ToolChoice)
+}
+
+func TestGenerateStructuredPrompt(t *testing.T) {
+	t.Parallel()
+	model := &recordingModel{response: "Here it is:\n```json\n" +
+		`{"name":"Pancakes","ingredients":["eggs"],"difficulty":"hard"}` + "\n```"}
+
+	got, err := llms.GenerateStructured[recipe](context.Background(), model, structuredMessages)
+	require.NoError(t, err)
+	require.Equal(t, []string{"eggs"}, got.Ingredients)
+
+	require.Len(t, model.messages, 1)
+	require.Len(t, model.messages[0], 2)
+	instructions := model.messages[0][1].Parts[0].(llms.TextContent).Text //nolint:forcetypeassert
+	require.Contains(t, instructions, "JSON schema")
+	require.Contains(t, instructions, `"difficulty"`)
+}
+
+func TestGenerateStructuredNonObject(t *testing.T) {
+	t.Parallel()
+	model := &structuredModel{native: true, response: textResponse(`{"result":["a","b"]}`)}
+
+	got, err := llms.GenerateStructured[[]string](context.Background(), model, structuredMessages)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b"}, got)
+
+	schema, ok := model.options.ResponseSchema.Schema.(*jsonschema.Definition)
+	require.True(t, ok)
+	require.Equal(t, jsonschema.Object, schema.Type)
+	require.Equal(t, jsonschema.Array, schema.Properties["result"].Type)
+	require.Equal(t, "response", model.options.ResponseSchema.Name)
+
+	prompted, err := llms.GenerateStructured[int](context.Background(), &recordingModel{response: "42"},
+		structuredMessages)
+	require.NoError(t, err)
+	require.Equal(t, 42, prompted)
+}
+
+func TestGenerateStructuredInvalid(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		response string
+	}{
+		{"missing required", `{"name":"Pancakes","difficulty":"easy"}`},
+		{"not in enum", `{"name":"Pancakes","ingredients":[],"difficulty":"impossible"}`},
+		{"not json", `I cannot help with that.`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			model := &structuredModel{native: true, response: textResponse(tt.response)}
+			_, err := llms.GenerateStructured[recipe](context.Background(), model, structuredMessages)
+			require.ErrorIs(t, err, llms.ErrInvalidStructuredOutput)
+		})
+	}
+
+	_, err := llms.GenerateStructured[chan int](context.Background(), &recordingModel{}, structuredMessages)
+	require.ErrorIs(t, err, jsonschema.ErrUnsupportedType)
+}
+
+type category struct {
+	Name          string     `json:"name"`
+	Subcategories []category `json:"subcategories,omitempty"`
+}
+
+func TestGenerateStructuredRecursive(t *testing.T) {
+	t.Parallel()
+	model := &structuredModel{native: true, response: textResponse(
+		`{"result":[{"name":"food","subcategories":[{"name":"fruit"}]}]}`)}
+
+	got, err := llms.GenerateStructured[[]category](context.Background(), model, structuredMessages)
+	require.NoError(t, err)
+	require.Equal(t, []category{{Name: "food", Subcategories: []category{{Name: "fruit"}}}}, got)
+
+	// The definitions stay at the root of the wrapping object.
+	schema, ok := model.options.ResponseSchema.Schema.(*jsonschema.Definition)
+	require.True(t, ok)
+	require.Contains(t, schema.Defs, "category")
+	require.Equal(t, "#/$defs/category", schema.Properties["result"].Items.Ref)
+}
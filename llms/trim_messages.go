@@ -0,0 +1,383 @@
+package llms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// _messageTokenOverhead is the number of tokens a message takes besides
+	// its content, for its role and delimiters.
+	_messageTokenOverhead = 4
+	// _imageTokenEstimate is the number of tokens counted for an image.
+	_imageTokenEstimate = 85
+)
+
+var (
+	// ErrMessagesTooLarge is returned when the messages can not be trimmed to
+	// fit the context of the model, for example because the system messages
+	// alone do not fit.
+	ErrMessagesTooLarge = errors.New("messages do not fit the context of the model")
+	// ErrMissingSummarizer is returned when the TrimSummarizeMiddle strategy
+	// is used without a summarizer model.
+	ErrMissingSummarizer = errors.New("summarize middle strategy requires a summarizer model")
+)
+
+//nolint:lll
+const _summarizeMiddleTemplate = `Progressively summarize the lines of conversation provided, keeping the facts, decisions and open questions the rest of the conversation may rely on.
+
+Conversation:
+%s
+
+Summary:`
+
+// TrimStrategy is the strategy used to make the messages fit the context of
+// the model.
+type TrimStrategy int
+
+const (
+	// TrimDropOldest drops the oldest messages, except the system messages,
+	// until the messages fit.
+	TrimDropOldest TrimStrategy = iota
+	// TrimKeepLast keeps the system messages and the last KeepLast messages,
+	// dropping the oldest of those too if they still do not fit.
+	TrimKeepLast
+	// TrimSummarizeMiddle keeps the system messages and the most recent
+	// messages that fit, and replaces the messages in between with a summary
+	// generated by the Summarizer model. The summary is added as a system
+	// message after the other system messages.
+	TrimSummarizeMiddle
+)
+
+// TrimOptions is a set of options for TrimMessages.
+type TrimOptions struct {
+	// Model is the name of the model, used to get the context size and to
+	// count tokens.
+	Model string
+	// ContextSize is the context size of the model. Defaults to
+	// GetModelContextSize(Model).
+	ContextSize int
+	// MaxTokens is the number of tokens reserved for the response.
+	MaxTokens int
+	// Strategy is the trimming strategy.
+	Strategy TrimStrategy
+	// KeepLast is the number of most recent messages kept by TrimKeepLast.
+	KeepLast int
+	// Summarizer is the model generating the summary of TrimSummarizeMiddle.
+	Summarizer Model
+	// TokenCounter counts the tokens of a message. Defaults to counting the
+	// tokens of its parts with CountTokens.
+	TokenCounter func(MessageContent) int
+}
+
+// TrimOption is a function that configures TrimOptions.
+type TrimOption func(*TrimOptions)
+
+// WithTrimModel sets the name of the model used to get the context size and
+// to count tokens.
+func WithTrimModel(model string) TrimOption {
+	return func(o *TrimOptions) {
+		o.Model = model
+	}
+}
+
+// WithContextSize sets the context size of the model, instead of the one
+// returned by GetModelContextSize.
+func WithContextSize(contextSize int) TrimOption {
+	return func(o *TrimOptions) {
+		o.ContextSize = contextSize
+	}
+}
+
+// WithReservedTokens sets the number of tokens reserved for the response.
+func WithReservedTokens(maxTokens int) TrimOption {
+	return func(o *TrimOptions) {
+		o.MaxTokens = maxTokens
+	}
+}
+
+// WithTrimStrategy sets the trimming strategy.
+func WithTrimStrategy(strategy TrimStrategy) TrimOption {
+	return func(o *TrimOptions) {
+		o.Strategy = strategy
+	}
+}
+
+// WithKeepLast sets the TrimKeepLast strategy, keeping the system messages
+// and the last n messages.
+func WithKeepLast(n int) TrimOption {
+	return func(o *TrimOptions) {
+		o.Strategy = TrimKeepLast
+		o.KeepLast = n
+	}
+}
+
+// WithSummarizer sets the TrimSummarizeMiddle strategy, summarizing the
+// dropped messages with the model.
+func WithSummarizer(model Model) TrimOption {
+	return func(o *TrimOptions) {
+		o.Strategy = TrimSummarizeMiddle
+		o.Summarizer = model
+	}
+}
+
+// WithTokenCounter sets the function counting the tokens of a message.
+func WithTokenCounter(counter func(MessageContent) int) TrimOption {
+	return func(o *TrimOptions) {
+		o.TokenCounter = counter
+	}
+}
+
+// TrimMessages trims the messages to fit the context size of the model minus
+// the tokens reserved for the response, using the configured strategy. The
+// system messages at the start of the conversation and the most recent
+// message are always kept, and a tool call response is never kept without the
+// message holding its tool call. It returns ErrMessagesTooLarge if the
+// messages can not be trimmed enough.
+func TrimMessages(ctx context.Context, messages []MessageContent, options ...TrimOption) ([]MessageContent, error) {
+	opts := TrimOptions{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+	if opts.ContextSize == 0 {
+		opts.ContextSize = GetModelContextSize(opts.Model)
+	}
+	if opts.TokenCounter == nil {
+		opts.TokenCounter = func(mc MessageContent) int { return countMessageTokens(opts.Model, mc) }
+	}
+	if opts.Strategy == TrimSummarizeMiddle && opts.Summarizer == nil {
+		return nil, ErrMissingSummarizer
+	}
+	budget := opts.ContextSize - opts.MaxTokens
+
+	system, groups := groupMessages(messages)
+	if opts.Strategy == TrimKeepLast && len(groups) > 0 {
+		if opts.KeepLast < 1 {
+			return nil, fmt.Errorf("%w: keeping the last %d messages drops them all", ErrMessagesTooLarge, opts.KeepLast)
+		}
+		groups = lastGroups(groups, opts.KeepLast)
+	}
+
+	// Keep the most recent groups that fit, from groups[start] on.
+	used := countTokens(opts.TokenCounter, system)
+	start := 0
+	for i := len(groups) - 1; i >= 0; i-- {
+		tokens := countTokens(opts.TokenCounter, groups[i])
+		if used+tokens > budget {
+			start = i + 1
+			break
+		}
+		used += tokens
+	}
+
+	// The most recent message, such as the last turn of the user, is never
+	// dropped.
+	if len(groups) > 0 && start == len(groups) {
+		return nil, fmt.Errorf("%w: the last message does not fit a budget of %d tokens", ErrMessagesTooLarge, budget)
+	}
+
+	if opts.Strategy == TrimSummarizeMiddle && start > 0 {
+		for {
+			summary, err := summarizeMessages(ctx, opts.Summarizer, flatten(groups[:start]))
+			if err != nil {
+				return nil, err
+			}
+			tokens := opts.TokenCounter(summary)
+			if used+tokens <= budget {
+				system = append(system, summary)
+				used += tokens
+				break
+			}
+			if start == len(groups)-1 {
+				return nil, fmt.Errorf("%w: the summary and the last message do not fit a budget of %d tokens",
+					ErrMessagesTooLarge, budget)
+			}
+			// The summary pushes the oldest kept group out, which is
+			// summarized too.
+			used -= countTokens(opts.TokenCounter, groups[start])
+			start++
+		}
+	}
+
+	if used > budget {
+		return nil, fmt.Errorf("%w: %d tokens for a budget of %d", ErrMessagesTooLarge, used, budget)
+	}
+	return append(system, flatten(groups[start:])...), nil
+}
+
+// groupMessages splits the messages into the system messages at their start
+// and groups of messages to be kept or dropped together: a message and the
+// tool call responses following it.
+func groupMessages(messages []MessageContent) ([]MessageContent, [][]MessageContent) {
+	var system []MessageContent
+	for len(messages) > 0 && messages[0].Role == ChatMessageTypeSystem {
+		system = append(system, messages[0])
+		messages = messages[1:]
+	}
+
+	var groups [][]MessageContent
+	for _, message := range messages {
+		if len(groups) > 0 && isToolResponse(message) {
+			groups[len(groups)-1] = append(groups[len(groups)-1], message)
+			continue
+		}
+		groups = append(groups, []MessageContent{message})
+	}
+	return system, groups
+}
+
+// lastGroups returns the groups holding the last n messages. A group is kept
+// whole if any of its messages is among the last n.
+func lastGroups(groups [][]MessageContent, n int) [][]MessageContent {
+	count := 0
+	for i := len(groups) - 1; i >= 0; i-- {
+		if count >= n {
+			return groups[i+1:]
+		}
+		count += len(groups[i])
+	}
+	return groups
+}
+
+func isToolResponse(message MessageContent) bool {
+	if message.Role == ChatMessageTypeTool {
+		return true
+	}
+	for _, part := range message.Parts {
+		if cached, ok := part.(CachedContent); ok {
+			part = cached.ContentPart
+		}
+		if _, ok := part.(ToolCallResponse); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func flatten(groups [][]MessageContent) []MessageContent {
+	var messages []MessageContent
+	for _, group := range groups {
+		messages = append(messages, group...)
+	}
+	return messages
+}
+
+func countTokens(counter func(MessageContent) int, messages []MessageContent) int {
+	tokens := 0
+	for _, message := range messages {
+		tokens += counter(message)
+	}
+	return tokens
+}
+
+// countMessageTokens counts the tokens of the parts of the message with
+// CountTokens. Images are counted as a fixed number of tokens.
+func countMessageTokens(model string, message MessageContent) int {
+	tokens := _messageTokenOverhead
+	for _, part := range message.Parts {
+		if cached, ok := part.(CachedContent); ok {
+			part = cached.ContentPart
+		}
+		switch part := part.(type) {
+		case TextContent:
+			tokens += CountTokens(model, part.Text)
+		case ThinkingContent:
+			tokens += CountTokens(model, part.Thinking)
+		case ToolCall:
+			if part.FunctionCall != nil {
+				tokens += CountTokens(model, part.FunctionCall.Name+part.FunctionCall.Arguments)
+			}
+		case ToolCallResponse:
+			tokens += CountTokens(model, part.Content)
+		case ImageURLContent, BinaryContent:
+			tokens += _imageTokenEstimate
+		}
+	}
+	return tokens
+}
+
+func summarizeMessages(ctx context.Context, model Model, messages []MessageContent) (MessageContent, error) {
+	lines := make([]string, 0, len(messages))
+	for _, message := range messages {
+		lines = append(lines, messageLine(message))
+	}
+
+	summary, err := GenerateFromSinglePrompt(ctx, model, fmt.Sprintf(_summarizeMiddleTemplate, strings.Join(lines, "\n")))
+	if err != nil {
+		return MessageContent{}, err
+	}
+	return TextParts(ChatMessageTypeSystem, "Summary of the earlier conversation: "+strings.TrimSpace(summary)), nil
+}
+
+// messageLine renders the message as a line of conversation.
+func messageLine(message MessageContent) string {
+	var texts []string
+	for _, part := range message.Parts {
+		if cached, ok := part.(CachedContent); ok {
+			part = cached.ContentPart
+		}
+		switch part := part.(type) {
+		case TextContent:
+			texts = append(texts, part.Text)
+		case ToolCall:
+			if part.FunctionCall != nil {
+				texts = append(texts, fmt.Sprintf("[called %s with %s]", part.FunctionCall.Name, part.FunctionCall.Arguments))
+			}
+		case ToolCallResponse:
+			texts = append(texts, fmt.Sprintf("[%s returned %s]", part.Name, part.Content))
+		}
+	}
+	return fmt.Sprintf("%s: %s", message.Role, strings.Join(texts, " "))
+}
+
+// TrimmingModel is a model trimming the messages to fit the context of the
+// model before generating content.
+type TrimmingModel struct {
+	Model
+	Options []TrimOption
+}
+
+var _ Model = &TrimmingModel{}
+
+// NewTrimmingModel wraps the model so that the messages are trimmed with the
+// options before being sent. The Model and MaxTokens call options, when set,
+// override the model name and the tokens reserved for the response.
+func NewTrimmingModel(model Model, options ...TrimOption) *TrimmingModel {
+	return &TrimmingModel{
+		Model:   model,
+		Options: options,
+	}
+}
+
+// GenerateContent trims the messages and generates content with the wrapped
+// model.
+func (m *TrimmingModel) GenerateContent(
+	ctx context.Context, messages []MessageContent, options ...CallOption,
+) (*ContentResponse, error) {
+	callOpts := CallOptions{}
+	for _, opt := range options {
+		opt(&callOpts)
+	}
+
+	trimOpts := m.Options[:len(m.Options):len(m.Options)]
+	if callOpts.Model != "" {
+		trimOpts = append(trimOpts, WithTrimModel(callOpts.Model))
+	}
+	if callOpts.MaxTokens > 0 {
+		trimOpts = append(trimOpts, WithReservedTokens(callOpts.MaxTokens))
+	}
+
+	messages, err := TrimMessages(ctx, messages, trimOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return m.Model.GenerateContent(ctx, messages, options...)
+}
+
+// Call trims the single prompt message and generates content with the
+// wrapped model.
+func (m *TrimmingModel) Call(ctx context.Context, prompt string, options ...CallOption) (string, error) {
+	return GenerateFromSinglePrompt(ctx, m, prompt, options...)
+}
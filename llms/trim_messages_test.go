@@ -0,0 +1,208 @@
+package llms_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/devmiahub/langchaingo/llms"
+	"github.com/stretchr/testify/require"
+)
+
+// wordCounter counts a token per word of the text parts of a message.
+func wordCounter(mc llms.MessageContent) int {
+	tokens := 0
+	for _, part := range mc.Parts {
+		switch part := part.(type) {
+		case llms.TextContent:
+			tokens += len(strings.Fields(part.Text))
+		case llms.ToolCallResponse:
+			tokens += len(strings.Fields(part.Content))
+		case llms.ToolCall:
+			tokens++
+		}
+	}
+	return tokens
+}
+
+// recordingModel records the messages it is given and answers with a fixed
+// response.
+type recordingModel struct {
+	response string
+	messages [][]llms.MessageContent
+}
+
+func (m *recordingModel) GenerateContent(
+	_ context.Context, messages []llms.MessageContent, _ ...llms.CallOption,
+) (*llms.ContentResponse, error) {
+	m.messages = append(m.messages, messages)
+	return &llms.ContentResponse{Choices: []*llms.ContentChoice{{Content: m.response}}}, nil
+}
+
+func (m *recordingModel) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, m, prompt, options...)
+}
+
+func trimConversation() []llms.MessageContent {
+	return []llms.MessageContent{
+		llms.TextParts(llms.ChatMessageTypeSystem, "be brief"),
+		llms.TextParts(llms.ChatMessageTypeHuman, "my name is Ada"),
+		llms.TextParts(llms.ChatMessageTypeAI, "hello Ada"),
+		llms.TextParts(llms.ChatMessageTypeHuman, "weather in Paris"),
+		{
+			Role: llms.ChatMessageTypeAI,
+			Parts: []llms.ContentPart{llms.ToolCall{
+				ID: "call_1", Type: "function", FunctionCall: &llms.FunctionCall{Name: "weather", Arguments: `{}`},
+			}},
+		},
+		{
+			Role:  llms.ChatMessageTypeTool,
+			Parts: []llms.ContentPart{llms.ToolCallResponse{ToolCallID: "call_1", Name: "weather", Content: "sunny and warm"}},
+		},
+		llms.TextParts(llms.ChatMessageTypeAI, "it is sunny"),
+	}
+}
+
+func TestTrimMessages(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conversation := trimConversation()
+
+	tests := []struct {
+		name    string
+		options []llms.TrimOption
+		want    []llms.MessageContent
+	}{
+		{
+			name:    "fits",
+			options: []llms.TrimOption{llms.WithContextSize(100)},
+			want:    conversation,
+		},
+		{
+			name:    "drop oldest",
+			options: []llms.TrimOption{llms.WithContextSize(20), llms.WithReservedTokens(12)},
+			// The tool call response is not kept without its tool call.
+			want: []llms.MessageContent{conversation[0], conversation[6]},
+		},
+		{
+			name:    "drop oldest keeps tool call with response",
+			options: []llms.TrimOption{llms.WithContextSize(20), llms.WithReservedTokens(10)},
+			want:    []llms.MessageContent{conversation[0], conversation[4], conversation[5], conversation[6]},
+		},
+		{
+			name:    "keep last",
+			options: []llms.TrimOption{llms.WithContextSize(100), llms.WithKeepLast(2)},
+			want:    []llms.MessageContent{conversation[0], conversation[4], conversation[5], conversation[6]},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			options := append([]llms.TrimOption{llms.WithTokenCounter(wordCounter)}, tt.options...)
+			got, err := llms.TrimMessages(ctx, conversation, options...)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTrimMessagesSummarizeMiddle(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conversation := trimConversation()
+
+	summarizer := &recordingModel{response: "Ada."}
+	got, err := llms.TrimMessages(ctx, conversation,
+		llms.WithTokenCounter(wordCounter),
+		llms.WithContextSize(16),
+		llms.WithSummarizer(summarizer),
+	)
+	require.NoError(t, err)
+	require.Equal(t, []llms.MessageContent{
+		conversation[0],
+		llms.TextParts(llms.ChatMessageTypeSystem, "Summary of the earlier conversation: Ada."),
+		conversation[4], conversation[5], conversation[6],
+	}, got)
+
+	// The summary pushes out kept messages, which get summarized again.
+	require.Len(t, summarizer.messages, 3)
+	prompt := summarizer.messages[2][0].Parts[0].(llms.TextContent).Text
+	require.Contains(t, prompt, "human: my name is Ada\nai: hello Ada\nhuman: weather in Paris")
+	require.NotContains(t, prompt, "sunny")
+
+	_, err = llms.TrimMessages(ctx, conversation, llms.WithTrimStrategy(llms.TrimSummarizeMiddle))
+	require.ErrorIs(t, err, llms.ErrMissingSummarizer)
+}
+
+func TestTrimMessagesTooLarge(t *testing.T) {
+	t.Parallel()
+
+	_, err := llms.TrimMessages(context.Background(), trimConversation(),
+		llms.WithTokenCounter(wordCounter),
+		llms.WithContextSize(1),
+	)
+	require.ErrorIs(t, err, llms.ErrMessagesTooLarge)
+
+	// The last message is not dropped, even when the system messages fit.
+	textLength := func(mc llms.MessageContent) int {
+		return len(mc.Parts[0].(llms.TextContent).Text)
+	}
+	conversation := []llms.MessageContent{
+		llms.TextParts(llms.ChatMessageTypeSystem, "sys"),
+		llms.TextParts(llms.ChatMessageTypeHuman, "an earlier question"),
+		llms.TextParts(llms.ChatMessageTypeHuman, "the question of the user"),
+	}
+	for _, options := range [][]llms.TrimOption{
+		{llms.WithTokenCounter(textLength), llms.WithContextSize(20)},
+		{llms.WithTokenCounter(textLength), llms.WithContextSize(20), llms.WithSummarizer(&recordingModel{response: "x"})},
+		{llms.WithTokenCounter(textLength), llms.WithContextSize(100), llms.WithKeepLast(0)},
+	} {
+		got, err := llms.TrimMessages(context.Background(), conversation, options...)
+		require.ErrorIs(t, err, llms.ErrMessagesTooLarge)
+		require.Nil(t, got)
+	}
+
+	// The summary does not push out the last message.
+	_, err = llms.TrimMessages(context.Background(), conversation,
+		llms.WithTokenCounter(textLength),
+		llms.WithContextSize(30),
+		llms.WithSummarizer(&recordingModel{response: "a summary that is too long"}),
+	)
+	require.ErrorIs(t, err, llms.ErrMessagesTooLarge)
+}
+
+func TestTrimmingModel(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conversation := trimConversation()
+
+	model := &recordingModel{response: "ok"}
+	trimming := llms.NewTrimmingModel(model, llms.WithTokenCounter(wordCounter), llms.WithContextSize(20))
+
+	_, err := trimming.GenerateContent(ctx, conversation)
+	require.NoError(t, err)
+	require.Equal(t, conversation, model.messages[0])
+
+	_, err = trimming.GenerateContent(ctx, conversation, llms.WithMaxTokens(12))
+	require.NoError(t, err)
+	require.Equal(t, []llms.MessageContent{conversation[0], conversation[6]}, model.messages[1])
+}
+
+func TestTrimmingModelCallModel(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conversation := []llms.MessageContent{llms.TextParts(llms.ChatMessageTypeHuman, "a long question")}
+
+	model := &recordingModel{response: "ok"}
+	trimming := llms.NewTrimmingModel(model, llms.WithTokenCounter(func(llms.MessageContent) int { return 4000 }))
+
+	// The default context size is too small, that of the model of the call
+	// is not.
+	_, err := trimming.GenerateContent(ctx, conversation)
+	require.ErrorIs(t, err, llms.ErrMessagesTooLarge)
+
+	_, err = trimming.GenerateContent(ctx, conversation, llms.WithModel("gpt-4"))
+	require.NoError(t, err)
+	require.Equal(t, conversation, model.messages[0])
+}
@@ -19,7 +19,10 @@ type ChatMessageHistory struct {
 	schemaName string
 }
 
-var _ schema.ChatMessageHistory = &ChatMessageHistory{}
+var (
+	_ schema.ChatMessageHistory    = &ChatMessageHistory{}
+	_ schema.MessageContentHistory = &ChatMessageHistory{}
+)
 
 // NewChatMessageHistory creates a new NewChatMessageHistory with options.
 func NewChatMessageHistory(ctx context.Context,
@@ -113,36 +116,21 @@ func (c *ChatMessageHistory) validateTable(ctx context.Context) error {
 	return nil
 }
 
-// addMessage adds a new message into the ChatMessageHistory for a given
-// session.
-func (c *ChatMessageHistory) addMessage(ctx context.Context, content string, messageType llms.ChatMessageType) error {
-	data, err := json.Marshal(content)
-	if err != nil {
-		return fmt.Errorf("failed to serialize content to JSON: %w", err)
-	}
-	query := fmt.Sprintf(`INSERT INTO %q.%q (session_id, data, type) VALUES ($1, $2, $3)`,
-		c.schemaName, c.tableName)
-
-	_, err = c.engine.Pool.Exec(ctx, query, c.sessionID, data, messageType)
-	if err != nil {
-		return fmt.Errorf("failed to add message to database: %w", err)
-	}
-	return nil
-}
-
-// AddMessage adds a message to the ChatMessageHistory.
+// AddMessage adds a message with its full content, including the tool calls
+// and thinking of AI messages and the tool call IDs of tool messages, to the
+// ChatMessageHistory.
 func (c *ChatMessageHistory) AddMessage(ctx context.Context, message llms.ChatMessage) error {
-	return c.addMessage(ctx, message.GetContent(), message.GetType())
+	return c.AddMessageContent(ctx, llms.MessageContentFromChatMessage(message))
 }
 
 // AddAIMessage adds an AI-generated message to the ChatMessageHistory.
 func (c *ChatMessageHistory) AddAIMessage(ctx context.Context, content string) error {
-	return c.addMessage(ctx, content, llms.ChatMessageTypeAI)
+	return c.AddMessage(ctx, llms.AIChatMessage{Content: content})
 }
 
 // AddUserMessage adds a user-generated message to the ChatMessageHistory.
 func (c *ChatMessageHistory) AddUserMessage(ctx context.Context, content string) error {
-	return c.addMessage(ctx, content, llms.ChatMessageTypeHuman)
+	return c.AddMessage(ctx, llms.HumanChatMessage{Content: content})
 }
 
 // Clear removes all messages associated with a session from the
@@ -158,21 +146,10 @@ func (c *ChatMessageHistory) Clear(ctx context.Context) error {
 	return err
 }
 
-// AddMessages adds multiple messages to the ChatMessageHistory for a given
-// session.
+// AddMessages adds multiple messages with their full content to the
+// ChatMessageHistory for a given session.
 func (c *ChatMessageHistory) AddMessages(ctx context.Context, messages []llms.ChatMessage) error {
-	b := &pgx.Batch{}
-	query := fmt.Sprintf(`INSERT INTO %q.%q (session_id, data, type) VALUES ($1, $2, $3)`,
-		c.schemaName, c.tableName)
-
-	for _, message := range messages {
-		data, err := json.Marshal(message.GetContent())
-		if err != nil {
-			return fmt.Errorf("failed to serialize content to JSON: %w", err)
-		}
-		b.Queue(query, c.sessionID, data, message.GetType())
-	}
-	return c.engine.Pool.SendBatch(ctx, b).Close()
+	return c.addMessageContents(ctx, messageContents(messages))
 }
 
 // Messages retrieves all messages associated with a session from the
@@ -198,24 +175,11 @@ func (c *ChatMessageHistory) Messages(ctx context.Context) ([]llms.ChatMessage,
 			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
 
-		// Variable to hold the deserialized content
-		var content string
-
-		// Unmarshal the JSON data into the content variable
-		err := json.Unmarshal([]byte(data), &content)
+		message, err := decodeChatMessage(data, messageType)
 		if err != nil {
-			return nil, fmt.Errorf("failed to unmarshal data: %w", err)
-		}
-		switch messageType {
-		case string(llms.ChatMessageTypeAI):
-			messages = append(messages, llms.AIChatMessage{Content: content})
-		case string(llms.ChatMessageTypeHuman):
-			messages = append(messages, llms.HumanChatMessage{Content: content})
-		case string(llms.ChatMessageTypeSystem):
-			messages = append(messages, llms.SystemChatMessage{Content: content})
-		default:
-			return nil, fmt.Errorf("unsupported message type: %s", messageType)
+			return nil, err
 		}
+		messages = append(messages, message)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -226,23 +190,131 @@ func (c *ChatMessageHistory) Messages(ctx context.Context) ([]llms.ChatMessage,
 }
 
 // SetMessages clears the current messages from the ChatMessageHistory for a
-// given session and then adds new messages to it.
+// given session and then adds new messages with their full content to it.
 func (c *ChatMessageHistory) SetMessages(ctx context.Context, messages []llms.ChatMessage) error {
+	return c.SetMessageContents(ctx, messageContents(messages))
+}
+
+// AddMessageContent adds a message with its full content, including tool
+// calls, images and thinking content, to the ChatMessageHistory.
+func (c *ChatMessageHistory) AddMessageContent(ctx context.Context, message llms.MessageContent) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to serialize message to JSON: %w", err)
+	}
+	query := fmt.Sprintf(`INSERT INTO %q.%q (session_id, data, type) VALUES ($1, $2, $3)`,
+		c.schemaName, c.tableName)
+
+	_, err = c.engine.Pool.Exec(ctx, query, c.sessionID, data, message.Role)
+	if err != nil {
+		return fmt.Errorf("failed to add message to database: %w", err)
+	}
+	return nil
+}
+
+// MessageContents retrieves all messages associated with a session from the
+// ChatMessageHistory with their full content. Messages added as chat
+// messages are converted to message contents.
+func (c *ChatMessageHistory) MessageContents(ctx context.Context) ([]llms.MessageContent, error) {
+	query := fmt.Sprintf(
+		`SELECT data, type FROM %q.%q WHERE session_id = $1 ORDER BY id`,
+		c.schemaName, c.tableName,
+	)
+
+	rows, err := c.engine.Pool.Query(ctx, query, c.sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve messages: %w", err)
+	}
+	defer rows.Close()
+
+	var messages []llms.MessageContent
+	for rows.Next() {
+		var data, messageType string
+		if err := rows.Scan(&data, &messageType); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		message, _, err := decodeMessageContent(data, messageType)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, message)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate over rows: %w", err)
+	}
+
+	return messages, nil
+}
+
+// SetMessageContents clears the current messages from the ChatMessageHistory
+// for a given session and then adds new messages with their full content to
+// it.
+func (c *ChatMessageHistory) SetMessageContents(ctx context.Context, messages []llms.MessageContent) error {
 	err := c.Clear(ctx)
 	if err != nil {
 		return err
 	}
+	return c.addMessageContents(ctx, messages)
+}
 
+// addMessageContents adds messages with their full content to the
+// ChatMessageHistory in a single batch.
+func (c *ChatMessageHistory) addMessageContents(ctx context.Context, messages []llms.MessageContent) error {
 	b := &pgx.Batch{}
 	query := fmt.Sprintf(`INSERT INTO %q.%q (session_id, data, type) VALUES ($1, $2, $3)`,
 		c.schemaName, c.tableName)
 
 	for _, message := range messages {
-		data, err := json.Marshal(message.GetContent())
+		data, err := json.Marshal(message)
 		if err != nil {
-			return fmt.Errorf("failed to serialize content to JSON: %w", err)
+			return fmt.Errorf("failed to serialize message to JSON: %w", err)
 		}
-		b.Queue(query, c.sessionID, data, message.GetType())
+		b.Queue(query, c.sessionID, data, message.Role)
 	}
 	return c.engine.Pool.SendBatch(ctx, b).Close()
 }
+
+// messageContents converts chat messages to message contents.
+func messageContents(messages []llms.ChatMessage) []llms.MessageContent {
+	contents := make([]llms.MessageContent, 0, len(messages))
+	for _, message := range messages {
+		contents = append(contents, llms.MessageContentFromChatMessage(message))
+	}
+	return contents
+}
+
+// decodeMessageContent decodes the data column of a row. Rows added with
+// AddMessageContent hold a message content object, rows added as chat
+// messages hold the text of the message as a JSON string. It reports whether
+// the row holds a plain text message.
+func decodeMessageContent(data, messageType string) (llms.MessageContent, bool, error) {
+	var content string
+	if err := json.Unmarshal([]byte(data), &content); err == nil {
+		return llms.TextParts(llms.ChatMessageType(messageType), content), true, nil
+	}
+
+	var message llms.MessageContent
+	if err := json.Unmarshal([]byte(data), &message); err != nil {
+		return llms.MessageContent{}, false, fmt.Errorf("failed to unmarshal data: %w", err)
+	}
+	return message, false, nil
+}
+
+// decodeChatMessage decodes the data column of a row into a chat message.
+func decodeChatMessage(data, messageType string) (llms.ChatMessage, error) { //nolint:ireturn
+	message, text, err := decodeMessageContent(data, messageType)
+	if err != nil {
+		return nil, err
+	}
+	if text {
+		switch llms.ChatMessageType(messageType) {
+		case llms.ChatMessageTypeAI, llms.ChatMessageTypeHuman, llms.ChatMessageTypeSystem,
+			llms.ChatMessageTypeTool, llms.ChatMessageTypeFunction, llms.ChatMessageTypeGeneric:
+		default:
+			return nil, fmt.Errorf("unsupported message type: %s", messageType)
+		}
+	}
+	return llms.ChatMessageFromMessageContent(message), nil
+}
@@ -1,6 +1,7 @@
 package alloydb
 
 import (
+	"encoding/json"
 	"errors"
 	"strings"
 	"testing"
@@ -395,3 +396,72 @@ func TestChatMessageHistory_QueryFormatting(t *testing.T) {
 		})
 	}
 }
+
+func TestChatMessageHistory_DecodeMessageContent(t *testing.T) {
+	message := llms.MessageContent{
+		Role: llms.ChatMessageTypeAI,
+		Parts: []llms.ContentPart{
+			llms.TextContent{Text: "Let me check."},
+			llms.ToolCall{
+				ID:           "call_1",
+				Type:         "function",
+				FunctionCall: &llms.FunctionCall{Name: "weather", Arguments: `{"city":"Paris"}`},
+			},
+		},
+	}
+	data, err := json.Marshal(message)
+	require.NoError(t, err)
+
+	decoded, text, err := decodeMessageContent(string(data), string(llms.ChatMessageTypeAI))
+	require.NoError(t, err)
+	assert.False(t, text)
+	assert.Equal(t, message, decoded)
+
+	chatMessage, err := decodeChatMessage(string(data), string(llms.ChatMessageTypeAI))
+	require.NoError(t, err)
+	assert.Equal(t, llms.AIChatMessage{Content: "Let me check.", ToolCalls: []llms.ToolCall{
+		message.Parts[1].(llms.ToolCall),
+	}}, chatMessage)
+
+	// Rows added as chat messages hold the text as a JSON string.
+	decoded, text, err = decodeMessageContent(`"Hello, world!"`, string(llms.ChatMessageTypeHuman))
+	require.NoError(t, err)
+	assert.True(t, text)
+	assert.Equal(t, llms.TextParts(llms.ChatMessageTypeHuman, "Hello, world!"), decoded)
+
+	_, err = decodeChatMessage(`"Hello"`, "unknown")
+	require.Error(t, err)
+}
+
+func TestChatMessageHistory_ChatMessageRoundTrip(t *testing.T) {
+	messages := []llms.ChatMessage{
+		llms.SystemChatMessage{Content: "You are a weather bot."},
+		llms.HumanChatMessage{Content: "Weather in Paris?"},
+		llms.AIChatMessage{
+			Content:          "Let me check.",
+			ThinkingContent:  "The user wants the weather.",
+			ThoughtSignature: "sig",
+			ToolCalls: []llms.ToolCall{{
+				ID:           "call_1",
+				Type:         "function",
+				FunctionCall: &llms.FunctionCall{Name: "weather", Arguments: `{"city":"Paris"}`},
+			}},
+		},
+		llms.ToolChatMessage{ID: "call_1", Content: "sunny"},
+	}
+	for _, message := range messages {
+		// Chat messages are stored as message contents, like in AddMessage.
+		content := llms.MessageContentFromChatMessage(message)
+		data, err := json.Marshal(content)
+		require.NoError(t, err)
+
+		decoded, err := decodeChatMessage(string(data), string(content.Role))
+		require.NoError(t, err)
+		assert.Equal(t, message, decoded)
+	}
+
+	// Rows of tool messages stored as text only are still read.
+	decoded, err := decodeChatMessage(`"sunny"`, string(llms.ChatMessageTypeTool))
+	require.NoError(t, err)
+	assert.Equal(t, llms.ToolChatMessage{Content: "sunny"}, decoded)
+}
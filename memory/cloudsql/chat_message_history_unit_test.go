@@ -0,0 +1,43 @@
+package cloudsql
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/devmiahub/langchaingo/llms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChatMessageHistory_ChatMessageRoundTrip(t *testing.T) {
+	messages := []llms.ChatMessage{
+		llms.SystemChatMessage{Content: "You are a weather bot."},
+		llms.HumanChatMessage{Content: "Weather in Paris?"},
+		llms.AIChatMessage{
+			Content:          "Let me check.",
+			ThinkingContent:  "The user wants the weather.",
+			ThoughtSignature: "sig",
+			ToolCalls: []llms.ToolCall{{
+				ID:           "call_1",
+				Type:         "function",
+				FunctionCall: &llms.FunctionCall{Name: "weather", Arguments: `{"city":"Paris"}`},
+			}},
+		},
+		llms.ToolChatMessage{ID: "call_1", Content: "sunny"},
+	}
+	for _, message := range messages {
+		// Chat messages are stored as message contents, like in AddMessage.
+		content := llms.MessageContentFromChatMessage(message)
+		data, err := json.Marshal(content)
+		require.NoError(t, err)
+
+		decoded, err := decodeChatMessage(string(data), string(content.Role))
+		require.NoError(t, err)
+		assert.Equal(t, message, decoded)
+	}
+
+	// Rows of tool messages stored as text only are still read.
+	decoded, err := decodeChatMessage(`"sunny"`, string(llms.ChatMessageTypeTool))
+	require.NoError(t, err)
+	assert.Equal(t, llms.ToolChatMessage{Content: "sunny"}, decoded)
+}
@@ -5,5 +5,9 @@ a variety of implementations for storing and retrieving that data.
 The main components of this package are:
 - ChatMessageHistory: a struct that stores chat messages.
 - ConversationBuffer: a simple form of memory that remembers previous conversational back and forth directly.
+- ConversationSummary: a memory that keeps a rolling LLM-generated summary of the conversation.
+- ConversationSummaryBuffer: a memory that keeps recent messages verbatim and summarizes older ones.
+- VectorStoreRetriever: a long-term memory that loads the past exchanges most relevant to the input from a vector store.
+- ConversationEntity: a memory that keeps LLM-generated summaries of the entities mentioned in the conversation in an EntityStore.
 */
 package memory
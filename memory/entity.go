@@ -0,0 +1,318 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/devmiahub/langchaingo/llms"
+	"github.com/devmiahub/langchaingo/prompts"
+	"github.com/devmiahub/langchaingo/schema"
+)
+
+//nolint:lll
+const _entityExtractionTemplate = `You are an AI assistant reading the transcript of a conversation between an AI and a human. Extract all of the proper nouns from the last line of conversation. As a guideline, a proper noun is generally capitalized. You should definitely extract all names and places.
+
+The conversation history is provided just in case of a coreference (e.g. "What do you know about him" where "him" is defined in a previous line) -- ignore items mentioned there that are not in the last line.
+
+Return the output as a single comma-separated list, or NONE if there is nothing of note to return (e.g. the user is just issuing a greeting or having a simple conversation).
+
+EXAMPLE
+Conversation history:
+Person #1: how's it going today?
+AI: "It's going great! How about you?"
+Person #1: good! busy working on Langchain. lots to do.
+AI: "That sounds like a lot of work! What kind of things are you doing to make Langchain better?"
+Last line:
+Person #1: i'm trying to improve Langchain's interfaces, the UX, its integrations with various products the user might want ... a lot of stuff.
+Output: Langchain
+END OF EXAMPLE
+
+EXAMPLE
+Conversation history:
+Person #1: how's it going today?
+AI: "It's going great! How about you?"
+Person #1: good! busy working on Langchain. lots to do.
+AI: "That sounds like a lot of work! What kind of things are you doing to make Langchain better?"
+Last line:
+Person #1: i'm trying to improve Langchain's interfaces, the UX, its integrations with various products the user might want ... a lot of stuff. I'm working with Person #2.
+Output: Langchain, Person #2
+END OF EXAMPLE
+
+Conversation history (for reference only):
+{{.history}}
+Last line of conversation (for extraction):
+Human: {{.input}}
+
+Output:`
+
+//nolint:lll
+const _entitySummarizationTemplate = `You are an AI assistant helping a human keep track of facts about relevant people, places, and concepts in their life. Update the summary of the provided entity in the "Entity" section based on the last line of your conversation with the human. If you are writing the summary for the first time, return a single sentence.
+The update should only include facts that are relayed in the last line of conversation about the provided entity, and should only contain facts about the provided entity.
+
+If there is no new information about the provided entity or the information is not worth noting (not an important or relevant fact to remember long-term), return the existing summary unchanged.
+
+Full conversation history (for context):
+{{.history}}
+
+Entity to summarize:
+{{.entity}}
+
+Existing summary of {{.entity}}:
+{{.summary}}
+
+Last line of conversation:
+Human: {{.input}}
+Updated summary:`
+
+const (
+	_defaultEntityKey   = "entities"
+	_defaultEntityK     = 3
+	_noEntitiesResponse = "NONE"
+)
+
+// ConversationEntity is a memory that tracks facts about the people, places
+// and things mentioned in the conversation. It uses an LLM to extract the
+// entities mentioned in each input and to keep a summary of each entity up
+// to date in an entity store.
+//
+// Besides the recent messages of the conversation, it loads the summaries of
+// the entities mentioned in the input under EntityKey, as lines of the form
+// "entity: summary", or as a map from entity to summary if ReturnMessages is
+// set.
+type ConversationEntity struct {
+	ConversationBuffer
+	LLM         llms.Model
+	EntityStore EntityStore
+	// EntityKey is the memory key of the entity summaries.
+	EntityKey string
+	// K is the number of recent exchanges loaded and given to the LLM as
+	// context.
+	K int
+	// ExtractionPrompt is the prompt used to extract the entities. It gets the
+	// recent history as "history" and the input as "input".
+	ExtractionPrompt prompts.PromptTemplate
+	// SummarizationPrompt is the prompt used to update the summary of an
+	// entity. It gets "history", "input", "entity" and "summary".
+	SummarizationPrompt prompts.PromptTemplate
+
+	mu             sync.Mutex
+	entitiesInput  string
+	cachedEntities []string
+}
+
+// Statically assert that ConversationEntity implement the memory interface.
+var _ schema.Memory = &ConversationEntity{}
+
+// NewConversationEntity is a function for creating a new entity memory. If the
+// entity store is nil the entities are kept in memory.
+func NewConversationEntity(
+	llm llms.Model,
+	entityStore EntityStore,
+	options ...ConversationBufferOption,
+) *ConversationEntity {
+	if entityStore == nil {
+		entityStore = NewInMemoryEntityStore()
+	}
+
+	return &ConversationEntity{
+		ConversationBuffer:  *applyBufferOptions(options...),
+		LLM:                 llm,
+		EntityStore:         entityStore,
+		EntityKey:           _defaultEntityKey,
+		K:                   _defaultEntityK,
+		ExtractionPrompt:    prompts.NewPromptTemplate(_entityExtractionTemplate, []string{"history", "input"}),
+		SummarizationPrompt: prompts.NewPromptTemplate(_entitySummarizationTemplate, []string{"history", "input", "entity", "summary"}), //nolint:lll
+	}
+}
+
+// MemoryVariables returns the memory key and the entity key.
+func (m *ConversationEntity) MemoryVariables(context.Context) []string {
+	return []string{m.MemoryKey, m.EntityKey}
+}
+
+// LoadMemoryVariables extracts the entities mentioned in the input and returns
+// their summaries along with the recent messages.
+func (m *ConversationEntity) LoadMemoryVariables(
+	ctx context.Context, inputs map[string]any,
+) (map[string]any, error) {
+	recent, err := m.recentMessages(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var entities []string
+	if len(inputs) > 0 {
+		input, err := GetInputValue(inputs, m.InputKey)
+		if err != nil {
+			return nil, err
+		}
+		entities, err = m.extractEntities(ctx, recent, input)
+		if err != nil {
+			return nil, err
+		}
+
+		m.mu.Lock()
+		m.entitiesInput, m.cachedEntities = input, entities
+		m.mu.Unlock()
+	}
+
+	summaries := make(map[string]string, len(entities))
+	lines := make([]string, 0, len(entities))
+	for _, entity := range entities {
+		summary, ok, err := m.EntityStore.Get(ctx, entity)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		summaries[entity] = summary
+		lines = append(lines, fmt.Sprintf("%s: %s", entity, summary))
+	}
+
+	if m.ReturnMessages {
+		return map[string]any{
+			m.MemoryKey: recent,
+			m.EntityKey: summaries,
+		}, nil
+	}
+
+	history, err := llms.GetBufferString(recent, m.HumanPrefix, m.AIPrefix)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		m.MemoryKey: history,
+		m.EntityKey: strings.Join(lines, "\n"),
+	}, nil
+}
+
+// SaveContext saves the exchange and updates the summaries of the entities
+// mentioned in the input.
+func (m *ConversationEntity) SaveContext(
+	ctx context.Context, inputValues map[string]any, outputValues map[string]any,
+) error {
+	err := m.ConversationBuffer.SaveContext(ctx, inputValues, outputValues)
+	if err != nil {
+		return err
+	}
+
+	input, err := GetInputValue(inputValues, m.InputKey)
+	if err != nil {
+		return err
+	}
+
+	recent, err := m.recentMessages(ctx)
+	if err != nil {
+		return err
+	}
+	history, err := llms.GetBufferString(recent, m.HumanPrefix, m.AIPrefix)
+	if err != nil {
+		return err
+	}
+
+	// The entities were usually extracted when the memory was loaded for the
+	// same input.
+	var entities []string
+	m.mu.Lock()
+	if m.entitiesInput == input {
+		entities = m.cachedEntities
+	}
+	m.entitiesInput, m.cachedEntities = "", nil
+	m.mu.Unlock()
+	if entities == nil {
+		// The history given for reference excludes the exchange just saved.
+		entities, err = m.extractEntities(ctx, recent[:max(len(recent)-2, 0)], input)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, entity := range entities {
+		if err := m.updateEntitySummary(ctx, history, input, entity); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Clear removes the messages and the entities.
+func (m *ConversationEntity) Clear(ctx context.Context) error {
+	if err := m.ConversationBuffer.Clear(ctx); err != nil {
+		return err
+	}
+	return m.EntityStore.Clear(ctx)
+}
+
+func (m *ConversationEntity) recentMessages(ctx context.Context) ([]llms.ChatMessage, error) {
+	messages, err := m.ChatHistory.Messages(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if numMessages := 2 * m.K; len(messages) > numMessages {
+		messages = messages[len(messages)-numMessages:]
+	}
+	return messages, nil
+}
+
+func (m *ConversationEntity) extractEntities(
+	ctx context.Context, recent []llms.ChatMessage, input string,
+) ([]string, error) {
+	history, err := llms.GetBufferString(recent, m.HumanPrefix, m.AIPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	prompt, err := m.ExtractionPrompt.Format(map[string]any{
+		"history": history,
+		"input":   input,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := llms.GenerateFromSinglePrompt(ctx, m.LLM, prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	entities := make([]string, 0)
+	output = strings.TrimSpace(output)
+	if strings.EqualFold(output, _noEntitiesResponse) {
+		return entities, nil
+	}
+	for _, entity := range strings.Split(output, ",") {
+		entity = strings.TrimSpace(entity)
+		if entity != "" && !strings.EqualFold(entity, _noEntitiesResponse) {
+			entities = append(entities, entity)
+		}
+	}
+	return entities, nil
+}
+
+func (m *ConversationEntity) updateEntitySummary(ctx context.Context, history, input, entity string) error {
+	summary, _, err := m.EntityStore.Get(ctx, entity)
+	if err != nil {
+		return err
+	}
+
+	prompt, err := m.SummarizationPrompt.Format(map[string]any{
+		"history": history,
+		"input":   input,
+		"entity":  entity,
+		"summary": summary,
+	})
+	if err != nil {
+		return err
+	}
+
+	output, err := llms.GenerateFromSinglePrompt(ctx, m.LLM, prompt)
+	if err != nil {
+		return err
+	}
+
+	return m.EntityStore.Set(ctx, entity, strings.TrimSpace(output))
+}
@@ -0,0 +1,71 @@
+package memory
+
+import (
+	"context"
+	"sync"
+)
+
+// EntityStore stores the summaries of the entities tracked by an entity
+// memory.
+type EntityStore interface {
+	// Get returns the summary of the entity and whether the entity is known.
+	Get(ctx context.Context, entity string) (string, bool, error)
+	// Set sets the summary of the entity.
+	Set(ctx context.Context, entity string, summary string) error
+	// Delete removes the entity.
+	Delete(ctx context.Context, entity string) error
+	// Clear removes all entities.
+	Clear(ctx context.Context) error
+}
+
+// InMemoryEntityStore is an entity store keeping the summaries in memory.
+type InMemoryEntityStore struct {
+	mu       sync.RWMutex
+	entities map[string]string
+}
+
+// Statically assert that InMemoryEntityStore implement the entity store interface.
+var _ EntityStore = &InMemoryEntityStore{}
+
+// NewInMemoryEntityStore creates a new empty in-memory entity store.
+func NewInMemoryEntityStore() *InMemoryEntityStore {
+	return &InMemoryEntityStore{
+		entities: make(map[string]string),
+	}
+}
+
+// Get returns the summary of the entity and whether the entity is known.
+func (s *InMemoryEntityStore) Get(_ context.Context, entity string) (string, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	summary, ok := s.entities[entity]
+	return summary, ok, nil
+}
+
+// Set sets the summary of the entity.
+func (s *InMemoryEntityStore) Set(_ context.Context, entity string, summary string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entities[entity] = summary
+	return nil
+}
+
+// Delete removes the entity.
+func (s *InMemoryEntityStore) Delete(_ context.Context, entity string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entities, entity)
+	return nil
+}
+
+// Clear removes all entities.
+func (s *InMemoryEntityStore) Clear(context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entities = make(map[string]string)
+	return nil
+}
@@ -0,0 +1,89 @@
+package memory
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/devmiahub/langchaingo/llms"
+	"github.com/stretchr/testify/require"
+)
+
+// entityLLM extracts the capitalized words of the last line of conversation
+// and summarizes entities by the last input mentioning them.
+type entityLLM struct {
+	extractions int
+}
+
+func (l *entityLLM) GenerateContent(
+	_ context.Context, messages []llms.MessageContent, _ ...llms.CallOption,
+) (*llms.ContentResponse, error) {
+	prompt := messages[0].Parts[0].(llms.TextContent).Text
+	lastLine := prompt[strings.LastIndex(prompt, "Human: ")+len("Human: "):]
+	lastLine = strings.TrimSpace(lastLine[:strings.Index(lastLine, "\n")])
+
+	content := lastLine
+	if strings.Contains(prompt, "Extract all of the proper nouns") {
+		l.extractions++
+		entities := make([]string, 0)
+		for _, word := range strings.Fields(lastLine) {
+			if word[0] >= 'A' && word[0] <= 'Z' && word != "I" {
+				entities = append(entities, strings.Trim(word, ".?"))
+			}
+		}
+		content = strings.Join(entities, ", ")
+		if content == "" {
+			content = "NONE"
+		}
+	}
+
+	return &llms.ContentResponse{Choices: []*llms.ContentChoice{{Content: content}}}, nil
+}
+
+func (l *entityLLM) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, l, prompt, options...)
+}
+
+func TestConversationEntity(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	llm := &entityLLM{}
+	store := NewInMemoryEntityStore()
+	m := NewConversationEntity(llm, store)
+	require.Equal(t, []string{"history", "entities"}, m.MemoryVariables(ctx))
+
+	inputs := map[string]any{"input": "Alice moved to Paris."}
+	vars, err := m.LoadMemoryVariables(ctx, inputs)
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"history": "", "entities": ""}, vars)
+
+	err = m.SaveContext(ctx, inputs, map[string]any{"output": "Good for her."})
+	require.NoError(t, err)
+	require.Equal(t, 1, llm.extractions)
+
+	summary, ok, err := store.Get(ctx, "Alice")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, "Alice moved to Paris.", summary)
+
+	vars, err = m.LoadMemoryVariables(ctx, map[string]any{"input": "where does Alice live?"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{
+		"history":  "Human: Alice moved to Paris.\nAI: Good for her.",
+		"entities": "Alice: Alice moved to Paris.",
+	}, vars)
+
+	// Saving without loading first extracts the entities again.
+	err = m.SaveContext(ctx, map[string]any{"input": "Bob is a cook."}, map[string]any{"output": "Nice."})
+	require.NoError(t, err)
+	require.Equal(t, 3, llm.extractions)
+	_, ok, err = store.Get(ctx, "Bob")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	require.NoError(t, m.Clear(ctx))
+	_, ok, err = store.Get(ctx, "Alice")
+	require.NoError(t, err)
+	require.False(t, ok)
+}
@@ -0,0 +1,63 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/devmiahub/langchaingo/llms"
+	"github.com/devmiahub/langchaingo/schema"
+)
+
+// MessageContentHistory is a struct that stores the full content of messages
+// in memory.
+type MessageContentHistory struct {
+	mu       sync.RWMutex
+	messages []llms.MessageContent
+}
+
+// Statically assert that MessageContentHistory implement the message content history interface.
+var _ schema.MessageContentHistory = &MessageContentHistory{}
+
+// NewMessageContentHistory creates a new MessageContentHistory holding the
+// previous messages.
+func NewMessageContentHistory(previousMessages ...llms.MessageContent) *MessageContentHistory {
+	return &MessageContentHistory{
+		messages: slices.Clone(previousMessages),
+	}
+}
+
+// AddMessageContent adds a message to the history.
+func (h *MessageContentHistory) AddMessageContent(_ context.Context, message llms.MessageContent) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.messages = append(h.messages, message)
+	return nil
+}
+
+// MessageContents returns all messages stored.
+func (h *MessageContentHistory) MessageContents(context.Context) ([]llms.MessageContent, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return slices.Clone(h.messages), nil
+}
+
+// SetMessageContents replaces the messages of the history.
+func (h *MessageContentHistory) SetMessageContents(_ context.Context, messages []llms.MessageContent) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.messages = slices.Clone(messages)
+	return nil
+}
+
+// Clear removes all messages.
+func (h *MessageContentHistory) Clear(context.Context) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.messages = nil
+	return nil
+}
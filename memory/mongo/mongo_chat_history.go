@@ -29,10 +29,15 @@ type ChatMessageHistory struct {
 type chatMessageModel struct {
 	SessionID string `bson:"SessionId" json:"SessionId"`
 	History   string `bson:"History"   json:"History"`
+	// Content is the full content of messages added as message contents.
+	Content string `bson:"Content,omitempty" json:"Content,omitempty"`
 }
 
-// Statically assert that MongoDBChatMessageHistory implement the chat message history interface.
-var _ schema.ChatMessageHistory = &ChatMessageHistory{}
+// Statically assert that MongoDBChatMessageHistory implement the chat message history interfaces.
+var (
+	_ schema.ChatMessageHistory    = &ChatMessageHistory{}
+	_ schema.MessageContentHistory = &ChatMessageHistory{}
+)
 
 // NewMongoDBChatMessageHistory creates a new MongoDBChatMessageHistory using chat message options.
 func NewMongoDBChatMessageHistory(ctx context.Context, options ...ChatMessageHistoryOption) (*ChatMessageHistory, error) {
@@ -59,25 +64,15 @@ func NewMongoDBChatMessageHistory(ctx context.Context, options ...ChatMessageHis
 
 // Messages returns all messages stored.
 func (h *ChatMessageHistory) Messages(ctx context.Context) ([]llms.ChatMessage, error) {
-	messages := []llms.ChatMessage{}
-	filter := bson.M{mongoSessionIDKey: h.sessionID}
-	cursor, err := h.collection.Find(ctx, filter)
+	contents, err := h.MessageContents(ctx)
 	if err != nil {
-		return messages, err
+		return []llms.ChatMessage{}, err
 	}
 
-	_messages := []chatMessageModel{}
-	if err := cursor.All(ctx, &_messages); err != nil {
-		return messages, err
+	messages := make([]llms.ChatMessage, 0, len(contents))
+	for _, content := range contents {
+		messages = append(messages, llms.ChatMessageFromMessageContent(content))
 	}
-	for _, message := range _messages {
-		m := llms.ChatMessageModel{}
-		if err := json.Unmarshal([]byte(message.History), &m); err != nil {
-			return messages, err
-		}
-		messages = append(messages, m.ToChatMessage())
-	}
-
 	return messages, nil
 }
 
@@ -98,39 +93,112 @@ func (h *ChatMessageHistory) Clear(ctx context.Context) error {
 	return err
 }
 
-// AddMessage adds a message to the store.
+// AddMessage adds a message with its full content, including the tool calls
+// and thinking of AI messages and the tool call IDs of tool messages, to the
+// store.
 func (h *ChatMessageHistory) AddMessage(ctx context.Context, message llms.ChatMessage) error {
-	_message, err := json.Marshal(llms.ConvertChatMessageToModel(message))
+	return h.AddMessageContent(ctx, llms.MessageContentFromChatMessage(message))
+}
+
+// SetMessages replaces existing messages in the store, storing their full
+// content.
+func (h *ChatMessageHistory) SetMessages(ctx context.Context, messages []llms.ChatMessage) error {
+	contents := make([]llms.MessageContent, 0, len(messages))
+	for _, message := range messages {
+		contents = append(contents, llms.MessageContentFromChatMessage(message))
+	}
+	return h.SetMessageContents(ctx, contents)
+}
+
+// MessageContents returns all messages stored with their full content.
+// Messages added as chat messages are converted to message contents.
+func (h *ChatMessageHistory) MessageContents(ctx context.Context) ([]llms.MessageContent, error) {
+	messages := []llms.MessageContent{}
+	filter := bson.M{mongoSessionIDKey: h.sessionID}
+	cursor, err := h.collection.Find(ctx, filter)
 	if err != nil {
-		return err
+		return messages, err
 	}
 
-	_, err = h.collection.InsertOne(ctx, chatMessageModel{
-		SessionID: h.sessionID,
-		History:   string(_message),
-	})
+	_messages := []chatMessageModel{}
+	if err := cursor.All(ctx, &_messages); err != nil {
+		return messages, err
+	}
+	for _, message := range _messages {
+		mc, err := message.messageContent()
+		if err != nil {
+			return messages, err
+		}
+		messages = append(messages, mc)
+	}
+
+	return messages, nil
+}
+
+// messageContent returns the full content of the stored message. Messages
+// stored before their content was hold only their text.
+func (m chatMessageModel) messageContent() (llms.MessageContent, error) {
+	if m.Content == "" {
+		history := llms.ChatMessageModel{}
+		if err := json.Unmarshal([]byte(m.History), &history); err != nil {
+			return llms.MessageContent{}, err
+		}
+		return llms.TextParts(llms.ChatMessageType(history.Type), history.Data.Content), nil
+	}
+
+	mc := llms.MessageContent{}
+	err := json.Unmarshal([]byte(m.Content), &mc)
+	return mc, err
+}
 
+// AddMessageContent adds a message with its full content to the store.
+func (h *ChatMessageHistory) AddMessageContent(ctx context.Context, message llms.MessageContent) error {
+	_message, err := newMessageContentModel(h.sessionID, message)
+	if err != nil {
+		return err
+	}
+
+	_, err = h.collection.InsertOne(ctx, _message)
 	return err
 }
 
-// SetMessages replaces existing messages in the store.
-func (h *ChatMessageHistory) SetMessages(ctx context.Context, messages []llms.ChatMessage) error {
+// SetMessageContents replaces existing messages in the store.
+func (h *ChatMessageHistory) SetMessageContents(ctx context.Context, messages []llms.MessageContent) error {
 	_messages := []interface{}{}
 	for _, message := range messages {
-		_message, err := json.Marshal(llms.ConvertChatMessageToModel(message))
+		_message, err := newMessageContentModel(h.sessionID, message)
 		if err != nil {
 			return err
 		}
-		_messages = append(_messages, chatMessageModel{
-			SessionID: h.sessionID,
-			History:   string(_message),
-		})
+		_messages = append(_messages, _message)
 	}
 
 	if err := h.Clear(ctx); err != nil {
 		return err
 	}
+	if len(_messages) == 0 {
+		return nil
+	}
 
 	_, err := h.collection.InsertMany(ctx, _messages)
 	return err
 }
+
+// newMessageContentModel stores the full content of the message along with
+// the history readers of chat messages understand.
+func newMessageContentModel(sessionID string, message llms.MessageContent) (chatMessageModel, error) {
+	content, err := json.Marshal(message)
+	if err != nil {
+		return chatMessageModel{}, err
+	}
+	history, err := json.Marshal(llms.ConvertChatMessageToModel(llms.ChatMessageFromMessageContent(message)))
+	if err != nil {
+		return chatMessageModel{}, err
+	}
+
+	return chatMessageModel{
+		SessionID: sessionID,
+		History:   string(history),
+		Content:   string(content),
+	}, nil
+}
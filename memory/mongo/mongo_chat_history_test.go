@@ -94,3 +94,80 @@ func TestMongoDBChatMessageHistory(t *testing.T) {
 		}
 	})
 }
+
+func TestMongoDBMessageContentHistory(t *testing.T) {
+	t.Parallel()
+	testctr.SkipIfDockerNotAvailable(t)
+	ctx := context.Background()
+
+	url := runTestContainer(t)
+	history, err := NewMongoDBChatMessageHistory(ctx, WithConnectionURL(url), WithSessionID("testSessionContent"))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := history.Clear(context.Background()); err != nil {
+			t.Logf("Failed to clear mongo history: %v", err)
+		}
+	})
+
+	err = history.AddUserMessage(ctx, "What is the weather in Paris?")
+	require.NoError(t, err)
+
+	toolCall := llms.MessageContent{
+		Role: llms.ChatMessageTypeAI,
+		Parts: []llms.ContentPart{
+			llms.ThinkingPartWithSignature("I should check.", "sig"),
+			llms.ToolCall{ID: "call_1", Type: "function", FunctionCall: &llms.FunctionCall{Name: "weather", Arguments: `{"city":"Paris"}`}},
+		},
+	}
+	toolResponse := llms.MessageContent{
+		Role:  llms.ChatMessageTypeTool,
+		Parts: []llms.ContentPart{llms.ToolCallResponse{ToolCallID: "call_1", Name: "weather", Content: "sunny"}},
+	}
+	require.NoError(t, history.AddMessageContent(ctx, toolCall))
+	require.NoError(t, history.AddMessageContent(ctx, toolResponse))
+
+	messages, err := history.MessageContents(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []llms.MessageContent{
+		llms.TextParts(llms.ChatMessageTypeHuman, "What is the weather in Paris?"),
+		toolCall,
+		toolResponse,
+	}, messages)
+}
+
+func TestChatMessageModelRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	messages := []llms.ChatMessage{
+		llms.SystemChatMessage{Content: "You are a weather bot."},
+		llms.HumanChatMessage{Content: "Weather in Paris?"},
+		llms.AIChatMessage{
+			Content:          "Let me check.",
+			ThinkingContent:  "The user wants the weather.",
+			ThoughtSignature: "sig",
+			ToolCalls: []llms.ToolCall{{
+				ID:           "call_1",
+				Type:         "function",
+				FunctionCall: &llms.FunctionCall{Name: "weather", Arguments: `{"city":"Paris"}`},
+			}},
+		},
+		llms.ToolChatMessage{ID: "call_1", Content: "sunny"},
+	}
+	for _, message := range messages {
+		// Chat messages are stored as message contents, like in AddMessage.
+		model, err := newMessageContentModel("session", llms.MessageContentFromChatMessage(message))
+		require.NoError(t, err)
+
+		content, err := model.messageContent()
+		require.NoError(t, err)
+		assert.Equal(t, message, llms.ChatMessageFromMessageContent(content))
+	}
+
+	// Messages stored with their text only are still read, system messages
+	// included.
+	content, err := chatMessageModel{
+		History: `{"type":"system","data":{"type":"system","content":"You are a weather bot."}}`,
+	}.messageContent()
+	require.NoError(t, err)
+	assert.Equal(t, llms.SystemChatMessage{Content: "You are a weather bot."}, llms.ChatMessageFromMessageContent(content))
+}
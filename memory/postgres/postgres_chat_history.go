@@ -0,0 +1,226 @@
+// Package postgres adds support for chat message history using PostgreSQL.
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/devmiahub/langchaingo/llms"
+	"github.com/devmiahub/langchaingo/schema"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// DefaultSchema is the schema of the messages table, created when the history
+// is created.
+const DefaultSchema = `CREATE TABLE IF NOT EXISTS %[1]s (
+	id BIGSERIAL PRIMARY KEY,
+	session_id TEXT NOT NULL,
+	message JSONB NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS %[2]s_session_id_idx ON %[1]s (session_id);`
+
+// PGXConn represents both a pgx.Conn and pgxpool.Pool conn.
+type PGXConn interface {
+	Ping(ctx context.Context) error
+	Begin(ctx context.Context) (pgx.Tx, error)
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, arguments ...any) (pgx.Rows, error)
+}
+
+// ChatMessageHistory is a chat message history storing the messages of a
+// session in a PostgreSQL table. The messages are stored with their full
+// content as JSONB, so that tool calls, images and thinking content are kept.
+type ChatMessageHistory struct {
+	url         string
+	sessionID   string
+	tableName   string
+	ttl         time.Duration
+	maxMessages int
+	conn        PGXConn
+	ownedConn   *pgx.Conn
+}
+
+// Statically assert that ChatMessageHistory implement the chat message history interfaces.
+var (
+	_ schema.ChatMessageHistory    = &ChatMessageHistory{}
+	_ schema.MessageContentHistory = &ChatMessageHistory{}
+)
+
+// NewPostgresChatMessageHistory creates a new ChatMessageHistory using chat
+// message options and creates its table if it does not exist.
+func NewPostgresChatMessageHistory(
+	ctx context.Context,
+	options ...ChatMessageHistoryOption,
+) (*ChatMessageHistory, error) {
+	h, err := applyPostgresChatOptions(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	if h.conn == nil {
+		h.ownedConn, err = pgx.Connect(ctx, h.url)
+		if err != nil {
+			return nil, err
+		}
+		h.conn = h.ownedConn
+	}
+	if err := h.conn.Ping(ctx); err != nil {
+		return nil, err
+	}
+
+	indexPrefix := strings.ReplaceAll(h.tableName, ".", "_")
+	if _, err := h.conn.Exec(ctx, fmt.Sprintf(DefaultSchema, h.tableName, indexPrefix)); err != nil {
+		return nil, fmt.Errorf("create table %s: %w", h.tableName, err)
+	}
+
+	return h, nil
+}
+
+// Close closes the connection if it was created by the history.
+func (h *ChatMessageHistory) Close(ctx context.Context) error {
+	if h.ownedConn != nil {
+		return h.ownedConn.Close(ctx)
+	}
+	return nil
+}
+
+// Messages returns all messages stored.
+func (h *ChatMessageHistory) Messages(ctx context.Context) ([]llms.ChatMessage, error) {
+	contents, err := h.MessageContents(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]llms.ChatMessage, 0, len(contents))
+	for _, content := range contents {
+		messages = append(messages, llms.ChatMessageFromMessageContent(content))
+	}
+	return messages, nil
+}
+
+// AddAIMessage adds an AIMessage to the chat message history.
+func (h *ChatMessageHistory) AddAIMessage(ctx context.Context, text string) error {
+	return h.AddMessage(ctx, llms.AIChatMessage{Content: text})
+}
+
+// AddUserMessage adds a user to the chat message history.
+func (h *ChatMessageHistory) AddUserMessage(ctx context.Context, text string) error {
+	return h.AddMessage(ctx, llms.HumanChatMessage{Content: text})
+}
+
+// AddMessage adds a message to the store.
+func (h *ChatMessageHistory) AddMessage(ctx context.Context, message llms.ChatMessage) error {
+	return h.AddMessageContent(ctx, llms.MessageContentFromChatMessage(message))
+}
+
+// SetMessages replaces existing messages in the store.
+func (h *ChatMessageHistory) SetMessages(ctx context.Context, messages []llms.ChatMessage) error {
+	contents := make([]llms.MessageContent, 0, len(messages))
+	for _, message := range messages {
+		contents = append(contents, llms.MessageContentFromChatMessage(message))
+	}
+	return h.SetMessageContents(ctx, contents)
+}
+
+// Clear removes all messages of the session.
+func (h *ChatMessageHistory) Clear(ctx context.Context) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE session_id = $1`, h.tableName)
+	_, err := h.conn.Exec(ctx, query, h.sessionID)
+	return err
+}
+
+// MessageContents returns all messages stored with their full content.
+func (h *ChatMessageHistory) MessageContents(ctx context.Context) ([]llms.MessageContent, error) {
+	query := fmt.Sprintf(`SELECT message FROM %s WHERE session_id = $1`, h.tableName)
+	args := []any{h.sessionID}
+	if h.ttl > 0 {
+		query += ` AND created_at > now() - make_interval(secs => $2)`
+		args = append(args, h.ttl.Seconds())
+	}
+	query += ` ORDER BY id`
+
+	rows, err := h.conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	messages := []llms.MessageContent{}
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+
+		var message llms.MessageContent
+		if err := json.Unmarshal(data, &message); err != nil {
+			return nil, err
+		}
+		messages = append(messages, message)
+	}
+	return messages, rows.Err()
+}
+
+// AddMessageContent adds a message with its full content to the store.
+func (h *ChatMessageHistory) AddMessageContent(ctx context.Context, message llms.MessageContent) error {
+	return h.write(ctx, false, []llms.MessageContent{message})
+}
+
+// SetMessageContents replaces existing messages in the store.
+func (h *ChatMessageHistory) SetMessageContents(ctx context.Context, messages []llms.MessageContent) error {
+	return h.write(ctx, true, messages)
+}
+
+// write appends the messages to the session, replacing the existing messages
+// if replace is set, and removes the expired messages and the messages over
+// the maximum length in a single transaction.
+func (h *ChatMessageHistory) write(ctx context.Context, replace bool, messages []llms.MessageContent) error {
+	tx, err := h.conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	if replace {
+		query := fmt.Sprintf(`DELETE FROM %s WHERE session_id = $1`, h.tableName)
+		if _, err := tx.Exec(ctx, query, h.sessionID); err != nil {
+			return err
+		}
+	}
+
+	query := fmt.Sprintf(`INSERT INTO %s (session_id, message) VALUES ($1, $2)`, h.tableName)
+	for _, message := range messages {
+		data, err := json.Marshal(message)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, query, h.sessionID, data); err != nil {
+			return err
+		}
+	}
+
+	if h.ttl > 0 {
+		query := fmt.Sprintf(
+			`DELETE FROM %s WHERE session_id = $1 AND created_at <= now() - make_interval(secs => $2)`,
+			h.tableName,
+		)
+		if _, err := tx.Exec(ctx, query, h.sessionID, h.ttl.Seconds()); err != nil {
+			return err
+		}
+	}
+
+	if h.maxMessages > 0 {
+		query := fmt.Sprintf(`DELETE FROM %[1]s WHERE session_id = $1 AND id NOT IN (
+			SELECT id FROM %[1]s WHERE session_id = $1 ORDER BY id DESC LIMIT $2)`, h.tableName)
+		if _, err := tx.Exec(ctx, query, h.sessionID, h.maxMessages); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// DefaultTableName is the default name of the table holding the messages.
+	// Its schema differs from that of the message_store table of langchain.
+	DefaultTableName = "langchaingo_messages"
+)
+
+var (
+	errPostgresInvalidURL       = errors.New("invalid postgres url option")
+	errPostgresInvalidSessionID = errors.New("invalid postgres session id option")
+)
+
+// ChatMessageHistoryOption is a function for creating a new chat message
+// history with other than the default values.
+type ChatMessageHistoryOption func(h *ChatMessageHistory)
+
+func applyPostgresChatOptions(options ...ChatMessageHistoryOption) (*ChatMessageHistory, error) {
+	h := &ChatMessageHistory{
+		tableName: DefaultTableName,
+	}
+
+	for _, option := range options {
+		option(h)
+	}
+
+	if h.conn == nil && h.url == "" {
+		return nil, errPostgresInvalidURL
+	}
+	if h.sessionID == "" {
+		return nil, errPostgresInvalidSessionID
+	}
+
+	return h, nil
+}
+
+// WithConnectionURL is an option for specifying the Postgres connection URL.
+// Either this or WithConn must be set.
+func WithConnectionURL(connectionURL string) ChatMessageHistoryOption {
+	return func(h *ChatMessageHistory) {
+		h.url = connectionURL
+	}
+}
+
+// WithConn is an option for specifying an existing connection or pool. The
+// connection is not closed when the history is closed.
+func WithConn(conn PGXConn) ChatMessageHistoryOption {
+	return func(h *ChatMessageHistory) {
+		h.conn = conn
+	}
+}
+
+// WithSessionID is an arbitrary key that is used to store the messages of a single chat session,
+// like user name, email, chat id etc. Must be set.
+func WithSessionID(sessionID string) ChatMessageHistoryOption {
+	return func(h *ChatMessageHistory) {
+		h.sessionID = sessionID
+	}
+}
+
+// WithTableName is an option for specifying the table name. The table is
+// created if it does not exist.
+func WithTableName(name string) ChatMessageHistoryOption {
+	return func(h *ChatMessageHistory) {
+		h.tableName = name
+	}
+}
+
+// WithTTL is an option for expiring messages the given duration after they
+// were added. Expired messages are not returned and are deleted on the next
+// write to the session. By default the messages never expire.
+func WithTTL(ttl time.Duration) ChatMessageHistoryOption {
+	return func(h *ChatMessageHistory) {
+		h.ttl = ttl
+	}
+}
+
+// WithMaxMessages is an option for keeping only the given number of most
+// recent messages of the session. By default all messages are kept.
+func WithMaxMessages(maxMessages int) ChatMessageHistoryOption {
+	return func(h *ChatMessageHistory) {
+		h.maxMessages = maxMessages
+	}
+}
@@ -0,0 +1,192 @@
+package postgres
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/devmiahub/langchaingo/internal/testutil/testctr"
+	"github.com/devmiahub/langchaingo/llms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/log"
+	tcpostgres "github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func getTestURL(t *testing.T) string {
+	t.Helper()
+	testctr.SkipIfDockerNotAvailable(t)
+
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	if url := os.Getenv("POSTGRES_CONNECTION_STRING"); url != "" {
+		return url
+	}
+
+	ctx := context.Background()
+	postgresContainer, err := tcpostgres.Run(
+		ctx,
+		"docker.io/postgres:16",
+		tcpostgres.WithDatabase("db_test"),
+		tcpostgres.WithUsername("user"),
+		tcpostgres.WithPassword("passw0rd!"),
+		testcontainers.WithLogger(log.TestLogger(t)),
+		testcontainers.WithWaitStrategy(
+			wait.ForAll(
+				wait.ForLog("database system is ready to accept connections").
+					WithOccurrence(2).
+					WithStartupTimeout(60*time.Second),
+				wait.ForListeningPort("5432/tcp").
+					WithStartupTimeout(60*time.Second),
+			)),
+	)
+	if err != nil && strings.Contains(err.Error(), "Cannot connect to the Docker daemon") {
+		t.Skip("Docker not available")
+	}
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		if err := postgresContainer.Terminate(context.Background()); err != nil {
+			t.Logf("Failed to terminate postgres container: %v", err)
+		}
+	})
+
+	url, err := postgresContainer.ConnectionString(ctx, "sslmode=disable")
+	require.NoError(t, err)
+	return url
+}
+
+func newTestHistory(t *testing.T, url, sessionID string, options ...ChatMessageHistoryOption) *ChatMessageHistory {
+	t.Helper()
+	ctx := context.Background()
+
+	options = append([]ChatMessageHistoryOption{WithConnectionURL(url), WithSessionID(sessionID)}, options...)
+	history, err := NewPostgresChatMessageHistory(ctx, options...)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := history.Clear(context.Background()); err != nil {
+			t.Logf("Failed to clear postgres history: %v", err)
+		}
+		if err := history.Close(context.Background()); err != nil {
+			t.Logf("Failed to close postgres connection: %v", err)
+		}
+	})
+	return history
+}
+
+func TestPostgresChatMessageHistoryOptions(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	_, err := NewPostgresChatMessageHistory(ctx, WithSessionID("test"))
+	assert.Equal(t, errPostgresInvalidURL, err)
+
+	_, err = NewPostgresChatMessageHistory(ctx, WithConnectionURL("postgres://localhost:5432/db"))
+	assert.Equal(t, errPostgresInvalidSessionID, err)
+}
+
+func TestPostgresChatMessageHistory(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	url := getTestURL(t)
+	history := newTestHistory(t, url, "testSession")
+
+	require.NoError(t, history.AddAIMessage(ctx, "Hi"))
+	require.NoError(t, history.AddUserMessage(ctx, "Hello"))
+
+	messages, err := history.Messages(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []llms.ChatMessage{
+		llms.AIChatMessage{Content: "Hi"},
+		llms.HumanChatMessage{Content: "Hello"},
+	}, messages)
+
+	err = history.SetMessages(ctx, []llms.ChatMessage{
+		llms.SystemChatMessage{Content: "You are a helpful assistant."},
+		llms.ToolChatMessage{ID: "call_1", Content: "sunny"},
+	})
+	require.NoError(t, err)
+
+	messages, err = history.Messages(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []llms.ChatMessage{
+		llms.SystemChatMessage{Content: "You are a helpful assistant."},
+		llms.ToolChatMessage{ID: "call_1", Content: "sunny"},
+	}, messages)
+
+	// Sessions are kept apart.
+	other := newTestHistory(t, url, "otherSession")
+	messages, err = other.Messages(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, messages)
+
+	require.NoError(t, history.Clear(ctx))
+	messages, err = history.Messages(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, messages)
+}
+
+func TestPostgresMessageContentHistory(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	url := getTestURL(t)
+	history := newTestHistory(t, url, "testSessionContent", WithTableName("content_store"))
+
+	toolCall := llms.MessageContent{
+		Role: llms.ChatMessageTypeAI,
+		Parts: []llms.ContentPart{
+			llms.ThinkingPartWithSignature("I should check.", "sig"),
+			llms.ToolCall{ID: "call_1", Type: "function", FunctionCall: &llms.FunctionCall{Name: "weather", Arguments: `{"city":"Paris"}`}},
+		},
+	}
+	toolResponse := llms.MessageContent{
+		Role:  llms.ChatMessageTypeTool,
+		Parts: []llms.ContentPart{llms.ToolCallResponse{ToolCallID: "call_1", Name: "weather", Content: "sunny"}},
+	}
+	require.NoError(t, history.AddUserMessage(ctx, "What is the weather in Paris?"))
+	require.NoError(t, history.AddMessageContent(ctx, toolCall))
+	require.NoError(t, history.AddMessageContent(ctx, toolResponse))
+
+	messages, err := history.MessageContents(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []llms.MessageContent{
+		llms.TextParts(llms.ChatMessageTypeHuman, "What is the weather in Paris?"),
+		toolCall,
+		toolResponse,
+	}, messages)
+}
+
+func TestPostgresChatMessageHistoryLimits(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	url := getTestURL(t)
+	history := newTestHistory(t, url, "testSessionLimits", WithMaxMessages(2))
+
+	require.NoError(t, history.AddUserMessage(ctx, "one"))
+	require.NoError(t, history.AddAIMessage(ctx, "two"))
+	require.NoError(t, history.AddUserMessage(ctx, "three"))
+
+	messages, err := history.Messages(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []llms.ChatMessage{
+		llms.AIChatMessage{Content: "two"},
+		llms.HumanChatMessage{Content: "three"},
+	}, messages)
+
+	expiring := newTestHistory(t, url, "testSessionTTL", WithTTL(time.Second))
+	require.NoError(t, expiring.AddUserMessage(ctx, "soon gone"))
+	time.Sleep(1500 * time.Millisecond)
+
+	messages, err = expiring.Messages(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, messages)
+}
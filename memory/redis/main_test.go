@@ -0,0 +1,16 @@
+package redis
+
+import (
+	"os"
+	"testing"
+
+	"github.com/devmiahub/langchaingo/internal/testutil/testctr"
+)
+
+func TestMain(m *testing.M) {
+	code := testctr.EnsureTestEnv()
+	if code == 0 {
+		code = m.Run()
+	}
+	os.Exit(code)
+}
@@ -0,0 +1,172 @@
+// Package redis adds support for chat message history using Redis.
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/devmiahub/langchaingo/llms"
+	"github.com/devmiahub/langchaingo/schema"
+	"github.com/redis/rueidis"
+)
+
+// ChatMessageHistory is a chat message history storing the messages of a
+// session in a Redis list. The messages are stored with their full content
+// as JSON, so that tool calls, images and thinking content are kept.
+type ChatMessageHistory struct {
+	url         string
+	sessionID   string
+	keyPrefix   string
+	ttl         time.Duration
+	maxMessages int
+	client      rueidis.Client
+	ownsClient  bool
+}
+
+// Statically assert that ChatMessageHistory implement the chat message history interfaces.
+var (
+	_ schema.ChatMessageHistory    = &ChatMessageHistory{}
+	_ schema.MessageContentHistory = &ChatMessageHistory{}
+)
+
+// NewRedisChatMessageHistory creates a new ChatMessageHistory using chat message options.
+func NewRedisChatMessageHistory(options ...ChatMessageHistoryOption) (*ChatMessageHistory, error) {
+	h, err := applyRedisChatOptions(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	if h.client == nil {
+		clientOption, err := rueidis.ParseURL(h.url)
+		if err != nil {
+			return nil, err
+		}
+		h.client, err = rueidis.NewClient(clientOption)
+		if err != nil {
+			return nil, err
+		}
+		h.ownsClient = true
+	}
+
+	return h, nil
+}
+
+// Close closes the Redis client if it was created by the history.
+func (h *ChatMessageHistory) Close() {
+	if h.ownsClient {
+		h.client.Close()
+	}
+}
+
+// Messages returns all messages stored.
+func (h *ChatMessageHistory) Messages(ctx context.Context) ([]llms.ChatMessage, error) {
+	contents, err := h.MessageContents(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]llms.ChatMessage, 0, len(contents))
+	for _, content := range contents {
+		messages = append(messages, llms.ChatMessageFromMessageContent(content))
+	}
+	return messages, nil
+}
+
+// AddAIMessage adds an AIMessage to the chat message history.
+func (h *ChatMessageHistory) AddAIMessage(ctx context.Context, text string) error {
+	return h.AddMessage(ctx, llms.AIChatMessage{Content: text})
+}
+
+// AddUserMessage adds a user to the chat message history.
+func (h *ChatMessageHistory) AddUserMessage(ctx context.Context, text string) error {
+	return h.AddMessage(ctx, llms.HumanChatMessage{Content: text})
+}
+
+// AddMessage adds a message to the store.
+func (h *ChatMessageHistory) AddMessage(ctx context.Context, message llms.ChatMessage) error {
+	return h.AddMessageContent(ctx, llms.MessageContentFromChatMessage(message))
+}
+
+// SetMessages replaces existing messages in the store.
+func (h *ChatMessageHistory) SetMessages(ctx context.Context, messages []llms.ChatMessage) error {
+	contents := make([]llms.MessageContent, 0, len(messages))
+	for _, message := range messages {
+		contents = append(contents, llms.MessageContentFromChatMessage(message))
+	}
+	return h.SetMessageContents(ctx, contents)
+}
+
+// Clear removes all messages of the session.
+func (h *ChatMessageHistory) Clear(ctx context.Context) error {
+	return h.client.Do(ctx, h.client.B().Del().Key(h.key()).Build()).Error()
+}
+
+// MessageContents returns all messages stored with their full content.
+func (h *ChatMessageHistory) MessageContents(ctx context.Context) ([]llms.MessageContent, error) {
+	values, err := h.client.Do(ctx, h.client.B().Lrange().Key(h.key()).Start(0).Stop(-1).Build()).AsStrSlice()
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]llms.MessageContent, 0, len(values))
+	for _, value := range values {
+		var message llms.MessageContent
+		if err := json.Unmarshal([]byte(value), &message); err != nil {
+			return nil, err
+		}
+		messages = append(messages, message)
+	}
+	return messages, nil
+}
+
+// AddMessageContent adds a message with its full content to the store.
+func (h *ChatMessageHistory) AddMessageContent(ctx context.Context, message llms.MessageContent) error {
+	return h.write(ctx, false, []llms.MessageContent{message})
+}
+
+// SetMessageContents replaces existing messages in the store.
+func (h *ChatMessageHistory) SetMessageContents(ctx context.Context, messages []llms.MessageContent) error {
+	return h.write(ctx, true, messages)
+}
+
+// write appends the messages to the list of the session, replacing the
+// existing messages if replace is set, and applies the maximum length and
+// the expiry in a single transaction.
+func (h *ChatMessageHistory) write(ctx context.Context, replace bool, messages []llms.MessageContent) error {
+	values := make([]string, 0, len(messages))
+	for _, message := range messages {
+		value, err := json.Marshal(message)
+		if err != nil {
+			return err
+		}
+		values = append(values, string(value))
+	}
+
+	key := h.key()
+	cmds := rueidis.Commands{h.client.B().Multi().Build()}
+	if replace {
+		cmds = append(cmds, h.client.B().Del().Key(key).Build())
+	}
+	if len(values) > 0 {
+		cmds = append(cmds, h.client.B().Rpush().Key(key).Element(values...).Build())
+	}
+	if h.maxMessages > 0 {
+		cmds = append(cmds, h.client.B().Ltrim().Key(key).Start(int64(-h.maxMessages)).Stop(-1).Build())
+	}
+	if h.ttl > 0 {
+		cmds = append(cmds, h.client.B().Pexpire().Key(key).Milliseconds(h.ttl.Milliseconds()).Build())
+	}
+	cmds = append(cmds, h.client.B().Exec().Build())
+
+	for _, result := range h.client.DoMulti(ctx, cmds...) {
+		if err := result.Error(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *ChatMessageHistory) key() string {
+	return h.keyPrefix + h.sessionID
+}
@@ -0,0 +1,91 @@
+package redis
+
+import (
+	"errors"
+	"time"
+
+	"github.com/redis/rueidis"
+)
+
+const (
+	// DefaultKeyPrefix is the default prefix of the keys holding the messages
+	// of a session, same as langchain.
+	DefaultKeyPrefix = "message_store:"
+)
+
+var (
+	errRedisInvalidURL       = errors.New("invalid redis url option")
+	errRedisInvalidSessionID = errors.New("invalid redis session id option")
+)
+
+// ChatMessageHistoryOption is a function for creating a new chat message
+// history with other than the default values.
+type ChatMessageHistoryOption func(h *ChatMessageHistory)
+
+func applyRedisChatOptions(options ...ChatMessageHistoryOption) (*ChatMessageHistory, error) {
+	h := &ChatMessageHistory{
+		keyPrefix: DefaultKeyPrefix,
+	}
+
+	for _, option := range options {
+		option(h)
+	}
+
+	if h.client == nil && h.url == "" {
+		return nil, errRedisInvalidURL
+	}
+	if h.sessionID == "" {
+		return nil, errRedisInvalidSessionID
+	}
+
+	return h, nil
+}
+
+// WithConnectionURL is an option for specifying the Redis connection URL.
+// Either this or WithClient must be set.
+func WithConnectionURL(connectionURL string) ChatMessageHistoryOption {
+	return func(h *ChatMessageHistory) {
+		h.url = connectionURL
+	}
+}
+
+// WithClient is an option for specifying an existing Redis client. The
+// client is not closed when the history is closed.
+func WithClient(client rueidis.Client) ChatMessageHistoryOption {
+	return func(h *ChatMessageHistory) {
+		h.client = client
+	}
+}
+
+// WithSessionID is an arbitrary key that is used to store the messages of a single chat session,
+// like user name, email, chat id etc. Must be set.
+func WithSessionID(sessionID string) ChatMessageHistoryOption {
+	return func(h *ChatMessageHistory) {
+		h.sessionID = sessionID
+	}
+}
+
+// WithKeyPrefix is an option for specifying the prefix of the key holding
+// the messages of the session.
+func WithKeyPrefix(prefix string) ChatMessageHistoryOption {
+	return func(h *ChatMessageHistory) {
+		h.keyPrefix = prefix
+	}
+}
+
+// WithTTL is an option for expiring the messages of the session after they
+// have not been written to for the given duration. By default the messages
+// never expire.
+func WithTTL(ttl time.Duration) ChatMessageHistoryOption {
+	return func(h *ChatMessageHistory) {
+		h.ttl = ttl
+	}
+}
+
+// WithMaxMessages is an option for keeping only the given number of most
+// recent messages of the session. By default all messages are kept.
+func WithMaxMessages(maxMessages int) ChatMessageHistoryOption {
+	return func(h *ChatMessageHistory) {
+		h.maxMessages = maxMessages
+	}
+}
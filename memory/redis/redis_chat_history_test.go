@@ -0,0 +1,172 @@
+package redis
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/devmiahub/langchaingo/internal/testutil/testctr"
+	"github.com/devmiahub/langchaingo/llms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/log"
+	tcredis "github.com/testcontainers/testcontainers-go/modules/redis"
+)
+
+func getTestURL(t *testing.T) string {
+	t.Helper()
+	testctr.SkipIfDockerNotAvailable(t)
+
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	if url := os.Getenv("REDIS_URL"); url != "" {
+		return url
+	}
+
+	ctx := context.Background()
+	redisContainer, err := tcredis.Run(ctx,
+		"docker.io/redis:7.2",
+		testcontainers.WithLogger(log.TestLogger(t)),
+	)
+	if err != nil && strings.Contains(err.Error(), "Cannot connect to the Docker daemon") {
+		t.Skip("Docker not available")
+	}
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		if err := redisContainer.Terminate(context.Background()); err != nil {
+			t.Logf("Failed to terminate redis container: %v", err)
+		}
+	})
+
+	url, err := redisContainer.ConnectionString(ctx)
+	require.NoError(t, err)
+	return url
+}
+
+func newTestHistory(t *testing.T, url, sessionID string, options ...ChatMessageHistoryOption) *ChatMessageHistory {
+	t.Helper()
+
+	options = append([]ChatMessageHistoryOption{WithConnectionURL(url), WithSessionID(sessionID)}, options...)
+	history, err := NewRedisChatMessageHistory(options...)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := history.Clear(context.Background()); err != nil {
+			t.Logf("Failed to clear redis history: %v", err)
+		}
+		history.Close()
+	})
+	return history
+}
+
+func TestRedisChatMessageHistoryOptions(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewRedisChatMessageHistory(WithSessionID("test"))
+	assert.Equal(t, errRedisInvalidURL, err)
+
+	_, err = NewRedisChatMessageHistory(WithConnectionURL("redis://localhost:6379"))
+	assert.Equal(t, errRedisInvalidSessionID, err)
+}
+
+func TestRedisChatMessageHistory(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	url := getTestURL(t)
+	history := newTestHistory(t, url, "testSession")
+
+	require.NoError(t, history.AddAIMessage(ctx, "Hi"))
+	require.NoError(t, history.AddUserMessage(ctx, "Hello"))
+
+	messages, err := history.Messages(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []llms.ChatMessage{
+		llms.AIChatMessage{Content: "Hi"},
+		llms.HumanChatMessage{Content: "Hello"},
+	}, messages)
+
+	err = history.SetMessages(ctx, []llms.ChatMessage{
+		llms.SystemChatMessage{Content: "You are a helpful assistant."},
+		llms.ToolChatMessage{ID: "call_1", Content: "sunny"},
+	})
+	require.NoError(t, err)
+
+	messages, err = history.Messages(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []llms.ChatMessage{
+		llms.SystemChatMessage{Content: "You are a helpful assistant."},
+		llms.ToolChatMessage{ID: "call_1", Content: "sunny"},
+	}, messages)
+
+	// Sessions are kept apart.
+	other := newTestHistory(t, url, "otherSession")
+	messages, err = other.Messages(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, messages)
+
+	require.NoError(t, history.Clear(ctx))
+	messages, err = history.Messages(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, messages)
+}
+
+func TestRedisMessageContentHistory(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	url := getTestURL(t)
+	history := newTestHistory(t, url, "testSessionContent")
+
+	toolCall := llms.MessageContent{
+		Role: llms.ChatMessageTypeAI,
+		Parts: []llms.ContentPart{
+			llms.ThinkingPartWithSignature("I should check.", "sig"),
+			llms.ToolCall{ID: "call_1", Type: "function", FunctionCall: &llms.FunctionCall{Name: "weather", Arguments: `{"city":"Paris"}`}},
+		},
+	}
+	toolResponse := llms.MessageContent{
+		Role:  llms.ChatMessageTypeTool,
+		Parts: []llms.ContentPart{llms.ToolCallResponse{ToolCallID: "call_1", Name: "weather", Content: "sunny"}},
+	}
+	require.NoError(t, history.AddUserMessage(ctx, "What is the weather in Paris?"))
+	require.NoError(t, history.AddMessageContent(ctx, toolCall))
+	require.NoError(t, history.AddMessageContent(ctx, toolResponse))
+
+	messages, err := history.MessageContents(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []llms.MessageContent{
+		llms.TextParts(llms.ChatMessageTypeHuman, "What is the weather in Paris?"),
+		toolCall,
+		toolResponse,
+	}, messages)
+}
+
+func TestRedisChatMessageHistoryLimits(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	url := getTestURL(t)
+	history := newTestHistory(t, url, "testSessionLimits", WithMaxMessages(2), WithTTL(time.Minute))
+
+	require.NoError(t, history.AddUserMessage(ctx, "one"))
+	require.NoError(t, history.AddAIMessage(ctx, "two"))
+	require.NoError(t, history.AddUserMessage(ctx, "three"))
+
+	messages, err := history.Messages(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []llms.ChatMessage{
+		llms.AIChatMessage{Content: "two"},
+		llms.HumanChatMessage{Content: "three"},
+	}, messages)
+
+	ttl, err := history.client.Do(ctx, history.client.B().Pttl().Key(history.key()).Build()).AsInt64()
+	require.NoError(t, err)
+	assert.Positive(t, ttl)
+	assert.LessOrEqual(t, ttl, time.Minute.Milliseconds())
+}
@@ -0,0 +1,125 @@
+package sqlite3
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/devmiahub/langchaingo/memory"
+)
+
+// DefaultEntityTableName sets a default table name for entities.
+const DefaultEntityTableName = "langchaingo_entities"
+
+// DefaultEntitySchema sets a default schema for entities to be run after
+// connecting.
+const DefaultEntitySchema = `CREATE TABLE IF NOT EXISTS %s (
+		session TEXT NOT NULL,
+		entity TEXT NOT NULL,
+		summary TEXT NOT NULL,
+		updated TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (session, entity)
+);`
+
+// SqliteEntityStore is an entity store keeping the summaries of entities in
+// a sqlite3 database.
+type SqliteEntityStore struct {
+	// DB is the database connection.
+	DB *sql.DB
+	// TableName is the name of the entities table.
+	TableName string
+	// Session defines a session name or id for the entities.
+	Session string
+	// Schema defines a initial schema to be run.
+	Schema []byte
+}
+
+// Statically assert that SqliteEntityStore implement the entity store interface.
+var _ memory.EntityStore = &SqliteEntityStore{}
+
+// NewSqliteEntityStore creates a new SqliteEntityStore on the database and
+// runs its schema.
+func NewSqliteEntityStore(
+	ctx context.Context,
+	db *sql.DB,
+	options ...SqliteEntityStoreOption,
+) (*SqliteEntityStore, error) {
+	s := &SqliteEntityStore{DB: db}
+
+	for _, option := range options {
+		option(s)
+	}
+
+	if s.TableName == "" {
+		s.TableName = DefaultEntityTableName
+	}
+
+	if s.Session == "" {
+		s.Session = "default"
+	}
+
+	if s.Schema == nil {
+		s.Schema = []byte(fmt.Sprintf(DefaultEntitySchema, s.TableName))
+	}
+
+	if _, err := s.DB.ExecContext(ctx, string(s.Schema)); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Get returns the summary of the entity and whether the entity is known.
+func (s *SqliteEntityStore) Get(ctx context.Context, entity string) (string, bool, error) {
+	querytpl := []string{
+		"SELECT summary FROM ",
+		" WHERE session = ? AND entity = ?;",
+	}
+	query := strings.Join(querytpl, s.TableName)
+
+	var summary string
+	err := s.DB.QueryRowContext(ctx, query, s.Session, entity).Scan(&summary)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return summary, true, nil
+}
+
+// Set sets the summary of the entity.
+func (s *SqliteEntityStore) Set(ctx context.Context, entity string, summary string) error {
+	querytpl := []string{
+		"INSERT INTO ",
+		` (session, entity, summary) VALUES (?, ?, ?)
+		ON CONFLICT (session, entity) DO UPDATE SET summary = excluded.summary, updated = CURRENT_TIMESTAMP;`,
+	}
+	query := strings.Join(querytpl, s.TableName)
+	_, err := s.DB.ExecContext(ctx, query, s.Session, entity, summary)
+	return err
+}
+
+// Delete removes the entity.
+func (s *SqliteEntityStore) Delete(ctx context.Context, entity string) error {
+	querytpl := []string{
+		"DELETE FROM ",
+		" WHERE session = ? AND entity = ?;",
+	}
+	query := strings.Join(querytpl, s.TableName)
+	_, err := s.DB.ExecContext(ctx, query, s.Session, entity)
+	return err
+}
+
+// Clear removes all entities of the session.
+func (s *SqliteEntityStore) Clear(ctx context.Context) error {
+	querytpl := []string{
+		"DELETE FROM ",
+		" WHERE session = ?;",
+	}
+	query := strings.Join(querytpl, s.TableName)
+	_, err := s.DB.ExecContext(ctx, query, s.Session)
+	return err
+}
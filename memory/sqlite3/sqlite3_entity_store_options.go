@@ -0,0 +1,29 @@
+package sqlite3
+
+// SqliteEntityStoreOption is a function for creating a new entity store
+// with other than the default values.
+type SqliteEntityStoreOption func(s *SqliteEntityStore)
+
+// WithEntityTableName is an option for NewSqliteEntityStore for
+// setting the name of the entities table.
+func WithEntityTableName(name string) SqliteEntityStoreOption {
+	return func(s *SqliteEntityStore) {
+		s.TableName = name
+	}
+}
+
+// WithEntitySession is an option for NewSqliteEntityStore for
+// setting a session name or id for the entities.
+func WithEntitySession(session string) SqliteEntityStoreOption {
+	return func(s *SqliteEntityStore) {
+		s.Session = session
+	}
+}
+
+// WithEntitySchema is an option for NewSqliteEntityStore for
+// running a schema when connected. Useful for migrations for example.
+func WithEntitySchema(schema []byte) SqliteEntityStoreOption {
+	return func(s *SqliteEntityStore) {
+		s.Schema = schema
+	}
+}
@@ -0,0 +1,54 @@
+package sqlite3_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/devmiahub/langchaingo/memory/sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSqliteEntityStore(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	s, err := sqlite3.NewSqliteEntityStore(ctx, db)
+	require.NoError(t, err)
+	other, err := sqlite3.NewSqliteEntityStore(ctx, db, sqlite3.WithEntitySession("other"))
+	require.NoError(t, err)
+
+	_, ok, err := s.Get(ctx, "Alice")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	require.NoError(t, s.Set(ctx, "Alice", "Alice lives in Paris."))
+	require.NoError(t, s.Set(ctx, "Alice", "Alice lives in Berlin."))
+	require.NoError(t, s.Set(ctx, "Bob", "Bob is a cook."))
+	require.NoError(t, other.Set(ctx, "Alice", "Alice is a pilot."))
+
+	summary, ok, err := s.Get(ctx, "Alice")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, "Alice lives in Berlin.", summary)
+
+	require.NoError(t, s.Delete(ctx, "Bob"))
+	_, ok, err = s.Get(ctx, "Bob")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	require.NoError(t, s.Clear(ctx))
+	_, ok, err = s.Get(ctx, "Alice")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	summary, ok, err = other.Get(ctx, "Alice")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, "Alice is a pilot.", summary)
+}
@@ -1,11 +1,11 @@
 // Package sqlite3 adds support for
-// chat message history using sqlite3.
+// chat message history and entity stores using sqlite3.
 package sqlite3
 
 import (
-	"bytes"
 	"context"
 	"database/sql"
+	"encoding/json"
 	"strings"
 
 	_ "github.com/mattn/go-sqlite3" // sqlite3 driver.
@@ -34,8 +34,11 @@ type SqliteChatMessageHistory struct {
 	Overwrite bool
 }
 
-// Statically assert that SqliteChatMessageHistory implement the chat message history interface.
-var _ schema.ChatMessageHistory = &SqliteChatMessageHistory{}
+// Statically assert that SqliteChatMessageHistory implement the chat message history interfaces.
+var (
+	_ schema.ChatMessageHistory    = &SqliteChatMessageHistory{}
+	_ schema.MessageContentHistory = &SqliteChatMessageHistory{}
+)
 
 // NewSqliteChatMessageHistory creates a new SqliteChatMessageHistory using chat message options.
 func NewSqliteChatMessageHistory(options ...SqliteChatMessageHistoryOption) *SqliteChatMessageHistory {
@@ -45,8 +48,8 @@ func NewSqliteChatMessageHistory(options ...SqliteChatMessageHistoryOption) *Sql
 // Messages returns all messages stored.
 func (h *SqliteChatMessageHistory) Messages(ctx context.Context) ([]llms.ChatMessage, error) {
 	querytpl := []string{
-		"SELECT content,type,created FROM ",
-		" WHERE session = ? ORDER BY created ASC LIMIT ?;",
+		"SELECT content,type,created,message FROM ",
+		" WHERE session = ? ORDER BY created ASC, id ASC LIMIT ?;",
 	}
 	query := strings.Join(querytpl, h.TableName)
 	res, err := h.DB.QueryContext(ctx, query, h.Session, h.Limit)
@@ -60,11 +63,21 @@ func (h *SqliteChatMessageHistory) Messages(ctx context.Context) ([]llms.ChatMes
 	for res.Next() {
 		var content, msgtype string
 		var created interface{}
+		var message sql.NullString
 
-		if err = res.Scan(&content, &msgtype, &created); err != nil {
+		if err = res.Scan(&content, &msgtype, &created, &message); err != nil {
 			return nil, err
 		}
 
+		if message.Valid {
+			var mc llms.MessageContent
+			if err := json.Unmarshal([]byte(message.String), &mc); err != nil {
+				return nil, err
+			}
+			msgs = append(msgs, llms.ChatMessageFromMessageContent(mc))
+			continue
+		}
+
 		switch msgtype {
 		case string(llms.ChatMessageTypeAI):
 			msgs = append(msgs, llms.AIChatMessage{Content: content})
@@ -83,29 +96,20 @@ func (h *SqliteChatMessageHistory) Messages(ctx context.Context) ([]llms.ChatMes
 	return msgs, nil
 }
 
-func (h *SqliteChatMessageHistory) addMessage(ctx context.Context, text string, role llms.ChatMessageType) error {
-	querytpl := []string{
-		"INSERT INTO ",
-		" (session, content, type) VALUES (?, ?, ?);",
-	}
-	query := strings.Join(querytpl, h.TableName)
-	_, err := h.DB.ExecContext(ctx, query, h.Session, text, role)
-	return err
-}
-
-// AddMessage adds a message to the chat message history.
+// AddMessage adds a message to the chat message history. The full content of
+// the message, such as the tool calls of an AI message, is stored.
 func (h *SqliteChatMessageHistory) AddMessage(ctx context.Context, message llms.ChatMessage) error {
-	return h.addMessage(ctx, message.GetContent(), message.GetType())
+	return h.AddMessageContent(ctx, llms.MessageContentFromChatMessage(message))
 }
 
 // AddAIMessage adds an AIMessage to the chat message history.
 func (h *SqliteChatMessageHistory) AddAIMessage(ctx context.Context, text string) error {
-	return h.addMessage(ctx, text, llms.ChatMessageTypeAI)
+	return h.AddMessage(ctx, llms.AIChatMessage{Content: text})
 }
 
 // AddUserMessage adds a user to the chat message history.
 func (h *SqliteChatMessageHistory) AddUserMessage(ctx context.Context, text string) error {
-	return h.addMessage(ctx, text, llms.ChatMessageTypeHuman)
+	return h.AddMessage(ctx, llms.HumanChatMessage{Content: text})
 }
 
 // Clear resets messages.
@@ -123,38 +127,152 @@ func (h *SqliteChatMessageHistory) Clear(ctx context.Context) error {
 	return err
 }
 
-// SetMessages resets chat history and bulk insert new messages into it.
+// SetMessages resets chat history and inserts the messages with their full
+// content into it.
 func (h *SqliteChatMessageHistory) SetMessages(ctx context.Context, messages []llms.ChatMessage) error {
+	contents := make([]llms.MessageContent, 0, len(messages))
+	for _, message := range messages {
+		contents = append(contents, llms.MessageContentFromChatMessage(message))
+	}
+	return h.SetMessageContents(ctx, contents)
+}
+
+// MessageContents returns all messages stored with their full content.
+// Messages added as chat messages are converted to message contents.
+func (h *SqliteChatMessageHistory) MessageContents(ctx context.Context) ([]llms.MessageContent, error) {
+	querytpl := []string{
+		"SELECT content,type,message FROM ",
+		" WHERE session = ? ORDER BY created ASC, id ASC LIMIT ?;",
+	}
+	query := strings.Join(querytpl, h.TableName)
+	res, err := h.DB.QueryContext(ctx, query, h.Session, h.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Close()
+
+	var msgs []llms.MessageContent
+	for res.Next() {
+		var content, msgtype string
+		var message sql.NullString
+
+		if err = res.Scan(&content, &msgtype, &message); err != nil {
+			return nil, err
+		}
+
+		if !message.Valid {
+			msgs = append(msgs, llms.TextParts(llms.ChatMessageType(msgtype), content))
+			continue
+		}
+
+		var mc llms.MessageContent
+		if err := json.Unmarshal([]byte(message.String), &mc); err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, mc)
+	}
+
+	if err := res.Err(); err != nil {
+		return nil, err
+	}
+
+	return msgs, nil
+}
+
+// AddMessageContent adds a message with its full content to the chat message
+// history.
+func (h *SqliteChatMessageHistory) AddMessageContent(ctx context.Context, message llms.MessageContent) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	querytpl := []string{
+		"INSERT INTO ",
+		" (session, content, type, message) VALUES (?, ?, ?, ?);",
+	}
+	query := strings.Join(querytpl, h.TableName)
+	content := llms.ChatMessageFromMessageContent(message).GetContent()
+	_, err = h.DB.ExecContext(ctx, query, h.Session, content, message.Role, string(data))
+	return err
+}
+
+// SetMessageContents resets chat history and inserts the messages with their
+// full content into it.
+func (h *SqliteChatMessageHistory) SetMessageContents(ctx context.Context, messages []llms.MessageContent) error {
 	if !h.Overwrite {
 		return nil
 	}
 
-	/*
-	 BEGIN TRANSACTION;
-	 DELETE FROM table WHERE session = ?;
-	 INSERT INTO table (session, content, type)
-	 VALUES (?, ?, ?), ...;
-	 COMMIT;`
-	*/
-	buf := bytes.NewBufferString("BEGIN TRANSACTION;")
-	buf.WriteString(" DELETE FROM ")
-	buf.WriteString(h.TableName)
-	buf.WriteString(" WHERE session = ?;")
-	buf.WriteString(" INSERT INTO ")
-	buf.WriteString(h.TableName)
-	buf.WriteString(" (session, content, type) VALUES ")
+	tx, err := h.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	querytpl := []string{
+		"DELETE FROM ",
+		" WHERE session = ?;",
+	}
+	if _, err := tx.ExecContext(ctx, strings.Join(querytpl, h.TableName), h.Session); err != nil {
+		return err
+	}
+
+	querytpl = []string{
+		"INSERT INTO ",
+		" (session, content, type, message) VALUES (?, ?, ?, ?);",
+	}
+	query := strings.Join(querytpl, h.TableName)
+	for _, message := range messages {
+		data, err := json.Marshal(message)
+		if err != nil {
+			return err
+		}
+		content := llms.ChatMessageFromMessageContent(message).GetContent()
+		if _, err := tx.ExecContext(ctx, query, h.Session, content, message.Role, string(data)); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
 
-	inputs := make([]string, len(messages))
-	values := []interface{}{h.Session}
+// migrate adds the message column, storing the full content of messages, to
+// tables created before it existed.
+func (h *SqliteChatMessageHistory) migrate(ctx context.Context) error {
+	res, err := h.DB.QueryContext(ctx, "SELECT name FROM pragma_table_info(?);", h.TableName)
+	if err != nil {
+		return err
+	}
+	defer res.Close()
 
-	for i, msg := range messages {
-		inputs[i] = "(?, ?, ?)"
-		values = append(values, h.Session, msg.GetContent(), string(msg.GetType()))
+	columns := 0
+	for res.Next() {
+		var name string
+		if err := res.Scan(&name); err != nil {
+			return err
+		}
+		if name == "message" {
+			return nil
+		}
+		columns++
+	}
+	if err := res.Err(); err != nil {
+		return err
 	}
+	res.Close()
 
-	buf.WriteString(strings.Join(inputs, ", "))
-	buf.WriteString("; COMMIT;")
+	// The table does not exist, as a custom schema need not create it, so
+	// there is nothing to migrate.
+	if columns == 0 {
+		return nil
+	}
 
-	_, err := h.DB.ExecContext(ctx, buf.String(), values...)
+	querytpl := []string{
+		"ALTER TABLE ",
+		" ADD COLUMN message TEXT;",
+	}
+	_, err = h.DB.ExecContext(ctx, strings.Join(querytpl, h.TableName))
 	return err
 }
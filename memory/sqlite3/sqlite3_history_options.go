@@ -21,6 +21,7 @@ const DefaultSchema = `CREATE TABLE IF NOT EXISTS %s (
 		session TEXT NOT NULL,
 		content TEXT NOT NULL,
 		type TEXT NOT NULL,
+		message TEXT,
 		created TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
 CREATE INDEX IF NOT EXISTS idx_langchaingo_id ON %s (id);
@@ -137,5 +138,9 @@ func applyChatOptions(options ...SqliteChatMessageHistoryOption) *SqliteChatMess
 		panic(err)
 	}
 
+	if err := h.migrate(h.Ctx); err != nil {
+		panic(err)
+	}
+
 	return h
 }
@@ -2,12 +2,14 @@ package sqlite3_test
 
 import (
 	"context"
+	"database/sql"
+	"path/filepath"
 	"testing"
 
-	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/require"
 	"github.com/devmiahub/langchaingo/llms"
 	"github.com/devmiahub/langchaingo/memory/sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSqliteChatMessageHistory(t *testing.T) {
@@ -53,3 +55,130 @@ func TestSqliteChatMessageHistory(t *testing.T) {
 		llms.HumanChatMessage{Content: "zoo"},
 	}, messages)
 }
+
+func agentConversation() []llms.MessageContent {
+	return []llms.MessageContent{
+		llms.TextParts(llms.ChatMessageTypeSystem, "You are helpful."),
+		{
+			Role: llms.ChatMessageTypeHuman,
+			Parts: []llms.ContentPart{
+				llms.TextContent{Text: "What is in this image?"},
+				llms.ImageURLContent{URL: "https://example.com/cat.png", Detail: "low"},
+				llms.BinaryContent{MIMEType: "image/png", Data: []byte{0x89, 0x50}},
+			},
+		},
+		{
+			Role: llms.ChatMessageTypeAI,
+			Parts: []llms.ContentPart{
+				llms.ThinkingPartWithSignature("I should look it up.", "sig"),
+				llms.ToolCall{
+					ID:           "call_1",
+					Type:         "function",
+					FunctionCall: &llms.FunctionCall{Name: "search", Arguments: `{"q":"cat"}`},
+				},
+			},
+		},
+		{
+			Role: llms.ChatMessageTypeTool,
+			Parts: []llms.ContentPart{
+				llms.ToolCallResponse{ToolCallID: "call_1", Name: "search", Content: "a cat"},
+			},
+		},
+		{
+			Role: llms.ChatMessageTypeAI,
+			Parts: []llms.ContentPart{
+				llms.WithCacheControl(llms.TextContent{Text: "It is a cat."}, &llms.CacheControl{Type: "ephemeral"}),
+			},
+		},
+	}
+}
+
+func TestSqliteMessageContentHistory(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "history.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	h := sqlite3.NewSqliteChatMessageHistory(sqlite3.WithDB(db), sqlite3.WithOverwrite())
+
+	conversation := agentConversation()
+	for _, message := range conversation[:2] {
+		require.NoError(t, h.AddMessageContent(ctx, message))
+	}
+	messages, err := h.MessageContents(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, conversation[:2], messages)
+
+	require.NoError(t, h.SetMessageContents(ctx, conversation))
+	messages, err = h.MessageContents(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, conversation, messages)
+
+	chatMessages, err := h.Messages(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, llms.ToolChatMessage{ID: "call_1", Content: "a cat"}, chatMessages[3])
+}
+
+func TestSqliteChatMessageHistoryToolCalls(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	h := sqlite3.NewSqliteChatMessageHistory(sqlite3.WithContext(ctx), sqlite3.WithOverwrite())
+
+	conversation := []llms.ChatMessage{
+		llms.HumanChatMessage{Content: "What is the weather in Paris?"},
+		llms.AIChatMessage{
+			ThinkingContent:  "I should check the weather.",
+			ThoughtSignature: "sig",
+			ToolCalls: []llms.ToolCall{{
+				ID:           "call_1",
+				Type:         "function",
+				FunctionCall: &llms.FunctionCall{Name: "weather", Arguments: `{"city":"Paris"}`},
+			}},
+		},
+		llms.ToolChatMessage{ID: "call_1", Content: "sunny"},
+	}
+	for _, message := range conversation {
+		require.NoError(t, h.AddMessage(ctx, message))
+	}
+	messages, err := h.Messages(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, conversation, messages)
+
+	require.NoError(t, h.SetMessages(ctx, conversation[1:]))
+	messages, err = h.Messages(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, conversation[1:], messages)
+}
+
+func TestSqliteChatMessageHistoryMigration(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "history.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	_, err = db.ExecContext(ctx, `CREATE TABLE langchaingo_messages (
+		id INTEGER PRIMARY KEY,
+		name TEXT,
+		session TEXT NOT NULL,
+		content TEXT NOT NULL,
+		type TEXT NOT NULL,
+		created TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	);
+	INSERT INTO langchaingo_messages (session, content, type) VALUES ('default', 'hi', 'human');`)
+	require.NoError(t, err)
+
+	h := sqlite3.NewSqliteChatMessageHistory(sqlite3.WithDB(db))
+	require.NoError(t, h.AddMessageContent(ctx, agentConversation()[3]))
+
+	messages, err := h.MessageContents(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []llms.MessageContent{
+		llms.TextParts(llms.ChatMessageTypeHuman, "hi"),
+		agentConversation()[3],
+	}, messages)
+}
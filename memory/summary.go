@@ -0,0 +1,285 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/devmiahub/langchaingo/llms"
+	"github.com/devmiahub/langchaingo/prompts"
+	"github.com/devmiahub/langchaingo/schema"
+)
+
+//nolint:lll
+const _summaryTemplate = `Progressively summarize the lines of conversation provided, adding onto the previous summary returning a new summary.
+
+EXAMPLE
+Current summary:
+The human asks what the AI thinks of artificial intelligence. The AI thinks artificial intelligence is a force for good.
+
+New lines of conversation:
+Human: Why do you think artificial intelligence is a force for good?
+AI: Because artificial intelligence will help humans reach their full potential.
+
+New summary:
+The human asks what the AI thinks of artificial intelligence. The AI thinks artificial intelligence is a force for good because it will help humans reach their full potential.
+END OF EXAMPLE
+
+Current summary:
+{{.summary}}
+
+New lines of conversation:
+{{.new_lines}}
+
+New summary:`
+
+// _summaryPrefix is the prefix of the system message holding the summary in
+// the chat history, which tells it apart from other system messages.
+const _summaryPrefix = "Summary of the conversation so far: "
+
+// ErrSummaryNotPersisted is returned when saving the context of a summary
+// memory if the chat history did not keep the summary, as histories that
+// cannot be overwritten do not.
+var ErrSummaryNotPersisted = errors.New("summary not persisted by the chat history")
+
+// ConversationSummary is a memory that keeps a rolling summary of the whole
+// conversation, generated by an LLM, instead of the messages themselves.
+//
+// The summary is persisted in the chat history as a system message following
+// the messages it summarizes, so it survives restarts. The messages themselves
+// are left in the history. This requires a chat history that can be
+// overwritten and stores system messages, preferably one that also implements
+// schema.MessageContentHistory, through which the messages are then read and
+// written. Saving the context fails with ErrSummaryNotPersisted otherwise.
+type ConversationSummary struct {
+	ConversationBuffer
+	LLM llms.Model
+	// Prompt is the prompt used to summarize. It gets the current summary as
+	// "summary" and the lines of conversation to add as "new_lines".
+	Prompt prompts.PromptTemplate
+}
+
+// Statically assert that ConversationSummary implement the memory interface.
+var _ schema.Memory = &ConversationSummary{}
+
+// NewConversationSummary is a function for creating a new summary memory.
+func NewConversationSummary(llm llms.Model, options ...ConversationBufferOption) *ConversationSummary {
+	return &ConversationSummary{
+		ConversationBuffer: *applyBufferOptions(options...),
+		LLM:                llm,
+		Prompt:             defaultSummaryPrompt(),
+	}
+}
+
+// LoadMemoryVariables returns the system messages of the history, the summary
+// and the messages not summarized yet, as messages if ReturnMessages is set.
+func (s *ConversationSummary) LoadMemoryVariables(
+	ctx context.Context, _ map[string]any,
+) (map[string]any, error) {
+	messages, err := historyMessages(ctx, s.ChatHistory)
+	if err != nil {
+		return nil, err
+	}
+	messages = unsummarizedMessages(messages)
+
+	if s.ReturnMessages {
+		return map[string]any{s.MemoryKey: messages}, nil
+	}
+
+	bufferString, err := llms.GetBufferString(messages, s.HumanPrefix, s.AIPrefix)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{s.MemoryKey: bufferString}, nil
+}
+
+// SaveContext saves the exchange and folds it into the summary.
+func (s *ConversationSummary) SaveContext(
+	ctx context.Context, inputValues map[string]any, outputValues map[string]any,
+) error {
+	err := s.ConversationBuffer.SaveContext(ctx, inputValues, outputValues)
+	if err != nil {
+		return err
+	}
+
+	messages, err := historyMessages(ctx, s.ChatHistory)
+	if err != nil {
+		return err
+	}
+
+	summary, newLines := splitSummary(messages)
+	summary, err = s.predictNewSummary(ctx, summary, newLines)
+	if err != nil {
+		return err
+	}
+
+	return setHistoryMessages(ctx, s.ChatHistory, moveSummary(messages, summary, len(newLines)))
+}
+
+// Summary returns the current summary of the conversation.
+func (s *ConversationSummary) Summary(ctx context.Context) (string, error) {
+	messages, err := historyMessages(ctx, s.ChatHistory)
+	if err != nil {
+		return "", err
+	}
+
+	summary, _ := splitSummary(messages)
+	return summary, nil
+}
+
+func (s *ConversationSummary) predictNewSummary(
+	ctx context.Context, summary string, newLines []llms.ChatMessage,
+) (string, error) {
+	return predictNewSummary(ctx, s.LLM, s.Prompt, summary, newLines, s.HumanPrefix, s.AIPrefix)
+}
+
+func defaultSummaryPrompt() prompts.PromptTemplate {
+	return prompts.NewPromptTemplate(_summaryTemplate, []string{"summary", "new_lines"})
+}
+
+// historyMessages returns the messages of a chat history, read with their full
+// content if the history stores it.
+func historyMessages(ctx context.Context, history schema.ChatMessageHistory) ([]llms.ChatMessage, error) {
+	contentHistory, ok := history.(schema.MessageContentHistory)
+	if !ok {
+		return history.Messages(ctx)
+	}
+
+	contents, err := contentHistory.MessageContents(ctx)
+	if err != nil {
+		return nil, err
+	}
+	messages := make([]llms.ChatMessage, 0, len(contents))
+	for _, content := range contents {
+		messages = append(messages, llms.ChatMessageFromMessageContent(content))
+	}
+	return messages, nil
+}
+
+// setHistoryMessages replaces the messages of a chat history with messages
+// holding a summary, written with their full content if the history stores
+// it. It fails with ErrSummaryNotPersisted if the history did not keep the
+// summary where it was put.
+func setHistoryMessages(ctx context.Context, history schema.ChatMessageHistory, messages []llms.ChatMessage) error {
+	var err error
+	if contentHistory, ok := history.(schema.MessageContentHistory); ok {
+		contents := make([]llms.MessageContent, 0, len(messages))
+		for _, message := range messages {
+			contents = append(contents, llms.MessageContentFromChatMessage(message))
+		}
+		err = contentHistory.SetMessageContents(ctx, contents)
+	} else {
+		err = history.SetMessages(ctx, messages)
+	}
+	if err != nil {
+		return err
+	}
+
+	stored, err := historyMessages(ctx, history)
+	if err != nil {
+		return err
+	}
+	index, storedIndex := summaryIndex(messages), summaryIndex(stored)
+	if storedIndex < 0 ||
+		stored[storedIndex].GetContent() != messages[index].GetContent() ||
+		len(stored)-storedIndex != len(messages)-index {
+		return ErrSummaryNotPersisted
+	}
+	return nil
+}
+
+func isSystemMessage(message llms.ChatMessage) bool {
+	return message.GetType() == llms.ChatMessageTypeSystem
+}
+
+// summaryIndex returns the index of the message holding the summary in the
+// messages of a chat history, or -1 if there is none.
+func summaryIndex(messages []llms.ChatMessage) int {
+	for i := len(messages) - 1; i >= 0; i-- {
+		if isSystemMessage(messages[i]) && strings.HasPrefix(messages[i].GetContent(), _summaryPrefix) {
+			return i
+		}
+	}
+	return -1
+}
+
+// splitSummary returns the summary of the messages of a chat history and the
+// messages of the conversation not summarized yet, following the summary.
+// Other system messages are not part of the conversation.
+func splitSummary(messages []llms.ChatMessage) (string, []llms.ChatMessage) {
+	summary := ""
+	index := summaryIndex(messages)
+	if index >= 0 {
+		summary = strings.TrimPrefix(messages[index].GetContent(), _summaryPrefix)
+	}
+
+	var newLines []llms.ChatMessage
+	for _, message := range messages[index+1:] {
+		if !isSystemMessage(message) {
+			newLines = append(newLines, message)
+		}
+	}
+	return summary, newLines
+}
+
+// unsummarizedMessages returns the messages of a chat history without the
+// messages of the conversation preceding the summary.
+func unsummarizedMessages(messages []llms.ChatMessage) []llms.ChatMessage {
+	index := summaryIndex(messages)
+	result := make([]llms.ChatMessage, 0, len(messages))
+	for i, message := range messages {
+		if i >= index || isSystemMessage(message) {
+			result = append(result, message)
+		}
+	}
+	return result
+}
+
+// moveSummary returns the messages of a chat history with the summary replaced
+// by the new one, moved past the next summarized messages of the conversation.
+func moveSummary(messages []llms.ChatMessage, summary string, summarized int) []llms.ChatMessage {
+	index := summaryIndex(messages)
+	end := index + 1
+	for count := 0; count < summarized; end++ {
+		if !isSystemMessage(messages[end]) {
+			count++
+		}
+	}
+
+	result := make([]llms.ChatMessage, 0, len(messages)+1)
+	for i, message := range messages[:end] {
+		if i != index {
+			result = append(result, message)
+		}
+	}
+	result = append(result, llms.SystemChatMessage{Content: _summaryPrefix + summary})
+	return append(result, messages[end:]...)
+}
+
+func predictNewSummary(
+	ctx context.Context,
+	llm llms.Model,
+	prompt prompts.PromptTemplate,
+	summary string,
+	newLines []llms.ChatMessage,
+	humanPrefix, aiPrefix string,
+) (string, error) {
+	if len(newLines) == 0 {
+		return summary, nil
+	}
+
+	bufferString, err := llms.GetBufferString(newLines, humanPrefix, aiPrefix)
+	if err != nil {
+		return "", err
+	}
+
+	promptValue, err := prompt.Format(map[string]any{
+		"summary":   summary,
+		"new_lines": bufferString,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return llms.GenerateFromSinglePrompt(ctx, llm, promptValue)
+}
@@ -0,0 +1,83 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/devmiahub/langchaingo/llms"
+	"github.com/devmiahub/langchaingo/schema"
+)
+
+// ConversationSummaryBuffer is a memory that keeps the recent messages of the
+// conversation verbatim, up to a token limit, and a summary of the older ones
+// generated by an LLM.
+//
+// The summary is persisted in the chat history like for ConversationSummary,
+// which has the same requirements on the chat history.
+type ConversationSummaryBuffer struct {
+	ConversationSummary
+	MaxTokenLimit int
+}
+
+// Statically assert that ConversationSummaryBuffer implement the memory interface.
+var _ schema.Memory = &ConversationSummaryBuffer{}
+
+// NewConversationSummaryBuffer is a function for creating a new summary
+// buffer memory.
+func NewConversationSummaryBuffer(
+	llm llms.Model,
+	maxTokenLimit int,
+	options ...ConversationBufferOption,
+) *ConversationSummaryBuffer {
+	return &ConversationSummaryBuffer{
+		ConversationSummary: *NewConversationSummary(llm, options...),
+		MaxTokenLimit:       maxTokenLimit,
+	}
+}
+
+// SaveContext saves the exchange and, if the messages not summarized exceed
+// the token limit, folds the oldest of them into the summary.
+func (sb *ConversationSummaryBuffer) SaveContext(
+	ctx context.Context, inputValues map[string]any, outputValues map[string]any,
+) error {
+	err := sb.ConversationBuffer.SaveContext(ctx, inputValues, outputValues)
+	if err != nil {
+		return err
+	}
+
+	messages, err := historyMessages(ctx, sb.ChatHistory)
+	if err != nil {
+		return err
+	}
+
+	summary, buffer := splitSummary(messages)
+	pruned := 0
+	for pruned < len(buffer) {
+		numTokens, err := sb.getNumTokensFromMessages(buffer[pruned:])
+		if err != nil {
+			return err
+		}
+		if numTokens <= sb.MaxTokenLimit {
+			break
+		}
+		pruned++
+	}
+	if pruned == 0 {
+		return nil
+	}
+
+	summary, err = sb.predictNewSummary(ctx, summary, buffer[:pruned])
+	if err != nil {
+		return err
+	}
+
+	return setHistoryMessages(ctx, sb.ChatHistory, moveSummary(messages, summary, pruned))
+}
+
+func (sb *ConversationSummaryBuffer) getNumTokensFromMessages(messages []llms.ChatMessage) (int, error) {
+	bufferString, err := llms.GetBufferString(messages, sb.HumanPrefix, sb.AIPrefix)
+	if err != nil {
+		return 0, err
+	}
+
+	return llms.CountTokens("", bufferString), nil
+}
@@ -0,0 +1,227 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/devmiahub/langchaingo/llms"
+	"github.com/stretchr/testify/require"
+)
+
+// summarizer is a model returning numbered summaries and recording the
+// prompts it gets.
+type summarizer struct {
+	prompts []string
+}
+
+func (s *summarizer) GenerateContent(
+	_ context.Context, messages []llms.MessageContent, _ ...llms.CallOption,
+) (*llms.ContentResponse, error) {
+	s.prompts = append(s.prompts, messages[0].Parts[0].(llms.TextContent).Text)
+	return &llms.ContentResponse{
+		Choices: []*llms.ContentChoice{{Content: fmt.Sprintf("summary %d", len(s.prompts))}},
+	}, nil
+}
+
+func (s *summarizer) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, s, prompt, options...)
+}
+
+func TestConversationSummary(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	llm := &summarizer{}
+	history := NewChatMessageHistory()
+	m := NewConversationSummary(llm, WithChatHistory(history))
+
+	err := m.SaveContext(ctx, map[string]any{"input": "hi"}, map[string]any{"output": "hello"})
+	require.NoError(t, err)
+	err = m.SaveContext(ctx, map[string]any{"input": "how are you?"}, map[string]any{"output": "fine"})
+	require.NoError(t, err)
+
+	require.Len(t, llm.prompts, 2)
+	require.Contains(t, llm.prompts[1], "Current summary:\nsummary 1\n\nNew lines of conversation:\nHuman: how are you?\nAI: fine")
+
+	summary, err := m.Summary(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "summary 2", summary)
+
+	vars, err := m.LoadMemoryVariables(ctx, map[string]any{})
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"history": "system: Summary of the conversation so far: summary 2"}, vars)
+
+	// The summary is persisted alongside the messages, so a new memory picks
+	// it up.
+	messages, err := history.Messages(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []llms.ChatMessage{
+		llms.HumanChatMessage{Content: "hi"},
+		llms.AIChatMessage{Content: "hello"},
+		llms.HumanChatMessage{Content: "how are you?"},
+		llms.AIChatMessage{Content: "fine"},
+		llms.SystemChatMessage{Content: "Summary of the conversation so far: summary 2"},
+	}, messages)
+
+	m = NewConversationSummary(llm, WithChatHistory(history), WithReturnMessages(true))
+	vars, err = m.LoadMemoryVariables(ctx, map[string]any{})
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{
+		"history": []llms.ChatMessage{llms.SystemChatMessage{Content: "Summary of the conversation so far: summary 2"}},
+	}, vars)
+}
+
+func TestConversationSummarySystemMessage(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	llm := &summarizer{}
+	history := NewChatMessageHistory(WithPreviousMessages([]llms.ChatMessage{
+		llms.SystemChatMessage{Content: "You are a pirate."},
+	}))
+	m := NewConversationSummary(llm, WithChatHistory(history), WithReturnMessages(true))
+
+	err := m.SaveContext(ctx, map[string]any{"input": "hi"}, map[string]any{"output": "ahoy"})
+	require.NoError(t, err)
+	err = m.SaveContext(ctx, map[string]any{"input": "where to?"}, map[string]any{"output": "the sea"})
+	require.NoError(t, err)
+
+	// The system message is neither summarized nor replaced by the summary.
+	require.NotContains(t, llm.prompts[0], "pirate")
+	require.Contains(t, llm.prompts[1], "Current summary:\nsummary 1\n\nNew lines of conversation:\nHuman: where to?")
+
+	messages, err := history.Messages(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []llms.ChatMessage{
+		llms.SystemChatMessage{Content: "You are a pirate."},
+		llms.HumanChatMessage{Content: "hi"},
+		llms.AIChatMessage{Content: "ahoy"},
+		llms.HumanChatMessage{Content: "where to?"},
+		llms.AIChatMessage{Content: "the sea"},
+		llms.SystemChatMessage{Content: "Summary of the conversation so far: summary 2"},
+	}, messages)
+
+	vars, err := m.LoadMemoryVariables(ctx, map[string]any{})
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"history": []llms.ChatMessage{
+		llms.SystemChatMessage{Content: "You are a pirate."},
+		llms.SystemChatMessage{Content: "Summary of the conversation so far: summary 2"},
+	}}, vars)
+}
+
+func TestConversationSummaryBuffer(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	llm := &summarizer{}
+	m := NewConversationSummaryBuffer(llm, 1000, WithReturnMessages(true))
+
+	err := m.SaveContext(ctx, map[string]any{"input": "hi"}, map[string]any{"output": "hello"})
+	require.NoError(t, err)
+	require.Empty(t, llm.prompts)
+
+	vars, err := m.LoadMemoryVariables(ctx, map[string]any{})
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"history": []llms.ChatMessage{
+		llms.HumanChatMessage{Content: "hi"},
+		llms.AIChatMessage{Content: "hello"},
+	}}, vars)
+
+	m.MaxTokenLimit = 0
+	err = m.SaveContext(ctx, map[string]any{"input": "how are you?"}, map[string]any{"output": "fine"})
+	require.NoError(t, err)
+	require.Len(t, llm.prompts, 1)
+	require.Contains(t, llm.prompts[0], "Human: hi\nAI: hello\nHuman: how are you?\nAI: fine")
+
+	vars, err = m.LoadMemoryVariables(ctx, map[string]any{})
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"history": []llms.ChatMessage{
+		llms.SystemChatMessage{Content: "Summary of the conversation so far: summary 1"},
+	}}, vars)
+
+	m.MaxTokenLimit = 20
+	err = m.SaveContext(ctx, map[string]any{"input": "and you?"}, map[string]any{"output": "good"})
+	require.NoError(t, err)
+	require.Len(t, llm.prompts, 1)
+
+	// The messages stay in the history, the summary following the ones it
+	// summarizes.
+	messages, err := m.ChatHistory.Messages(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []llms.ChatMessage{
+		llms.HumanChatMessage{Content: "hi"},
+		llms.AIChatMessage{Content: "hello"},
+		llms.HumanChatMessage{Content: "how are you?"},
+		llms.AIChatMessage{Content: "fine"},
+		llms.SystemChatMessage{Content: "Summary of the conversation so far: summary 1"},
+		llms.HumanChatMessage{Content: "and you?"},
+		llms.AIChatMessage{Content: "good"},
+	}, messages)
+}
+
+// fixedHistory is a chat history that cannot be overwritten.
+type fixedHistory struct {
+	*ChatMessageHistory
+}
+
+func (fixedHistory) SetMessages(context.Context, []llms.ChatMessage) error { return nil }
+
+func TestConversationSummaryNotPersisted(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	m := NewConversationSummary(&summarizer{}, WithChatHistory(fixedHistory{NewChatMessageHistory()}))
+	err := m.SaveContext(ctx, map[string]any{"input": "hi"}, map[string]any{"output": "hello"})
+	require.ErrorIs(t, err, ErrSummaryNotPersisted)
+}
+
+var errLossy = errors.New("lossy chat messages")
+
+// contentHistory is a chat history storing the full content of messages,
+// whose chat messages must not be used.
+type contentHistory struct {
+	*MessageContentHistory
+}
+
+func (h contentHistory) AddMessage(ctx context.Context, message llms.ChatMessage) error {
+	return h.AddMessageContent(ctx, llms.MessageContentFromChatMessage(message))
+}
+
+func (h contentHistory) AddUserMessage(ctx context.Context, text string) error {
+	return h.AddMessage(ctx, llms.HumanChatMessage{Content: text})
+}
+
+func (h contentHistory) AddAIMessage(ctx context.Context, text string) error {
+	return h.AddMessage(ctx, llms.AIChatMessage{Content: text})
+}
+
+func (contentHistory) Messages(context.Context) ([]llms.ChatMessage, error) { return nil, errLossy }
+
+func (contentHistory) SetMessages(context.Context, []llms.ChatMessage) error { return errLossy }
+
+func TestConversationSummaryMessageContentHistory(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	history := contentHistory{NewMessageContentHistory()}
+	m := NewConversationSummaryBuffer(&summarizer{}, 0, WithChatHistory(history), WithReturnMessages(true))
+
+	err := m.SaveContext(ctx, map[string]any{"input": "hi"}, map[string]any{"output": "hello"})
+	require.NoError(t, err)
+
+	contents, err := history.MessageContents(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []llms.MessageContent{
+		llms.TextParts(llms.ChatMessageTypeHuman, "hi"),
+		llms.TextParts(llms.ChatMessageTypeAI, "hello"),
+		llms.TextParts(llms.ChatMessageTypeSystem, "Summary of the conversation so far: summary 1"),
+	}, contents)
+
+	vars, err := m.LoadMemoryVariables(ctx, map[string]any{})
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"history": []llms.ChatMessage{
+		llms.SystemChatMessage{Content: "Summary of the conversation so far: summary 1"},
+	}}, vars)
+}
@@ -0,0 +1,205 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/devmiahub/langchaingo/schema"
+	"github.com/devmiahub/langchaingo/vectorstores"
+)
+
+// SavedAtMetadataKey is the metadata key under which VectorStoreRetriever
+// stores the time an exchange was saved, formatted as RFC 3339.
+const SavedAtMetadataKey = "saved_at"
+
+const _defaultNumDocuments = 4
+
+// VectorStoreRetriever is a long-term memory that saves each exchange as a
+// document in a vector store and, instead of replaying the whole history,
+// loads the past exchanges most relevant to the current input.
+//
+// With a recency weight, the similarity of an exchange is combined with how
+// recently it was saved, so newer exchanges win over older ones of similar
+// relevance.
+type VectorStoreRetriever struct {
+	VectorStore vectorstores.VectorStore
+
+	// NumDocuments is the number of exchanges loaded.
+	NumDocuments int
+	// FetchK is the number of exchanges fetched from the vector store before
+	// the recency weight is applied. Defaults to four times NumDocuments.
+	FetchK int
+	// RecencyWeight is the weight of the recency score added to the
+	// similarity score of an exchange. Zero disables recency weighting.
+	RecencyWeight float64
+	// DecayRate is the rate, per hour, at which the recency score of an
+	// exchange decays from 1 towards 0.
+	DecayRate float64
+	// SearchOptions are passed to the vector store when searching, for
+	// example to filter exchanges by metadata.
+	SearchOptions []vectorstores.Option
+
+	ReturnDocs  bool
+	InputKey    string
+	OutputKey   string
+	HumanPrefix string
+	AIPrefix    string
+	MemoryKey   string
+
+	now func() time.Time
+}
+
+// Statically assert that VectorStoreRetriever implement the memory interface.
+var _ schema.Memory = &VectorStoreRetriever{}
+
+// NewVectorStoreRetriever is a function for creating a new vector store
+// memory.
+func NewVectorStoreRetriever(
+	vectorStore vectorstores.VectorStore,
+	options ...VectorStoreRetrieverOption,
+) *VectorStoreRetriever {
+	m := &VectorStoreRetriever{
+		VectorStore:  vectorStore,
+		NumDocuments: _defaultNumDocuments,
+		DecayRate:    0.01, //nolint:mnd
+		HumanPrefix:  "Human",
+		AIPrefix:     "AI",
+		MemoryKey:    "history",
+		now:          time.Now,
+	}
+
+	for _, opt := range options {
+		opt(m)
+	}
+
+	return m
+}
+
+// GetMemoryKey getter for memory key.
+func (m *VectorStoreRetriever) GetMemoryKey(context.Context) string {
+	return m.MemoryKey
+}
+
+// MemoryVariables gets the input key the memory class will load dynamically.
+func (m *VectorStoreRetriever) MemoryVariables(context.Context) []string {
+	return []string{m.MemoryKey}
+}
+
+// LoadMemoryVariables returns the past exchanges most relevant to the input.
+// If ReturnDocs is set the output is a slice of schema.Document, otherwise
+// the contents of the documents joined by new lines.
+func (m *VectorStoreRetriever) LoadMemoryVariables(
+	ctx context.Context, inputs map[string]any,
+) (map[string]any, error) {
+	var docs []schema.Document
+	if len(inputs) > 0 {
+		query, err := GetInputValue(inputs, m.InputKey)
+		if err != nil {
+			return nil, err
+		}
+
+		docs, err = m.relevantDocuments(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if m.ReturnDocs {
+		return map[string]any{m.MemoryKey: docs}, nil
+	}
+
+	contents := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		contents = append(contents, doc.PageContent)
+	}
+	return map[string]any{m.MemoryKey: strings.Join(contents, "\n")}, nil
+}
+
+// SaveContext saves the input and output of the exchange as a document.
+func (m *VectorStoreRetriever) SaveContext(
+	ctx context.Context,
+	inputValues map[string]any,
+	outputValues map[string]any,
+) error {
+	input, err := GetInputValue(inputValues, m.InputKey)
+	if err != nil {
+		return err
+	}
+	output, err := GetInputValue(outputValues, m.OutputKey)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.VectorStore.AddDocuments(ctx, []schema.Document{{
+		PageContent: fmt.Sprintf("%s: %s\n%s: %s", m.HumanPrefix, input, m.AIPrefix, output),
+		Metadata: map[string]any{
+			SavedAtMetadataKey: m.now().UTC().Format(time.RFC3339Nano),
+		},
+	}})
+	return err
+}
+
+// Clear does nothing, since vector stores don't support removing documents.
+// Use a new name space or collection to start over.
+func (m *VectorStoreRetriever) Clear(context.Context) error {
+	return nil
+}
+
+func (m *VectorStoreRetriever) relevantDocuments(ctx context.Context, query string) ([]schema.Document, error) {
+	if m.RecencyWeight == 0 {
+		return m.VectorStore.SimilaritySearch(ctx, query, m.NumDocuments, m.SearchOptions...)
+	}
+
+	fetchK := m.FetchK
+	if fetchK < m.NumDocuments {
+		fetchK = 4 * m.NumDocuments //nolint:mnd
+	}
+	docs, err := m.VectorStore.SimilaritySearch(ctx, query, fetchK, m.SearchOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	now := m.now()
+	scores := make([]float64, len(docs))
+	for i, doc := range docs {
+		scores[i] = float64(doc.Score) + m.RecencyWeight*m.recencyScore(doc, now)
+	}
+	sort.Stable(byScore{docs: docs, scores: scores})
+
+	if len(docs) > m.NumDocuments {
+		docs = docs[:m.NumDocuments]
+	}
+	return docs, nil
+}
+
+// recencyScore returns a score decaying from 1, for exchanges saved now,
+// towards 0 with the hours passed since the exchange was saved.
+func (m *VectorStoreRetriever) recencyScore(doc schema.Document, now time.Time) float64 {
+	savedAt, ok := doc.Metadata[SavedAtMetadataKey].(string)
+	if !ok {
+		return 0
+	}
+	t, err := time.Parse(time.RFC3339Nano, savedAt)
+	if err != nil {
+		return 0
+	}
+
+	hoursPassed := math.Max(now.Sub(t).Hours(), 0)
+	return math.Pow(1-m.DecayRate, hoursPassed)
+}
+
+type byScore struct {
+	docs   []schema.Document
+	scores []float64
+}
+
+func (s byScore) Len() int           { return len(s.docs) }
+func (s byScore) Less(i, j int) bool { return s.scores[i] > s.scores[j] }
+func (s byScore) Swap(i, j int) {
+	s.docs[i], s.docs[j] = s.docs[j], s.docs[i]
+	s.scores[i], s.scores[j] = s.scores[j], s.scores[i]
+}
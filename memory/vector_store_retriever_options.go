@@ -0,0 +1,70 @@
+package memory
+
+import "github.com/devmiahub/langchaingo/vectorstores"
+
+// VectorStoreRetrieverOption is a function for creating a new vector store
+// memory with other than the default values.
+type VectorStoreRetrieverOption func(m *VectorStoreRetriever)
+
+// WithNumDocuments is an option for specifying the number of past exchanges
+// loaded.
+func WithNumDocuments(numDocuments int) VectorStoreRetrieverOption {
+	return func(m *VectorStoreRetriever) {
+		m.NumDocuments = numDocuments
+	}
+}
+
+// WithRecencyWeight is an option for weighting the relevance of past exchanges
+// by how recently they were saved. The recency score of an exchange decays by
+// decayRate per hour.
+func WithRecencyWeight(weight, decayRate float64) VectorStoreRetrieverOption {
+	return func(m *VectorStoreRetriever) {
+		m.RecencyWeight = weight
+		m.DecayRate = decayRate
+	}
+}
+
+// WithFetchK is an option for specifying the number of exchanges fetched
+// before the recency weight is applied.
+func WithFetchK(fetchK int) VectorStoreRetrieverOption {
+	return func(m *VectorStoreRetriever) {
+		m.FetchK = fetchK
+	}
+}
+
+// WithSearchOptions is an option for specifying the options passed to the
+// vector store when searching.
+func WithSearchOptions(options ...vectorstores.Option) VectorStoreRetrieverOption {
+	return func(m *VectorStoreRetriever) {
+		m.SearchOptions = options
+	}
+}
+
+// WithReturnDocs is an option for specifying that documents are returned
+// instead of a string.
+func WithReturnDocs(returnDocs bool) VectorStoreRetrieverOption {
+	return func(m *VectorStoreRetriever) {
+		m.ReturnDocs = returnDocs
+	}
+}
+
+// WithVectorStoreInputKey is an option for specifying the input key.
+func WithVectorStoreInputKey(inputKey string) VectorStoreRetrieverOption {
+	return func(m *VectorStoreRetriever) {
+		m.InputKey = inputKey
+	}
+}
+
+// WithVectorStoreOutputKey is an option for specifying the output key.
+func WithVectorStoreOutputKey(outputKey string) VectorStoreRetrieverOption {
+	return func(m *VectorStoreRetriever) {
+		m.OutputKey = outputKey
+	}
+}
+
+// WithVectorStoreMemoryKey is an option for specifying the memory key.
+func WithVectorStoreMemoryKey(memoryKey string) VectorStoreRetrieverOption {
+	return func(m *VectorStoreRetriever) {
+		m.MemoryKey = memoryKey
+	}
+}
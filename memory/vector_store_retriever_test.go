@@ -0,0 +1,95 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/devmiahub/langchaingo/schema"
+	"github.com/devmiahub/langchaingo/vectorstores"
+	"github.com/stretchr/testify/require"
+)
+
+// wordStore is a vector store scoring documents by the fraction of the words
+// of the query they contain.
+type wordStore struct {
+	docs []schema.Document
+}
+
+func (s *wordStore) AddDocuments(_ context.Context, docs []schema.Document, _ ...vectorstores.Option) ([]string, error) {
+	s.docs = append(s.docs, docs...)
+	return nil, nil
+}
+
+func (s *wordStore) SimilaritySearch(
+	_ context.Context, query string, numDocuments int, _ ...vectorstores.Option,
+) ([]schema.Document, error) {
+	words := strings.Fields(strings.ToLower(query))
+	docs := make([]schema.Document, 0, len(s.docs))
+	for _, doc := range s.docs {
+		matches := 0
+		for _, word := range words {
+			if strings.Contains(strings.ToLower(doc.PageContent), word) {
+				matches++
+			}
+		}
+		doc.Score = float32(matches) / float32(len(words))
+		docs = append(docs, doc)
+	}
+	sort.SliceStable(docs, func(i, j int) bool { return docs[i].Score > docs[j].Score })
+	if len(docs) > numDocuments {
+		docs = docs[:numDocuments]
+	}
+	return docs, nil
+}
+
+func TestVectorStoreRetriever(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	m := NewVectorStoreRetriever(&wordStore{}, WithNumDocuments(1))
+	err := m.SaveContext(ctx, map[string]any{"input": "my favorite color is blue"}, map[string]any{"output": "noted"})
+	require.NoError(t, err)
+	err = m.SaveContext(ctx, map[string]any{"input": "I live in Paris"}, map[string]any{"output": "nice"})
+	require.NoError(t, err)
+
+	vars, err := m.LoadMemoryVariables(ctx, map[string]any{"input": "what is my favorite color?"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"history": "Human: my favorite color is blue\nAI: noted"}, vars)
+
+	vars, err = m.LoadMemoryVariables(ctx, map[string]any{})
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"history": ""}, vars)
+}
+
+func TestVectorStoreRetrieverRecencyWeight(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	m := NewVectorStoreRetriever(&wordStore{}, WithNumDocuments(1), WithReturnDocs(true))
+	m.now = func() time.Time { return now }
+
+	err := m.SaveContext(ctx, map[string]any{"input": "I live in Paris"}, map[string]any{"output": "nice"})
+	require.NoError(t, err)
+	now = now.Add(30 * 24 * time.Hour)
+	err = m.SaveContext(ctx, map[string]any{"input": "I moved, I live in Berlin"}, map[string]any{"output": "ok"})
+	require.NoError(t, err)
+
+	query := map[string]any{"input": "where do I live"}
+
+	// Without recency weighting both exchanges are equally relevant and the
+	// first one wins.
+	vars, err := m.LoadMemoryVariables(ctx, query)
+	require.NoError(t, err)
+	require.Contains(t, vars["history"].([]schema.Document)[0].PageContent, "Paris")
+
+	WithRecencyWeight(1, 0.01)(m)
+	vars, err = m.LoadMemoryVariables(ctx, query)
+	require.NoError(t, err)
+	docs := vars["history"].([]schema.Document)
+	require.Len(t, docs, 1)
+	require.Contains(t, docs[0].PageContent, "Berlin")
+}
@@ -0,0 +1,72 @@
+package outputparser
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/devmiahub/langchaingo/internal/codeblock"
+	"github.com/devmiahub/langchaingo/llms"
+	"github.com/devmiahub/langchaingo/schema"
+)
+
+// Datetime is an output parser used to parse the output of an LLM as a time
+// in a given layout.
+type Datetime struct {
+	// Layout is the layout of the time, as in time.Parse.
+	Layout string
+	// Location is the location of times without time zone. Defaults to UTC.
+	Location *time.Location
+}
+
+// NewDatetime creates a new Datetime parser for times in RFC 3339 format.
+func NewDatetime() Datetime {
+	return Datetime{Layout: time.RFC3339}
+}
+
+// Statically assert that Datetime implements the OutputParser interface.
+var _ schema.OutputParser[time.Time] = Datetime{}
+
+// GetFormatInstructions returns instructions on the expected output format.
+func (p Datetime) GetFormatInstructions() string {
+	examples := []time.Time{
+		time.Date(2023, time.July, 4, 14, 30, 0, 0, time.UTC),
+		time.Date(1999, time.December, 31, 23, 59, 59, 0, time.UTC),
+		time.Date(2010, time.January, 15, 8, 5, 12, 0, time.UTC),
+	}
+	formatted := make([]string, len(examples))
+	for i, example := range examples {
+		formatted[i] = example.Format(p.Layout)
+	}
+	return fmt.Sprintf("Write a datetime string that matches the following Go time layout: %q\n\n"+
+		"Examples: %s\n\nReturn ONLY this string, no other words!", p.Layout, strings.Join(formatted, ", "))
+}
+
+// Parse parses the time in the output, which may be quoted or in a fenced
+// code block.
+func (p Datetime) Parse(text string) (time.Time, error) {
+	value := strings.Trim(codeblock.Extract(text), "'\"` \t\n")
+	location := p.Location
+	if location == nil {
+		location = time.UTC
+	}
+
+	t, err := time.ParseInLocation(p.Layout, value, location)
+	if err != nil {
+		return time.Time{}, ParseError{
+			Text:   text,
+			Reason: fmt.Sprintf("could not parse datetime with layout %q: %s", p.Layout, err),
+		}
+	}
+	return t, nil
+}
+
+// ParseWithPrompt does the same as Parse.
+func (p Datetime) ParseWithPrompt(text string, _ llms.PromptValue) (time.Time, error) {
+	return p.Parse(text)
+}
+
+// Type returns the type of the parser.
+func (p Datetime) Type() string {
+	return "datetime_parser"
+}
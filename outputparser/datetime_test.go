@@ -0,0 +1,40 @@
+package outputparser_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/devmiahub/langchaingo/outputparser"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatetime(t *testing.T) {
+	t.Parallel()
+	parser := outputparser.NewDatetime()
+
+	want := time.Date(2024, time.May, 1, 9, 30, 0, 0, time.UTC)
+	for _, text := range []string{
+		"2024-05-01T09:30:00Z",
+		"  \"2024-05-01T09:30:00Z\"\n",
+		"```\n2024-05-01T11:30:00+02:00\n```",
+	} {
+		got, err := parser.Parse(text)
+		require.NoError(t, err, text)
+		require.True(t, want.Equal(got), text)
+	}
+
+	_, err := parser.Parse("May 1st, 2024")
+	require.ErrorAs(t, err, &outputparser.ParseError{})
+
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err == nil {
+		dateOnly := outputparser.Datetime{Layout: time.DateTime, Location: paris}
+		got, err := dateOnly.Parse("2024-05-01 09:30:00")
+		require.NoError(t, err)
+		require.Equal(t, paris, got.Location())
+	}
+
+	instructions := outputparser.Datetime{Layout: time.DateOnly}.GetFormatInstructions()
+	require.Contains(t, instructions, `"2006-01-02"`)
+	require.Contains(t, instructions, "2023-07-04, 1999-12-31, 2010-01-15")
+}
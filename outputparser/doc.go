@@ -17,5 +17,20 @@ The outputparser package includes the following parsers:
     and returns map[string]string of the regex groups.
   - RegexDict: a parser that searches a string for values in a dictionary format,
     and returns a map[string]string of the keys and their associated value.
+  - Fixing: a parser that wraps another parser and, when it fails, asks a model to fix
+    the output given the format instructions and the error.
+  - Retry: a parser that wraps another parser and, when it fails, runs the prompt passed
+    to ParseWithPrompt again, along with the output and the error.
+  - StreamingJSON: a parser that consumes the chunks of a streaming response and emits
+    the progressively completed JSON value, decoded into a type such as the one of a
+    Defined parser. ParsePartialJSON parses such incomplete JSON.
+  - XML: a parser that returns the tags of an XML response as a map[string]any tree.
+  - YAML: a parser that decodes a YAML response into a type and validates it against
+    the JSON schema of the type.
+  - MarkdownTable: a parser that returns the rows of a markdown table as a
+    []map[string]string keyed by column header.
+  - List: a parser that returns the items of a numbered or markdown list as a string slice.
+  - Datetime: a parser that returns a time.Time parsed with a given layout.
+  - Enum: a parser that returns the one of a set of values given in the response.
 */
 package outputparser
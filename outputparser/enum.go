@@ -0,0 +1,57 @@
+package outputparser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/devmiahub/langchaingo/internal/codeblock"
+	"github.com/devmiahub/langchaingo/llms"
+	"github.com/devmiahub/langchaingo/schema"
+)
+
+// Enum is an output parser used to parse the output of an LLM as one of a set
+// of values. The values are matched ignoring case, and the value is returned
+// as given in Values.
+type Enum struct {
+	Values []string
+}
+
+// NewEnum creates a new Enum parser for the values.
+func NewEnum(values ...string) Enum {
+	return Enum{Values: values}
+}
+
+// Statically assert that Enum implements the OutputParser interface.
+var _ schema.OutputParser[string] = Enum{}
+
+// GetFormatInstructions returns instructions on the expected output format.
+func (p Enum) GetFormatInstructions() string {
+	return fmt.Sprintf("Select one of the following options: %s\n\nReturn ONLY the option, no other words!",
+		strings.Join(p.Values, ", "))
+}
+
+// Parse parses the output, which may be quoted, end with a period, or be in a
+// fenced code block, as one of the values.
+func (p Enum) Parse(text string) (string, error) {
+	const quotes = "'\"` \t\n"
+	value := strings.Trim(strings.TrimRight(strings.Trim(codeblock.Extract(text), quotes), "."), quotes)
+	for _, v := range p.Values {
+		if strings.EqualFold(v, value) {
+			return v, nil
+		}
+	}
+	return "", ParseError{
+		Text:   text,
+		Reason: fmt.Sprintf("expected output to be one of %v, received %s", p.Values, value),
+	}
+}
+
+// ParseWithPrompt does the same as Parse.
+func (p Enum) ParseWithPrompt(text string, _ llms.PromptValue) (string, error) {
+	return p.Parse(text)
+}
+
+// Type returns the type of the parser.
+func (p Enum) Type() string {
+	return "enum_parser"
+}
@@ -0,0 +1,31 @@
+package outputparser_test
+
+import (
+	"testing"
+
+	"github.com/devmiahub/langchaingo/outputparser"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnum(t *testing.T) {
+	t.Parallel()
+	parser := outputparser.NewEnum("Red", "Green", "Blue")
+
+	for text, want := range map[string]string{
+		"Red":                 "Red",
+		" green\n":            "Green",
+		"\"BLUE\".":           "Blue",
+		"```\nred\n```":       "Red",
+		"`Green`":             "Green",
+		"```text\nBlue.\n```": "Blue",
+	} {
+		got, err := parser.Parse(text)
+		require.NoError(t, err, text)
+		require.Equal(t, want, got, text)
+	}
+
+	_, err := parser.Parse("Purple")
+	require.ErrorAs(t, err, &outputparser.ParseError{})
+
+	require.Contains(t, parser.GetFormatInstructions(), "Red, Green, Blue")
+}
@@ -0,0 +1,134 @@
+package outputparser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/devmiahub/langchaingo/llms"
+	"github.com/devmiahub/langchaingo/prompts"
+	"github.com/devmiahub/langchaingo/schema"
+)
+
+// ErrMaxAttemptsReached is returned by the fixing and retry parsers when the
+// output still cannot be parsed after the maximum number of attempts.
+var ErrMaxAttemptsReached = errors.New("output parser reached the maximum number of attempts")
+
+const (
+	// _defaultMaxAttempts is the default number of times the fixing and retry
+	// parsers ask the model for a new output.
+	_defaultMaxAttempts = 1
+
+	_fixingTemplate = `Instructions:
+--------------
+{instructions}
+--------------
+Completion:
+--------------
+{completion}
+--------------
+
+Above, the Completion did not satisfy the constraints given in the Instructions.
+Error:
+--------------
+{error}
+--------------
+
+Please try again. Please only respond with an answer that satisfies the constraints laid out in the Instructions:`
+)
+
+// Fixing is an output parser that asks a model to fix the output when the
+// wrapped parser fails to parse it. The model is given the format
+// instructions of the wrapped parser, the output and the error.
+type Fixing[T any] struct {
+	// Parser is the wrapped parser.
+	Parser schema.OutputParser[T]
+	// LLM is the model fixing the output.
+	LLM llms.Model
+	// Prompt is the prompt asking to fix the output, with the instructions,
+	// completion and error input variables.
+	Prompt prompts.PromptTemplate
+	// MaxAttempts is the maximum number of times the model is asked to fix the
+	// output.
+	MaxAttempts int
+	// CallOptions are the options of the calls to the model.
+	CallOptions []llms.CallOption
+}
+
+// NewFixing creates an output parser fixing the output of the parser with the
+// model, once by default.
+func NewFixing[T any](llm llms.Model, parser schema.OutputParser[T]) Fixing[T] {
+	return Fixing[T]{
+		Parser: parser,
+		LLM:    llm,
+		Prompt: prompts.PromptTemplate{
+			Template:       _fixingTemplate,
+			InputVariables: []string{"instructions", "completion", "error"},
+			TemplateFormat: prompts.TemplateFormatFString,
+		},
+		MaxAttempts: _defaultMaxAttempts,
+	}
+}
+
+// Statically assert that Fixing implements the OutputParser interface.
+var _ schema.OutputParser[any] = Fixing[any]{}
+
+// GetFormatInstructions returns the format instructions of the wrapped parser.
+func (p Fixing[T]) GetFormatInstructions() string {
+	return p.Parser.GetFormatInstructions()
+}
+
+// Parse parses the output, asking the model to fix it on failure.
+func (p Fixing[T]) Parse(text string) (T, error) {
+	return p.ParseContext(context.Background(), text)
+}
+
+// ParseWithPrompt parses the output with the prompt that produced it, asking
+// the model to fix it on failure.
+func (p Fixing[T]) ParseWithPrompt(text string, prompt llms.PromptValue) (T, error) {
+	return p.ParseWithPromptContext(context.Background(), text, prompt)
+}
+
+// ParseContext parses the output, asking the model to fix it on failure.
+func (p Fixing[T]) ParseContext(ctx context.Context, text string) (T, error) {
+	return p.parse(ctx, text, p.Parser.Parse)
+}
+
+// ParseWithPromptContext parses the output with the prompt that produced it,
+// asking the model to fix it on failure. The output and its fixes are parsed
+// with the ParseWithPrompt method of the wrapped parser.
+func (p Fixing[T]) ParseWithPromptContext(ctx context.Context, text string, prompt llms.PromptValue) (T, error) {
+	return p.parse(ctx, text, func(text string) (T, error) {
+		return p.Parser.ParseWithPrompt(text, prompt)
+	})
+}
+
+// parse parses the output and its fixes with parse.
+func (p Fixing[T]) parse(ctx context.Context, text string, parse func(text string) (T, error)) (T, error) {
+	parsed, err := parse(text)
+	for attempt := 0; err != nil; attempt++ {
+		if attempt >= p.MaxAttempts {
+			return parsed, fmt.Errorf("%w: %w", ErrMaxAttemptsReached, err)
+		}
+
+		prompt, formatErr := p.Prompt.Format(map[string]any{
+			"instructions": p.Parser.GetFormatInstructions(),
+			"completion":   text,
+			"error":        err.Error(),
+		})
+		if formatErr != nil {
+			return parsed, formatErr
+		}
+		text, err = llms.GenerateFromSinglePrompt(ctx, p.LLM, prompt, p.CallOptions...)
+		if err != nil {
+			return parsed, err
+		}
+		parsed, err = parse(text)
+	}
+	return parsed, nil
+}
+
+// Type returns the type of the output parser.
+func (p Fixing[T]) Type() string {
+	return "output_fixing_parser"
+}
@@ -0,0 +1,109 @@
+package outputparser
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/devmiahub/langchaingo/llms"
+	"github.com/devmiahub/langchaingo/prompts"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingLLM answers with its responses in turn and records the prompts.
+type recordingLLM struct {
+	responses []string
+	prompts   []string
+}
+
+func (m *recordingLLM) GenerateContent(
+	_ context.Context, messages []llms.MessageContent, _ ...llms.CallOption,
+) (*llms.ContentResponse, error) {
+	var prompt strings.Builder
+	for _, part := range messages[len(messages)-1].Parts {
+		if text, ok := part.(llms.TextContent); ok {
+			prompt.WriteString(text.Text)
+		}
+	}
+	m.prompts = append(m.prompts, prompt.String())
+
+	response := m.responses[0]
+	m.responses = m.responses[1:]
+	return &llms.ContentResponse{Choices: []*llms.ContentChoice{{Content: response}}}, nil
+}
+
+func (m *recordingLLM) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, m, prompt, options...)
+}
+
+type fixingShape struct {
+	Name  string `json:"name"`
+	Sides int    `json:"sides"`
+}
+
+func TestFixing(t *testing.T) {
+	t.Parallel()
+	defined, err := NewDefined(fixingShape{})
+	require.NoError(t, err)
+
+	llm := &recordingLLM{responses: []string{"```json\n{\"name\": \"square\", \"sides\": 4}\n```"}}
+	parser := NewFixing(llm, defined)
+
+	got, err := parser.Parse("{\"name\": \"square\", \"sides\": 4")
+	require.NoError(t, err)
+	require.Equal(t, fixingShape{Name: "square", Sides: 4}, got)
+
+	require.Len(t, llm.prompts, 1)
+	require.Contains(t, llm.prompts[0], defined.GetFormatInstructions())
+	require.Contains(t, llm.prompts[0], "{\"name\": \"square\", \"sides\": 4")
+	require.Contains(t, llm.prompts[0], "input text should start with ```json")
+	require.Equal(t, defined.GetFormatInstructions(), parser.GetFormatInstructions())
+}
+
+func TestFixingValidOutput(t *testing.T) {
+	t.Parallel()
+	llm := &recordingLLM{}
+	parser := NewFixing[any](llm, NewBooleanParser())
+
+	got, err := parser.ParseWithPrompt("yes", nil)
+	require.NoError(t, err)
+	require.Equal(t, true, got)
+	require.Empty(t, llm.prompts)
+}
+
+func TestFixingMaxAttempts(t *testing.T) {
+	t.Parallel()
+	llm := &recordingLLM{responses: []string{"maybe", "perhaps", "yes"}}
+	parser := NewFixing[any](llm, NewBooleanParser())
+	parser.MaxAttempts = 2
+
+	_, err := parser.ParseContext(context.Background(), "unsure")
+	require.ErrorIs(t, err, ErrMaxAttemptsReached)
+	require.ErrorAs(t, err, &ParseError{})
+	require.Len(t, llm.prompts, 2)
+	require.Contains(t, llm.prompts[1], "Completion:\n--------------\nmaybe\n")
+}
+
+// promptParser parses outputs that are the same as their prompts.
+type promptParser struct {
+	BooleanParser
+}
+
+func (promptParser) ParseWithPrompt(text string, prompt llms.PromptValue) (any, error) {
+	if prompt == nil || text != prompt.String() {
+		return nil, ParseError{Text: text, Reason: "output is not the prompt"}
+	}
+	return text, nil
+}
+
+func TestFixingWithPrompt(t *testing.T) {
+	t.Parallel()
+	llm := &recordingLLM{responses: []string{"echo"}}
+	parser := NewFixing[any](llm, promptParser{})
+
+	got, err := parser.ParseWithPrompt("typo", prompts.StringPromptValue("echo"))
+	require.NoError(t, err)
+	require.Equal(t, "echo", got)
+	require.Len(t, llm.prompts, 1)
+	require.Contains(t, llm.prompts[0], "output is not the prompt")
+}
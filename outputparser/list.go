@@ -0,0 +1,82 @@
+package outputparser
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/devmiahub/langchaingo/internal/codeblock"
+	"github.com/devmiahub/langchaingo/llms"
+	"github.com/devmiahub/langchaingo/schema"
+)
+
+// ListStyle is the style of the list an LLM should output.
+type ListStyle string
+
+const (
+	// ListStyleNumbered is a numbered list, such as "1. foo".
+	ListStyleNumbered ListStyle = "numbered"
+	// ListStyleMarkdown is a markdown bullet list, such as "- foo".
+	ListStyleMarkdown ListStyle = "markdown"
+)
+
+// _listItemExpression matches the items of numbered and markdown lists, such
+// as "1. foo", "2) foo", "- foo", "* foo" or "+ foo".
+var _listItemExpression = regexp.MustCompile(`^\s*(?:\d+[.)]|[-*+])\s+(.*)$`) //nolint:gochecknoglobals
+
+// List is an output parser used to parse a numbered or markdown list in the
+// output of an LLM into a string slice. Lines that are not list items, such
+// as an introduction, are ignored.
+type List struct {
+	// Style is the style of list asked for in the format instructions. Both
+	// styles are parsed.
+	Style ListStyle
+}
+
+// NewNumberedList creates a new List parser asking for a numbered list.
+func NewNumberedList() List {
+	return List{Style: ListStyleNumbered}
+}
+
+// NewMarkdownList creates a new List parser asking for a markdown list.
+func NewMarkdownList() List {
+	return List{Style: ListStyleMarkdown}
+}
+
+// Statically assert that List implements the OutputParser interface.
+var _ schema.OutputParser[[]string] = List{}
+
+// GetFormatInstructions returns instructions on the expected output format.
+func (p List) GetFormatInstructions() string {
+	if p.Style == ListStyleMarkdown {
+		return "Your response should be a markdown list, eg: `- foo\n- bar\n- baz`"
+	}
+	return "Your response should be a numbered list with each item on a new line. For example: \n\n1. foo\n\n2. bar\n\n3. baz"
+}
+
+// Parse parses the items of the list in the output, which may be in a fenced
+// code block.
+func (p List) Parse(text string) ([]string, error) {
+	var items []string
+	for _, line := range strings.Split(codeblock.Extract(text, "markdown", "md"), "\n") {
+		if match := _listItemExpression.FindStringSubmatch(line); match != nil {
+			items = append(items, strings.TrimSpace(match[1]))
+		}
+	}
+	if len(items) == 0 {
+		return nil, ParseError{Text: text, Reason: "no list items in output"}
+	}
+	return items, nil
+}
+
+// ParseWithPrompt does the same as Parse.
+func (p List) ParseWithPrompt(text string, _ llms.PromptValue) ([]string, error) {
+	return p.Parse(text)
+}
+
+// Type returns the type of the parser.
+func (p List) Type() string {
+	if p.Style == ListStyleMarkdown {
+		return "markdown_list_parser"
+	}
+	return "numbered_list_parser"
+}
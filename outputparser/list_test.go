@@ -0,0 +1,39 @@
+package outputparser_test
+
+import (
+	"testing"
+
+	"github.com/devmiahub/langchaingo/outputparser"
+	"github.com/stretchr/testify/require"
+)
+
+func TestList(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"numbered", "1. foo\n2. bar baz\n3) qux", []string{"foo", "bar baz", "qux"}},
+		{"markdown", "- foo\n* bar\n+ baz", []string{"foo", "bar", "baz"}},
+		{"introduction", "Here is the list:\n\n1. foo\n\n2. bar\n\nHope it helps!", []string{"foo", "bar"}},
+		{"nested", "- foo\n  - bar", []string{"foo", "bar"}},
+		{"code block", "```markdown\n- foo\n- bar\n```", []string{"foo", "bar"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := outputparser.NewNumberedList().Parse(tt.text)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := outputparser.NewMarkdownList().Parse("foo, bar")
+	require.ErrorAs(t, err, &outputparser.ParseError{})
+
+	require.Contains(t, outputparser.NewNumberedList().GetFormatInstructions(), "numbered list")
+	require.Contains(t, outputparser.NewMarkdownList().GetFormatInstructions(), "markdown list")
+	require.Equal(t, "markdown_list_parser", outputparser.NewMarkdownList().Type())
+}
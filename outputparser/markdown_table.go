@@ -0,0 +1,136 @@
+package outputparser
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/devmiahub/langchaingo/internal/codeblock"
+	"github.com/devmiahub/langchaingo/llms"
+	"github.com/devmiahub/langchaingo/schema"
+)
+
+// MarkdownTable is an output parser used to parse a markdown table in the
+// output of an LLM into a slice of rows, each mapping the column headers to
+// the cells of the row.
+type MarkdownTable struct {
+	// Columns are the headers of the columns the table should have. When set,
+	// a table missing one of them is an error. Headers are matched ignoring
+	// case, and rows are keyed by the columns as spelled here.
+	Columns []string
+}
+
+// NewMarkdownTable creates a new MarkdownTable parser expecting the columns.
+func NewMarkdownTable(columns ...string) MarkdownTable {
+	return MarkdownTable{Columns: columns}
+}
+
+// Statically assert that MarkdownTable implements the OutputParser interface.
+var _ schema.OutputParser[[]map[string]string] = MarkdownTable{}
+
+// GetFormatInstructions returns instructions on the expected output format.
+func (p MarkdownTable) GetFormatInstructions() string {
+	instructions := "Your output should be a markdown table, with a header row and a separator row, e.g.:\n"
+	if len(p.Columns) == 0 {
+		return instructions + "| Name | Value |\n| --- | --- |\n| foo | 1 |"
+	}
+
+	separators := make([]string, len(p.Columns))
+	for i := range separators {
+		separators[i] = "---"
+	}
+	return instructions + formatTableRow(p.Columns) + "\n" + formatTableRow(separators) + "\n" +
+		fmt.Sprintf("with exactly these columns: %s", strings.Join(p.Columns, ", "))
+}
+
+func formatTableRow(cells []string) string {
+	return "| " + strings.Join(cells, " | ") + " |"
+}
+
+// Parse parses the first markdown table of the output, which may be in a
+// fenced code block.
+func (p MarkdownTable) Parse(text string) ([]map[string]string, error) {
+	var lines []string
+	for _, line := range strings.Split(codeblock.Extract(text, "markdown", "md"), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "|") {
+			lines = append(lines, line)
+		} else if len(lines) > 0 {
+			break
+		}
+	}
+	if len(lines) < 2 || !isTableSeparator(splitTableRow(lines[1])) {
+		return nil, ParseError{Text: text, Reason: "no markdown table with a header and a separator row in output"}
+	}
+
+	// The headers matching a column are spelled as the column.
+	headers := splitTableRow(lines[0])
+	for _, column := range p.Columns {
+		i := slices.IndexFunc(headers, func(header string) bool { return strings.EqualFold(header, column) })
+		if i < 0 {
+			return nil, ParseError{Text: text, Reason: fmt.Sprintf("table is missing column %q", column)}
+		}
+		headers[i] = column
+	}
+
+	rows := make([]map[string]string, 0, len(lines)-2)
+	for _, line := range lines[2:] {
+		cells := splitTableRow(line)
+		row := make(map[string]string, len(headers))
+		for i, header := range headers {
+			if i < len(cells) {
+				row[header] = cells[i]
+			} else {
+				row[header] = ""
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// splitTableRow returns the cells of a row, unescaping the escaped pipes.
+func splitTableRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = line[:len(line)-1]
+	}
+
+	var cells []string
+	var cell strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case line[i] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(line[i])
+		}
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+// isTableSeparator reports whether the cells are those of the row separating
+// the header of a table, such as "---" or ":---:".
+func isTableSeparator(cells []string) bool {
+	for _, cell := range cells {
+		if strings.Trim(cell, ":") == "" || strings.Trim(cell, ":-") != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseWithPrompt does the same as Parse.
+func (p MarkdownTable) ParseWithPrompt(text string, _ llms.PromptValue) ([]map[string]string, error) {
+	return p.Parse(text)
+}
+
+// Type returns the type of the parser.
+func (p MarkdownTable) Type() string {
+	return "markdown_table_parser"
+}
@@ -0,0 +1,47 @@
+package outputparser_test
+
+import (
+	"testing"
+
+	"github.com/devmiahub/langchaingo/outputparser"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkdownTable(t *testing.T) {
+	t.Parallel()
+	parser := outputparser.NewMarkdownTable("City", "Country")
+
+	text := "Here are the cities:\n\n" +
+		"| City | Country | Note |\n" +
+		"|:-----|:-------:|-----:|\n" +
+		"| Paris | France | a \\| b |\n" +
+		"| Lyon | France |\n" +
+		"\nLet me know if you need more."
+	got, err := parser.Parse(text)
+	require.NoError(t, err)
+	require.Equal(t, []map[string]string{
+		{"City": "Paris", "Country": "France", "Note": "a | b"},
+		{"City": "Lyon", "Country": "France", "Note": ""},
+	}, got)
+
+	got, err = parser.Parse("```markdown\ncity | country\n--- | ---\n| Oslo | Norway |\n```")
+	require.Error(t, err, "rows must start with a pipe")
+	require.Nil(t, got)
+
+	// Rows are keyed by the columns of the parser, whatever the case of the
+	// headers of the table.
+	got, err = parser.Parse("```markdown\n| city | COUNTRY | note |\n| --- | --- | --- |\n| Oslo | Norway | cold |\n```")
+	require.NoError(t, err)
+	require.Equal(t, []map[string]string{{"City": "Oslo", "Country": "Norway", "note": "cold"}}, got)
+
+	for _, text := range []string{
+		"no table",
+		"| City | Country |\n| Paris | France |",
+		"| City | Population |\n| --- | --- |\n| Paris | 2M |",
+	} {
+		_, err := parser.Parse(text)
+		require.ErrorAs(t, err, &outputparser.ParseError{}, text)
+	}
+
+	require.Contains(t, parser.GetFormatInstructions(), "| City | Country |\n| --- | --- |")
+}
@@ -0,0 +1,336 @@
+package outputparser
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/devmiahub/langchaingo/internal/codeblock"
+	"github.com/devmiahub/langchaingo/schema"
+)
+
+// ParsePartialJSON parses the JSON object or array of a text that may still
+// be streaming, such as `{"name": "Ada", "skills": ["ma`. Unterminated
+// strings, arrays and objects are closed, and a trailing incomplete key or
+// value is dropped. The JSON of a json code block is preferred; text before
+// the JSON, such as the opening of a code block, and text after it are
+// ignored.
+func ParsePartialJSON(text string) (any, error) {
+	return parsePartialJSON(text, func(any) bool { return true })
+}
+
+// parsePartialJSON parses the first partial JSON value of the text accepted by
+// accept, trying each brace and bracket of the text as its start. Starts not
+// followed by valid JSON, such as the braces of "{name}" in prose, are
+// skipped.
+func parsePartialJSON(text string, accept func(value any) bool) (any, error) {
+	candidate, starts := jsonStarts(text)
+	if len(starts) == 0 {
+		return nil, ParseError{Text: text, Reason: "no JSON object or array in output"}
+	}
+
+	for _, start := range starts {
+		completed, ok := completeJSON(candidate[start:])
+		if !ok {
+			continue
+		}
+		var value any
+		if err := json.Unmarshal([]byte(completed), &value); err == nil && accept(value) {
+			return value, nil
+		}
+	}
+	return nil, ParseError{Text: text, Reason: "no valid JSON object or array in output"}
+}
+
+// jsonStarts returns the part of the text holding its JSON, which is the
+// content of its json code block if any, and the offsets in it of the braces
+// and brackets that may start the JSON.
+func jsonStarts(text string) (string, []int) {
+	if content, ok := codeblock.Find(text, "json"); ok && strings.ContainsAny(content, "{[") {
+		text = content
+	}
+	var starts []int
+	for i := range len(text) {
+		if text[i] == '{' || text[i] == '[' {
+			starts = append(starts, i)
+		}
+	}
+	return text, starts
+}
+
+// jsonExpect is what completeJSON expects next in a partial JSON value.
+type jsonExpect int
+
+const (
+	expectValue jsonExpect = iota
+	expectValueOrEnd
+	expectKey
+	expectKeyOrEnd
+	expectColon
+	expectCommaOrEnd
+)
+
+// completeJSON closes the unterminated string, arrays and objects of partial,
+// which starts with an object or an array, in a single pass. A trailing
+// incomplete key or value is dropped, and the text after the end of the value
+// is removed. It reports false if partial is not the beginning of a JSON
+// value.
+func completeJSON(partial string) (string, bool) {
+	var closers []byte
+	expect := expectValue
+	// cut is the end of the text that the closers complete into valid JSON.
+	// The closers do not change between it and the end of the text scanned.
+	cut := 0
+
+	for i := 0; i < len(partial); {
+		c := partial[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '"':
+			end := stringEnd(partial, i)
+			if end < 0 {
+				if expect == expectValue || expect == expectValueOrEnd {
+					if value, ok := closeString(partial[i:]); ok {
+						return partial[:i] + value + closeJSON(closers), true
+					}
+				}
+				return partial[:cut] + closeJSON(closers), true
+			}
+			switch expect {
+			case expectKey, expectKeyOrEnd:
+				expect = expectColon
+			case expectValue, expectValueOrEnd:
+				expect, cut = expectCommaOrEnd, end
+			default:
+				return "", false
+			}
+			i = end
+		case c == ':':
+			if expect != expectColon {
+				return "", false
+			}
+			expect = expectValue
+			i++
+		case c == ',':
+			if expect != expectCommaOrEnd {
+				return "", false
+			}
+			expect = expectValue
+			if closers[len(closers)-1] == '}' {
+				expect = expectKey
+			}
+			i++
+		case c == '{' || c == '[':
+			if expect != expectValue && expect != expectValueOrEnd {
+				return "", false
+			}
+			closers = append(closers, c+2) // '}' and ']' follow '{' and '[' by 2.
+			expect = expectValueOrEnd
+			if c == '{' {
+				expect = expectKeyOrEnd
+			}
+			i++
+			cut = i
+		case c == '}' || c == ']':
+			if len(closers) == 0 || closers[len(closers)-1] != c {
+				return "", false
+			}
+			if expect != expectCommaOrEnd && expect != expectKeyOrEnd && expect != expectValueOrEnd {
+				return "", false
+			}
+			closers = closers[:len(closers)-1]
+			i++
+			if len(closers) == 0 {
+				return partial[:i], true
+			}
+			expect, cut = expectCommaOrEnd, i
+		default:
+			// A number, true, false or null.
+			if expect != expectValue && expect != expectValueOrEnd {
+				return "", false
+			}
+			end := i
+			for end < len(partial) && strings.IndexByte("+-.0123456789Eabcdeflnrstu", partial[end]) >= 0 {
+				end++
+			}
+			valid := end > i && json.Valid([]byte(partial[i:end]))
+			if end == len(partial) {
+				// The literal may be incomplete, such as "tr" or "1.".
+				if valid {
+					cut = end
+				}
+				return partial[:cut] + closeJSON(closers), true
+			}
+			if !valid {
+				return "", false
+			}
+			expect, cut, i = expectCommaOrEnd, end, end
+		}
+	}
+	return partial[:cut] + closeJSON(closers), true
+}
+
+// stringEnd returns the offset after the end of the JSON string starting at
+// offset start of text, or -1 if the string is unterminated.
+func stringEnd(text string, start int) int {
+	for i := start + 1; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1
+		}
+	}
+	return -1
+}
+
+// closeString closes the unterminated JSON string value, dropping an
+// incomplete escape sequence or UTF-8 character at its end.
+func closeString(value string) (string, bool) {
+	for end := len(value); end > 0 && end >= len(value)-6; end-- {
+		if r, size := utf8.DecodeLastRuneInString(value[:end]); r == utf8.RuneError && size == 1 {
+			continue
+		}
+		if closed := value[:end] + `"`; json.Valid([]byte(closed)) {
+			return closed, true
+		}
+	}
+	return "", false
+}
+
+// closeJSON returns the closers of the unterminated arrays and objects.
+func closeJSON(closers []byte) string {
+	closed := make([]byte, len(closers))
+	for i, c := range closers {
+		closed[len(closers)-1-i] = c
+	}
+	return string(closed)
+}
+
+// StreamingJSON parses the JSON output of a model while it streams, calling
+// OnPartial with each progressively completed value decoded into T. Pass
+// its StreamingFunc to llms.WithStreamingFunc. T can be any to get maps and
+// slices, or the struct of a Defined[T] parser, whose format instructions
+// are then used in the prompt.
+//
+// Example:
+//
+//	parser, _ := outputparser.NewDefined(Recipe{})
+//	stream := outputparser.NewStreamingJSON(parser, func(ctx context.Context, partial Recipe) error {
+//		render(partial)
+//		return nil
+//	})
+//	_, err := llms.GenerateFromSinglePrompt(ctx, llm, prompt+parser.GetFormatInstructions(),
+//		llms.WithStreamingFunc(stream.StreamingFunc))
+//	recipe, err := stream.Result()
+//
+// A StreamingJSON is not safe for concurrent use, and streams one response.
+type StreamingJSON[T any] struct {
+	// Parser parses the complete output in Result. When nil, the JSON of the
+	// output is decoded into T.
+	Parser schema.OutputParser[T]
+	// OnPartial is called when the partial value changes.
+	OnPartial func(ctx context.Context, partial T) error
+
+	text strings.Builder
+	// last is the JSON of the last partial value.
+	last []byte
+}
+
+// NewStreamingJSON creates a streaming JSON parser calling onPartial with the
+// partial values, and parsing the complete output with the parser, which may
+// be nil.
+func NewStreamingJSON[T any](
+	parser schema.OutputParser[T], onPartial func(ctx context.Context, partial T) error,
+) *StreamingJSON[T] {
+	return &StreamingJSON[T]{Parser: parser, OnPartial: onPartial}
+}
+
+// StreamingFunc consumes a chunk of the output, calling OnPartial if the
+// partial value changed. Chunks that do not make the partial JSON valid
+// are only accumulated.
+func (p *StreamingJSON[T]) StreamingFunc(ctx context.Context, chunk []byte) error {
+	p.text.Write(chunk)
+
+	value, data, err := p.partial()
+	if err != nil || bytes.Equal(data, p.last) {
+		return nil //nolint:nilerr
+	}
+	p.last = data
+	if p.OnPartial == nil {
+		return nil
+	}
+	return p.OnPartial(ctx, value)
+}
+
+// Partial returns the current partial value.
+func (p *StreamingJSON[T]) Partial() (T, error) {
+	value, _, err := p.partial()
+	return value, err
+}
+
+// partial returns the first partial value of the output streamed so far that
+// fits T, and the JSON of that value as T, so that changes to fields T does
+// not have are not reported. Values that do not fit T, such as a "[1]" footnote
+// before an object, are skipped.
+func (p *StreamingJSON[T]) partial() (T, []byte, error) {
+	var (
+		value T
+		data  []byte
+	)
+	_, err := parsePartialJSON(p.text.String(), func(partial any) bool {
+		raw, err := json.Marshal(partial)
+		if err != nil {
+			return false
+		}
+		value = *new(T)
+		if json.Unmarshal(raw, &value) != nil {
+			return false
+		}
+		data, err = json.Marshal(value)
+		return err == nil
+	})
+	if err != nil {
+		return *new(T), nil, err
+	}
+	return value, data, nil
+}
+
+// Text returns the output streamed so far.
+func (p *StreamingJSON[T]) Text() string {
+	return p.text.String()
+}
+
+// Result parses the complete output, with Parser if set. Otherwise the first
+// JSON object or array of the output that fits T is decoded, preferring the
+// JSON of a json code block.
+func (p *StreamingJSON[T]) Result() (T, error) {
+	text := strings.TrimSpace(p.text.String())
+	if p.Parser != nil {
+		return p.Parser.Parse(text)
+	}
+
+	candidate, starts := jsonStarts(text)
+	if len(starts) == 0 {
+		return *new(T), ParseError{Text: text, Reason: "no JSON object or array in output"}
+	}
+	var err error
+	for _, start := range starts {
+		var value T
+		err = json.NewDecoder(strings.NewReader(candidate[start:])).Decode(&value)
+		if err == nil {
+			return value, nil
+		}
+		// JSON cut off by the end of the output is incomplete rather than
+		// the wrong candidate, so its nested values are not tried.
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			break
+		}
+	}
+	return *new(T), ParseError{Text: text, Reason: err.Error()}
+}
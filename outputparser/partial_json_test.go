@@ -0,0 +1,160 @@
+package outputparser
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePartialJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		text string
+		want any
+	}{
+		{"empty object", `{`, map[string]any{}},
+		{"unterminated string", `{"name": "Ad`, map[string]any{"name": "Ad"}},
+		{"unterminated key", `{"name": "Ada", "ag`, map[string]any{"name": "Ada"}},
+		{"missing value", `{"name": "Ada", "age": `, map[string]any{"name": "Ada"}},
+		{"incomplete literal", `{"ok": tr`, map[string]any{}},
+		{"trailing comma", `{"name": "Ada",`, map[string]any{"name": "Ada"}},
+		{"unterminated array", `{"skills": ["math", "eng`, map[string]any{"skills": []any{"math", "eng"}}},
+		{"nested", `{"a": {"b": [1, {"c": "d`, map[string]any{"a": map[string]any{"b": []any{1.0, map[string]any{"c": "d"}}}}},
+		{"escape", `{"quote": "say \"hi\" \`, map[string]any{"quote": `say "hi" `}},
+		{"braces in string", `{"code": "if x { [`, map[string]any{"code": "if x { ["}},
+		{"array", `[1, 2, 3`, []any{1.0, 2.0, 3.0}},
+		{"code block", "Here you go:\n```json\n{\"name\": \"Ada\"", map[string]any{"name": "Ada"}},
+		{"complete", "```json\n{\"name\": \"Ada\"}\n```", map[string]any{"name": "Ada"}},
+		{"braces in prose", `Use {braces} here: {"name": "Ad`, map[string]any{"name": "Ad"}},
+		{"incomplete number", `{"a": [1, 2.`, map[string]any{"a": []any{1.0}}},
+		{"incomplete escape", `{"a": "\u00`, map[string]any{"a": ""}},
+		{"code block after brackets", "See [1]:\n```json\n{\"name\": \"Ada\"", map[string]any{"name": "Ada"}},
+		{"unicode", `{"name": "Zoë`, map[string]any{"name": "Zoë"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParsePartialJSON(tt.text)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := ParsePartialJSON("```json\n")
+	require.ErrorAs(t, err, &ParseError{})
+}
+
+type streamedRecipe struct {
+	Name        string   `json:"name"`
+	Ingredients []string `json:"ingredients"`
+}
+
+func TestStreamingJSON(t *testing.T) {
+	t.Parallel()
+	defined, err := NewDefined(streamedRecipe{})
+	require.NoError(t, err)
+
+	var partials []streamedRecipe
+	stream := NewStreamingJSON(defined, func(_ context.Context, partial streamedRecipe) error {
+		partials = append(partials, partial)
+		return nil
+	})
+
+	chunks := []string{
+		"```json\n", `{"na`, `me": "Pan`, `cakes", `, `"ingredients": ["flo`, `ur", `, `"milk"]}`, "\n```",
+	}
+	for _, chunk := range chunks {
+		require.NoError(t, stream.StreamingFunc(context.Background(), []byte(chunk)))
+	}
+
+	require.Equal(t, []streamedRecipe{
+		{},
+		{Name: "Pan"},
+		{Name: "Pancakes"},
+		{Name: "Pancakes", Ingredients: []string{"flo"}},
+		{Name: "Pancakes", Ingredients: []string{"flour"}},
+		{Name: "Pancakes", Ingredients: []string{"flour", "milk"}},
+	}, partials)
+
+	partial, err := stream.Partial()
+	require.NoError(t, err)
+	require.Equal(t, partials[len(partials)-1], partial)
+
+	result, err := stream.Result()
+	require.NoError(t, err)
+	require.Equal(t, streamedRecipe{Name: "Pancakes", Ingredients: []string{"flour", "milk"}}, result)
+	require.Equal(t, "```json\n{\"name\": \"Pancakes\", \"ingredients\": [\"flour\", \"milk\"]}\n```", stream.Text())
+}
+
+func TestStreamingJSONUntyped(t *testing.T) {
+	t.Parallel()
+	var last any
+	stream := NewStreamingJSON[any](nil, func(_ context.Context, partial any) error {
+		last = partial
+		return nil
+	})
+
+	for _, chunk := range []string{`Sure: [{"id": 1}, `, `{"id": 2`} {
+		require.NoError(t, stream.StreamingFunc(context.Background(), []byte(chunk)))
+	}
+	require.Equal(t, []any{map[string]any{"id": 1.0}, map[string]any{"id": 2.0}}, last)
+
+	_, err := stream.Result()
+	require.ErrorAs(t, err, &ParseError{})
+	require.NoError(t, stream.StreamingFunc(context.Background(), []byte("}]")))
+	result, err := stream.Result()
+	require.NoError(t, err)
+	require.Equal(t, last, result)
+}
+
+func TestStreamingJSONSkipsValuesNotFittingType(t *testing.T) {
+	t.Parallel()
+	type note struct {
+		A int `json:"a"`
+	}
+	var partials []note
+	stream := NewStreamingJSON[note](nil, func(_ context.Context, partial note) error {
+		partials = append(partials, partial)
+		return nil
+	})
+
+	for _, chunk := range []string{`Note [1]: here {"a": 1, `, `"b": 2}`} {
+		require.NoError(t, stream.StreamingFunc(context.Background(), []byte(chunk)))
+	}
+	require.Equal(t, []note{{A: 1}}, partials)
+
+	result, err := stream.Result()
+	require.NoError(t, err)
+	require.Equal(t, note{A: 1}, result)
+}
+
+func TestStreamingJSONBracesInProse(t *testing.T) {
+	t.Parallel()
+	var partials []streamedRecipe
+	stream := NewStreamingJSON[streamedRecipe](nil, func(_ context.Context, partial streamedRecipe) error {
+		partials = append(partials, partial)
+		return nil
+	})
+
+	for _, chunk := range []string{`I'll fill {name}: `, `{"name": "Ad`, `a"}`} {
+		require.NoError(t, stream.StreamingFunc(context.Background(), []byte(chunk)))
+	}
+	require.Equal(t, []streamedRecipe{{Name: "Ad"}, {Name: "Ada"}}, partials)
+
+	result, err := stream.Result()
+	require.NoError(t, err)
+	require.Equal(t, streamedRecipe{Name: "Ada"}, result)
+}
+
+func TestStreamingJSONStops(t *testing.T) {
+	t.Parallel()
+	errStop := errors.New("stop")
+	stream := NewStreamingJSON[map[string]any](nil, func(context.Context, map[string]any) error {
+		return errStop
+	})
+	require.ErrorIs(t, stream.StreamingFunc(context.Background(), []byte(`{"a": 1`)), errStop)
+}
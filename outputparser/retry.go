@@ -0,0 +1,102 @@
+package outputparser
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/devmiahub/langchaingo/llms"
+	"github.com/devmiahub/langchaingo/prompts"
+	"github.com/devmiahub/langchaingo/schema"
+)
+
+const _retryTemplate = `Prompt:
+{prompt}
+Completion:
+{completion}
+
+Above, the Completion did not satisfy the constraints given in the Prompt.
+Details: {error}
+Please try again:`
+
+// Retry is an output parser that runs the prompt again when the wrapped
+// parser fails to parse the output. The model is given the original prompt,
+// the output and the error, so it needs ParseWithPrompt: Parse only uses the
+// wrapped parser.
+type Retry[T any] struct {
+	// Parser is the wrapped parser.
+	Parser schema.OutputParser[T]
+	// LLM is the model running the prompt again.
+	LLM llms.Model
+	// Prompt is the prompt asking to try again, with the prompt, completion and
+	// error input variables.
+	Prompt prompts.PromptTemplate
+	// MaxAttempts is the maximum number of times the prompt is run again.
+	MaxAttempts int
+	// CallOptions are the options of the calls to the model.
+	CallOptions []llms.CallOption
+}
+
+// NewRetry creates an output parser running the prompt again with the model
+// when the parser fails, once by default.
+func NewRetry[T any](llm llms.Model, parser schema.OutputParser[T]) Retry[T] {
+	return Retry[T]{
+		Parser: parser,
+		LLM:    llm,
+		Prompt: prompts.PromptTemplate{
+			Template:       _retryTemplate,
+			InputVariables: []string{"prompt", "completion", "error"},
+			TemplateFormat: prompts.TemplateFormatFString,
+		},
+		MaxAttempts: _defaultMaxAttempts,
+	}
+}
+
+// Statically assert that Retry implements the OutputParser interface.
+var _ schema.OutputParser[any] = Retry[any]{}
+
+// GetFormatInstructions returns the format instructions of the wrapped parser.
+func (p Retry[T]) GetFormatInstructions() string {
+	return p.Parser.GetFormatInstructions()
+}
+
+// Parse parses the output with the wrapped parser, without retrying as the
+// prompt is unknown.
+func (p Retry[T]) Parse(text string) (T, error) {
+	return p.Parser.Parse(text)
+}
+
+// ParseWithPrompt parses the output, running the prompt again on failure.
+func (p Retry[T]) ParseWithPrompt(text string, prompt llms.PromptValue) (T, error) {
+	return p.ParseWithPromptContext(context.Background(), text, prompt)
+}
+
+// ParseWithPromptContext parses the output, running the prompt again on
+// failure.
+func (p Retry[T]) ParseWithPromptContext(ctx context.Context, text string, prompt llms.PromptValue) (T, error) {
+	parsed, err := p.Parser.ParseWithPrompt(text, prompt)
+	for attempt := 0; err != nil; attempt++ {
+		if attempt >= p.MaxAttempts {
+			return parsed, fmt.Errorf("%w: %w", ErrMaxAttemptsReached, err)
+		}
+
+		retryPrompt, formatErr := p.Prompt.Format(map[string]any{
+			"prompt":     prompt.String(),
+			"completion": text,
+			"error":      err.Error(),
+		})
+		if formatErr != nil {
+			return parsed, formatErr
+		}
+		text, err = llms.GenerateFromSinglePrompt(ctx, p.LLM, retryPrompt, p.CallOptions...)
+		if err != nil {
+			return parsed, err
+		}
+		parsed, err = p.Parser.ParseWithPrompt(text, prompt)
+	}
+	return parsed, nil
+}
+
+// Type returns the type of the output parser.
+func (p Retry[T]) Type() string {
+	return "retry_with_error_parser"
+}
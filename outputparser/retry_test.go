@@ -0,0 +1,46 @@
+package outputparser
+
+import (
+	"testing"
+
+	"github.com/devmiahub/langchaingo/prompts"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetry(t *testing.T) {
+	t.Parallel()
+	llm := &recordingLLM{responses: []string{"no"}}
+	parser := NewRetry[any](llm, NewBooleanParser())
+	prompt := prompts.StringPromptValue("Is the sky blue? " + parser.GetFormatInstructions())
+
+	got, err := parser.ParseWithPrompt("It depends on the weather.", prompt)
+	require.NoError(t, err)
+	require.Equal(t, false, got)
+
+	require.Len(t, llm.prompts, 1)
+	require.Contains(t, llm.prompts[0], "Prompt:\nIs the sky blue?")
+	require.Contains(t, llm.prompts[0], "Completion:\nIt depends on the weather.\n")
+	require.Contains(t, llm.prompts[0], "Details: ")
+}
+
+func TestRetryMaxAttempts(t *testing.T) {
+	t.Parallel()
+	llm := &recordingLLM{responses: []string{"maybe", "perhaps"}}
+	parser := NewRetry[any](llm, NewBooleanParser())
+	parser.MaxAttempts = 2
+
+	_, err := parser.ParseWithPrompt("unsure", prompts.StringPromptValue("Is the sky blue?"))
+	require.ErrorIs(t, err, ErrMaxAttemptsReached)
+	require.Len(t, llm.prompts, 2)
+}
+
+func TestRetryParseWithoutPrompt(t *testing.T) {
+	t.Parallel()
+	llm := &recordingLLM{}
+	parser := NewRetry[any](llm, NewBooleanParser())
+
+	_, err := parser.Parse("unsure")
+	require.Error(t, err)
+	require.NotErrorIs(t, err, ErrMaxAttemptsReached)
+	require.Empty(t, llm.prompts)
+}
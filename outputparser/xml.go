@@ -0,0 +1,148 @@
+package outputparser
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/devmiahub/langchaingo/internal/codeblock"
+	"github.com/devmiahub/langchaingo/llms"
+	"github.com/devmiahub/langchaingo/schema"
+)
+
+const _xmlFormatInstructions = `The output should be formatted as a XML file.
+1. Output should conform to the tags below.
+2. If tags are not given, make them on your own.
+3. Remember to always open and close all the tags.
+
+As an example, for the tags ["foo", "bar", "baz"]:
+1. String "<foo>
+   <bar>
+      <baz></baz>
+   </bar>
+</foo>" is a well-formatted instance of the schema.
+2. String "<foo>
+   <bar>
+   </foo>" is a badly-formatted instance.
+3. String "<foo>
+   <tag>
+   </tag>
+</foo>" is a badly-formatted instance.
+
+Here are the output tags:
+` + "```\n%s\n```"
+
+// XML is an output parser used to parse the XML output of an LLM into the tree
+// of its tags. The root tag maps to its content: a map of its child tags, or
+// its text when it has none. Tags repeated under the same parent are gathered
+// in a []any. Attributes are ignored.
+//
+// For example, "<book><title>Dune</title><tag>a</tag><tag>b</tag></book>"
+// is parsed into:
+//
+//	map[string]any{"book": map[string]any{"title": "Dune", "tag": []any{"a", "b"}}}
+type XML struct {
+	// Tags are the tags the output should use, given in the format
+	// instructions.
+	Tags []string
+}
+
+// NewXML creates a new XML parser expecting the tags.
+func NewXML(tags ...string) XML {
+	return XML{Tags: tags}
+}
+
+// Statically assert that XML implements the OutputParser interface.
+var _ schema.OutputParser[map[string]any] = XML{}
+
+// GetFormatInstructions returns instructions on the expected output format.
+func (p XML) GetFormatInstructions() string {
+	if len(p.Tags) == 0 {
+		return "The output should be formatted as a XML file. Remember to always open and close all the tags."
+	}
+	return fmt.Sprintf(_xmlFormatInstructions, strings.Join(p.Tags, ", "))
+}
+
+// Parse parses the XML of the output, which may be in a fenced code block,
+// into the tree of its tags.
+func (p XML) Parse(text string) (map[string]any, error) {
+	content := codeblock.Extract(text, "xml")
+	start := strings.IndexByte(content, '<')
+	if start < 0 {
+		return nil, ParseError{Text: text, Reason: "no XML tag in output"}
+	}
+
+	decoder := xml.NewDecoder(strings.NewReader(content[start:]))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				err = errors.New("no root tag")
+			}
+			return nil, ParseError{Text: text, Reason: fmt.Sprintf("invalid XML: %s", err)}
+		}
+		if element, ok := token.(xml.StartElement); ok {
+			value, err := decodeXMLElement(decoder)
+			if err != nil {
+				return nil, ParseError{Text: text, Reason: fmt.Sprintf("invalid XML: %s", err)}
+			}
+			return map[string]any{element.Name.Local: value}, nil
+		}
+	}
+}
+
+// decodeXMLElement decodes the content of the element whose start tag was
+// just read.
+func decodeXMLElement(decoder *xml.Decoder) (any, error) {
+	var text strings.Builder
+	var children map[string]any
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		switch token := token.(type) {
+		case xml.StartElement:
+			value, err := decodeXMLElement(decoder)
+			if err != nil {
+				return nil, err
+			}
+			if children == nil {
+				children = map[string]any{}
+			}
+			addXMLChild(children, token.Name.Local, value)
+		case xml.CharData:
+			text.Write(token)
+		case xml.EndElement:
+			if children != nil {
+				return children, nil
+			}
+			return strings.TrimSpace(text.String()), nil
+		}
+	}
+}
+
+// addXMLChild adds a child element, gathering the repeated ones in a slice.
+func addXMLChild(children map[string]any, name string, value any) {
+	switch existing := children[name].(type) {
+	case nil:
+		children[name] = value
+	case []any:
+		children[name] = append(existing, value)
+	default:
+		children[name] = []any{existing, value}
+	}
+}
+
+// ParseWithPrompt does the same as Parse.
+func (p XML) ParseWithPrompt(text string, _ llms.PromptValue) (map[string]any, error) {
+	return p.Parse(text)
+}
+
+// Type returns the type of the parser.
+func (p XML) Type() string {
+	return "xml_parser"
+}
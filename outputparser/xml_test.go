@@ -0,0 +1,64 @@
+package outputparser_test
+
+import (
+	"testing"
+
+	"github.com/devmiahub/langchaingo/outputparser"
+	"github.com/stretchr/testify/require"
+)
+
+func TestXML(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		text string
+		want map[string]any
+	}{
+		{
+			name: "tree",
+			text: "<book><title>Dune</title><author><name>Frank Herbert</name></author></book>",
+			want: map[string]any{"book": map[string]any{
+				"title":  "Dune",
+				"author": map[string]any{"name": "Frank Herbert"},
+			}},
+		},
+		{
+			name: "repeated tags",
+			text: "<movies>\n  <movie>Alien</movie>\n  <movie>Heat</movie>\n  <movie>Ran</movie>\n</movies>",
+			want: map[string]any{"movies": map[string]any{"movie": []any{"Alien", "Heat", "Ran"}}},
+		},
+		{
+			name: "code block with text around",
+			text: "Sure!\n```xml\n<?xml version=\"1.0\"?>\n<answer score=\"1\">42</answer>\n```\nAnything else?",
+			want: map[string]any{"answer": "42"},
+		},
+		{
+			name: "escaped text",
+			text: "<expr>a &lt; b &amp;&amp; c</expr>",
+			want: map[string]any{"expr": "a < b && c"},
+		},
+		{
+			name: "empty tag",
+			text: "<result><items/></result>",
+			want: map[string]any{"result": map[string]any{"items": ""}},
+		},
+	}
+	parser := outputparser.NewXML("book", "title")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parser.Parse(tt.text)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+
+	for _, text := range []string{"no tags here", "<book><title>Dune</book>", "<book>"} {
+		_, err := parser.Parse(text)
+		require.ErrorAs(t, err, &outputparser.ParseError{}, text)
+	}
+
+	require.Contains(t, parser.GetFormatInstructions(), "book, title")
+	require.Contains(t, outputparser.NewXML().GetFormatInstructions(), "XML")
+}
@@ -0,0 +1,84 @@
+package outputparser
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/devmiahub/langchaingo/internal/codeblock"
+	"github.com/devmiahub/langchaingo/jsonschema"
+	"github.com/devmiahub/langchaingo/llms"
+	"github.com/devmiahub/langchaingo/schema"
+	"sigs.k8s.io/yaml"
+)
+
+//nolint:lll
+const _yamlFormatInstructions = `The output should be formatted as a YAML instance that conforms to the JSON schema below.
+
+As an example, for the schema
+` + "```" + `
+{"type": "object", "properties": {"foo": {"type": "array", "items": {"type": "string"}, "description": "a list of strings"}}, "required": ["foo"]}
+` + "```" + `
+the object {"foo": ["bar", "baz"]} is the well-formatted YAML instance:
+` + "```yaml\nfoo:\n  - bar\n  - baz\n```" + `
+
+Here is the output schema:
+` + "```\n%s\n```\n\nMake sure to always enclose the YAML output in triple backticks (```)."
+
+// YAML is an output parser used to parse the YAML output of an LLM into T. The
+// YAML is decoded with the json tags of T, and validated against the JSON
+// schema derived from T with jsonschema.For, which also makes the format
+// instructions.
+type YAML[T any] struct {
+	schema *jsonschema.Definition
+}
+
+// NewYAML creates a new YAML parser for the type T.
+func NewYAML[T any]() (YAML[T], error) {
+	definition, err := jsonschema.For[T]()
+	if err != nil {
+		return YAML[T]{}, err
+	}
+	return YAML[T]{schema: definition}, nil
+}
+
+// Statically assert that YAML implements the OutputParser interface.
+var _ schema.OutputParser[any] = YAML[any]{}
+
+// GetFormatInstructions returns instructions on the expected output format.
+func (p YAML[T]) GetFormatInstructions() string {
+	definition, err := json.Marshal(p.schema)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf(_yamlFormatInstructions, definition)
+}
+
+// Parse parses the YAML of the output, which may be in a fenced code block,
+// into T.
+func (p YAML[T]) Parse(text string) (T, error) {
+	var target T
+
+	data, err := yaml.YAMLToJSON([]byte(codeblock.Extract(text, "yaml", "yml")))
+	if err != nil {
+		return target, ParseError{Text: text, Reason: fmt.Sprintf("invalid YAML: %s", err)}
+	}
+	if p.schema != nil {
+		if err := p.schema.ValidateJSON(data); err != nil {
+			return target, ParseError{Text: text, Reason: err.Error()}
+		}
+	}
+	if err := json.Unmarshal(data, &target); err != nil {
+		return target, ParseError{Text: text, Reason: fmt.Sprintf("could not decode YAML: %s", err)}
+	}
+	return target, nil
+}
+
+// ParseWithPrompt does the same as Parse.
+func (p YAML[T]) ParseWithPrompt(text string, _ llms.PromptValue) (T, error) {
+	return p.Parse(text)
+}
+
+// Type returns the type of the parser.
+func (p YAML[T]) Type() string {
+	return "yaml_parser"
+}
@@ -0,0 +1,48 @@
+package outputparser_test
+
+import (
+	"testing"
+
+	"github.com/devmiahub/langchaingo/outputparser"
+	"github.com/stretchr/testify/require"
+)
+
+type yamlRecipe struct {
+	Name        string   `json:"name" description:"name of the dish"`
+	Servings    int      `json:"servings"`
+	Ingredients []string `json:"ingredients"`
+	Vegan       bool     `json:"vegan,omitempty"`
+}
+
+func TestYAML(t *testing.T) {
+	t.Parallel()
+	parser, err := outputparser.NewYAML[yamlRecipe]()
+	require.NoError(t, err)
+
+	want := yamlRecipe{Name: "Pancakes", Servings: 4, Ingredients: []string{"flour", "milk"}}
+	for name, text := range map[string]string{
+		"plain":      "name: Pancakes\nservings: 4\ningredients:\n  - flour\n  - milk\n",
+		"code block": "Here you go:\n```yaml\nname: Pancakes\nservings: 4\ningredients: [flour, milk]\n```",
+		"yml block":  "```yml\nname: Pancakes\nservings: 4\ningredients:\n- flour\n- milk\n```",
+	} {
+		got, err := parser.Parse(text)
+		require.NoError(t, err, name)
+		require.Equal(t, want, got, name)
+	}
+
+	for _, text := range []string{
+		"name: Pancakes\nservings: [4\n",
+		"name: Pancakes\ningredients: []\n",
+		"name: Pancakes\nservings: four\ningredients: []\n",
+	} {
+		_, err := parser.Parse(text)
+		require.ErrorAs(t, err, &outputparser.ParseError{}, text)
+	}
+
+	instructions := parser.GetFormatInstructions()
+	require.Contains(t, instructions, "YAML")
+	require.Contains(t, instructions, `"name of the dish"`)
+
+	_, err = outputparser.NewYAML[chan int]()
+	require.Error(t, err)
+}
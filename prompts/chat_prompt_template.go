@@ -14,9 +14,10 @@ type ChatPromptTemplate struct {
 }
 
 var (
-	_ Formatter        = ChatPromptTemplate{}
-	_ MessageFormatter = ChatPromptTemplate{}
-	_ FormatPrompter   = ChatPromptTemplate{}
+	_ Formatter               = ChatPromptTemplate{}
+	_ MessageFormatter        = ChatPromptTemplate{}
+	_ MessageContentFormatter = ChatPromptTemplate{}
+	_ FormatPrompter          = ChatPromptTemplate{}
 )
 
 // FormatPrompt formats the messages into a chat prompt value.
@@ -19,11 +19,12 @@
 //
 // # Template Formats
 //
-// Three template formats are supported:
+// Four template formats are supported:
 //
 //   - Go Templates (default): `{{ .variable }}` - Native Go text/template with sprig functions
 //   - Jinja2: `{{ variable }}` - Python-style templates with filters and logic
 //   - F-Strings: `{variable}` - Simple Python-style variable substitution
+//   - Mustache: `{{variable}}` - Logic-less templates with sections and partials
 //
 // Example using different formats:
 //
@@ -47,6 +48,24 @@
 //		TemplateFormat: prompts.TemplateFormatFString,
 //	}
 //
+// The input variables can also be inferred from the template, in any format:
+//
+//	template, err := prompts.ParsePromptTemplate(
+//		"Hello {name}!",
+//		prompts.TemplateFormatFString,
+//	)
+//
+// # Validation
+//
+// The Validate methods of PromptTemplate, ChatPromptTemplate and FewShotPrompt
+// report the variables used by the templates but not declared, and the input
+// and partial variables never used, before the prompt is formatted:
+//
+//	var variablesErr *prompts.VariablesError
+//	if errors.As(chatTemplate.Validate(), &variablesErr) {
+//		fmt.Println(variablesErr.Missing, variablesErr.Unused)
+//	}
+//
 // # Chat Prompts
 //
 // For conversational AI, use ChatPromptTemplate:
@@ -66,6 +85,22 @@
 //		"question": "What is the capital of France?",
 //	})
 //
+// Images, binary data and files are templated with
+// MessageContentPromptTemplate, and FormatMessageContents returns messages
+// that can be passed to llms.Model.GenerateContent:
+//
+//	chatTemplate := prompts.NewChatPromptTemplate([]prompts.MessageFormatter{
+//		prompts.NewMessageContentPromptTemplate(llms.ChatMessageTypeHuman,
+//			prompts.NewTextPartTemplate("What is in this image?", nil),
+//			prompts.NewImageURLPartTemplate("{{.url}}", []string{"url"}),
+//		),
+//	})
+//
+//	messages, err := chatTemplate.FormatMessageContents(map[string]any{
+//		"url": "https://example.com/cat.png",
+//	})
+//	resp, err := model.GenerateContent(ctx, messages)
+//
 // # Partial Variables
 //
 // Pre-fill some template variables while leaving others for runtime:
@@ -128,6 +163,21 @@
 //		InputVariables: []string{"question"},
 //	}
 //
+// Instead of fixed examples, an ExampleSelector can choose them for each
+// input: LengthBasedExampleSelector fits the examples into a token budget,
+// SemanticSimilarityExampleSelector picks the examples most similar to the
+// input from a vector store and MaxMarginalRelevanceExampleSelector picks
+// similar but diverse examples.
+//
+// # Prompt Files
+//
+// Prompts can be stored in versioned JSON or YAML files, compatible with
+// LangChain's prompt files where possible:
+//
+//	prompt, err := prompts.LoadPrompt(os.DirFS("prompts"), "joke.yaml")
+//
+//	err = prompts.SavePrompt("prompts/joke.yaml", template)
+//
 // # Performance Considerations
 //
 // - Go templates are fastest and recommended for production
@@ -0,0 +1,90 @@
+package prompts
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/devmiahub/langchaingo/llms"
+)
+
+// LengthBasedExampleSelector selects examples in order until their length,
+// together with the length of the input, would exceed the maximum length. It
+// is equivalent to LengthBasedExampleSelector in langchain.
+type LengthBasedExampleSelector struct {
+	// ExamplePrompt is used to format the examples to measure them.
+	ExamplePrompt PromptTemplate
+	// MaxLength is the maximum length of the examples and the input.
+	MaxLength int
+	// TextLength measures a text.
+	TextLength func(string) int
+
+	mu       sync.RWMutex
+	examples []map[string]string
+}
+
+var _ ExampleSelector = &LengthBasedExampleSelector{}
+
+// NewLengthBasedExampleSelector creates a length-based example selector. By
+// default the length of a text is its number of tokens counted with
+// llms.CountTokens.
+func NewLengthBasedExampleSelector(
+	examplePrompt PromptTemplate,
+	examples []map[string]string,
+	options ...ExampleSelectorOption,
+) *LengthBasedExampleSelector {
+	opts := applyExampleSelectorOptions(options...)
+	textLength := opts.textLength
+	if textLength == nil {
+		textLength = func(text string) int { return llms.CountTokens(opts.model, text) }
+	}
+
+	return &LengthBasedExampleSelector{
+		ExamplePrompt: examplePrompt,
+		MaxLength:     opts.maxLength,
+		TextLength:    textLength,
+		examples:      slices.Clone(examples),
+	}
+}
+
+// AddExample adds an example to the ones to select from.
+func (s *LengthBasedExampleSelector) AddExample(example map[string]string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.examples = append(s.examples, example)
+	return ""
+}
+
+// SelectExamples returns the examples that fit in the maximum length along
+// with the input. Examples that can not be formatted are skipped.
+func (s *LengthBasedExampleSelector) SelectExamples(inputVariables map[string]string) []map[string]string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	inputs := make([]string, 0, len(inputVariables))
+	for _, key := range slices.Sorted(maps.Keys(inputVariables)) {
+		inputs = append(inputs, inputVariables[key])
+	}
+	remaining := s.MaxLength - s.TextLength(strings.Join(inputs, " "))
+
+	selected := make([]map[string]string, 0, len(s.examples))
+	for _, example := range s.examples {
+		values := make(map[string]any, len(example))
+		for k, v := range example {
+			values[k] = v
+		}
+		text, err := s.ExamplePrompt.Format(values)
+		if err != nil {
+			continue
+		}
+
+		remaining -= s.TextLength(text)
+		if remaining < 0 {
+			break
+		}
+		selected = append(selected, example)
+	}
+	return selected
+}
@@ -0,0 +1,113 @@
+package prompts
+
+import "github.com/devmiahub/langchaingo/vectorstores"
+
+const (
+	_defaultMaxLength  = 2048
+	_defaultSelectK    = 4
+	_defaultFetchK     = 20
+	_defaultLambdaMult = 0.5
+)
+
+// exampleSelectorOptions holds the options of the example selectors.
+type exampleSelectorOptions struct {
+	maxLength          int
+	model              string
+	textLength         func(string) int
+	k                  int
+	fetchK             int
+	lambdaMult         float32
+	inputKeys          []string
+	exampleKeys        []string
+	vectorStoreOptions []vectorstores.Option
+}
+
+// ExampleSelectorOption is a function for creating an example selector with
+// other than the default values.
+type ExampleSelectorOption func(*exampleSelectorOptions)
+
+func applyExampleSelectorOptions(options ...ExampleSelectorOption) exampleSelectorOptions {
+	opts := exampleSelectorOptions{
+		maxLength:  _defaultMaxLength,
+		k:          _defaultSelectK,
+		fetchK:     _defaultFetchK,
+		lambdaMult: _defaultLambdaMult,
+	}
+	for _, option := range options {
+		option(&opts)
+	}
+	return opts
+}
+
+// WithMaxLength sets the maximum length, in tokens, of the examples and the
+// input selected by a length-based example selector. Defaults to 2048.
+func WithMaxLength(maxLength int) ExampleSelectorOption {
+	return func(o *exampleSelectorOptions) {
+		o.maxLength = maxLength
+	}
+}
+
+// WithTokenModel sets the model whose tokenizer is used by a length-based
+// example selector to measure the examples.
+func WithTokenModel(model string) ExampleSelectorOption {
+	return func(o *exampleSelectorOptions) {
+		o.model = model
+	}
+}
+
+// WithTextLength sets the function measuring the length of the examples and
+// the input for a length-based example selector, instead of counting their
+// tokens with llms.CountTokens.
+func WithTextLength(textLength func(string) int) ExampleSelectorOption {
+	return func(o *exampleSelectorOptions) {
+		o.textLength = textLength
+	}
+}
+
+// WithK sets the number of examples selected by the semantic similarity and
+// MMR example selectors. Defaults to 4.
+func WithK(k int) ExampleSelectorOption {
+	return func(o *exampleSelectorOptions) {
+		o.k = k
+	}
+}
+
+// WithFetchK sets the number of candidate examples the MMR example selector
+// fetches before selecting diverse ones among them. Defaults to 20.
+func WithFetchK(fetchK int) ExampleSelectorOption {
+	return func(o *exampleSelectorOptions) {
+		o.fetchK = fetchK
+	}
+}
+
+// WithLambdaMult sets the trade-off between similarity to the input, at 1,
+// and diversity, at 0, of the MMR example selector. Defaults to 0.5.
+func WithLambdaMult(lambdaMult float32) ExampleSelectorOption {
+	return func(o *exampleSelectorOptions) {
+		o.lambdaMult = lambdaMult
+	}
+}
+
+// WithInputKeys sets the keys of the examples and of the input that are
+// compared to select examples. Defaults to all keys.
+func WithInputKeys(keys ...string) ExampleSelectorOption {
+	return func(o *exampleSelectorOptions) {
+		o.inputKeys = keys
+	}
+}
+
+// WithExampleKeys sets the keys of the selected examples that are returned.
+// Defaults to all keys.
+func WithExampleKeys(keys ...string) ExampleSelectorOption {
+	return func(o *exampleSelectorOptions) {
+		o.exampleKeys = keys
+	}
+}
+
+// WithVectorStoreOptions sets the options used when adding examples to and
+// searching the vector store of the semantic similarity example selector.
+func WithVectorStoreOptions(options ...vectorstores.Option) ExampleSelectorOption {
+	return func(o *exampleSelectorOptions) {
+		o.vectorStoreOptions = options
+	}
+}
@@ -0,0 +1,342 @@
+package prompts
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/devmiahub/langchaingo/embeddings"
+	"github.com/devmiahub/langchaingo/schema"
+	"github.com/devmiahub/langchaingo/vectorstores"
+)
+
+// SemanticSimilarityExampleSelector selects the examples most similar to the
+// input, using a vector store. It is equivalent to
+// SemanticSimilarityExampleSelector in langchain.
+//
+// The ExampleSelector methods can not report errors: they return no examples
+// when the vector store fails. Use SelectExamplesContext and
+// AddExampleContext to get the errors.
+type SemanticSimilarityExampleSelector struct {
+	// VectorStore stores the examples.
+	VectorStore vectorstores.VectorStore
+	// K is the number of examples selected.
+	K int
+	// InputKeys are the keys of the examples and the input compared. If empty
+	// all keys are compared.
+	InputKeys []string
+	// ExampleKeys are the keys of the examples returned. If empty all keys are
+	// returned.
+	ExampleKeys []string
+	// VectorStoreOptions are used when adding and searching examples.
+	VectorStoreOptions []vectorstores.Option
+}
+
+var _ ExampleSelector = &SemanticSimilarityExampleSelector{}
+
+// NewSemanticSimilarityExampleSelector creates a semantic similarity example
+// selector and adds the examples to the vector store.
+func NewSemanticSimilarityExampleSelector(
+	ctx context.Context,
+	vectorStore vectorstores.VectorStore,
+	examples []map[string]string,
+	options ...ExampleSelectorOption,
+) (*SemanticSimilarityExampleSelector, error) {
+	opts := applyExampleSelectorOptions(options...)
+	s := &SemanticSimilarityExampleSelector{
+		VectorStore:        vectorStore,
+		K:                  opts.k,
+		InputKeys:          opts.inputKeys,
+		ExampleKeys:        opts.exampleKeys,
+		VectorStoreOptions: opts.vectorStoreOptions,
+	}
+
+	if len(examples) == 0 {
+		return s, nil
+	}
+	docs := make([]schema.Document, 0, len(examples))
+	for _, example := range examples {
+		docs = append(docs, exampleDocument(example, s.InputKeys))
+	}
+	if _, err := vectorStore.AddDocuments(ctx, docs, s.VectorStoreOptions...); err != nil {
+		return nil, fmt.Errorf("adding examples: %w", err)
+	}
+	return s, nil
+}
+
+// AddExample adds an example to the vector store and returns its id, or an
+// empty string if it could not be added.
+func (s *SemanticSimilarityExampleSelector) AddExample(example map[string]string) string {
+	id, _ := s.AddExampleContext(context.Background(), example)
+	return id
+}
+
+// AddExampleContext adds an example to the vector store and returns its id.
+func (s *SemanticSimilarityExampleSelector) AddExampleContext(
+	ctx context.Context, example map[string]string,
+) (string, error) {
+	ids, err := s.VectorStore.AddDocuments(ctx, []schema.Document{exampleDocument(example, s.InputKeys)},
+		s.VectorStoreOptions...)
+	if err != nil {
+		return "", err
+	}
+	if len(ids) == 0 {
+		return "", nil
+	}
+	return ids[0], nil
+}
+
+// SelectExamples returns the K examples most similar to the input, or no
+// examples if the vector store fails.
+func (s *SemanticSimilarityExampleSelector) SelectExamples(inputVariables map[string]string) []map[string]string {
+	examples, _ := s.SelectExamplesContext(context.Background(), inputVariables)
+	return examples
+}
+
+// SelectExamplesContext returns the K examples most similar to the input.
+func (s *SemanticSimilarityExampleSelector) SelectExamplesContext(
+	ctx context.Context, inputVariables map[string]string,
+) ([]map[string]string, error) {
+	docs, err := s.VectorStore.SimilaritySearch(ctx, exampleText(inputVariables, s.InputKeys), s.K,
+		s.VectorStoreOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	examples := make([]map[string]string, 0, len(docs))
+	for _, doc := range docs {
+		examples = append(examples, documentExample(doc, s.ExampleKeys))
+	}
+	return examples, nil
+}
+
+// MaxMarginalRelevanceExampleSelector selects examples similar to the input
+// while being diverse, using maximal marginal relevance. The examples and
+// their embeddings are kept in memory. It is equivalent to
+// MaxMarginalRelevanceExampleSelector in langchain.
+//
+// The ExampleSelector methods can not report errors: they return no examples
+// when the embedder fails. Use SelectExamplesContext and AddExampleContext to
+// get the errors.
+type MaxMarginalRelevanceExampleSelector struct {
+	// Embedder embeds the examples and the input.
+	Embedder embeddings.Embedder
+	// K is the number of examples selected.
+	K int
+	// FetchK is the number of examples most similar to the input among which
+	// the K examples are selected.
+	FetchK int
+	// LambdaMult is the trade-off between similarity to the input, at 1, and
+	// diversity, at 0.
+	LambdaMult float32
+	// InputKeys are the keys of the examples and the input compared. If empty
+	// all keys are compared.
+	InputKeys []string
+	// ExampleKeys are the keys of the examples returned. If empty all keys are
+	// returned.
+	ExampleKeys []string
+
+	mu       sync.RWMutex
+	examples []map[string]string
+	vectors  [][]float32
+}
+
+var _ ExampleSelector = &MaxMarginalRelevanceExampleSelector{}
+
+// NewMaxMarginalRelevanceExampleSelector creates a maximal marginal relevance
+// example selector and embeds the examples.
+func NewMaxMarginalRelevanceExampleSelector(
+	ctx context.Context,
+	embedder embeddings.Embedder,
+	examples []map[string]string,
+	options ...ExampleSelectorOption,
+) (*MaxMarginalRelevanceExampleSelector, error) {
+	opts := applyExampleSelectorOptions(options...)
+	s := &MaxMarginalRelevanceExampleSelector{
+		Embedder:    embedder,
+		K:           opts.k,
+		FetchK:      opts.fetchK,
+		LambdaMult:  opts.lambdaMult,
+		InputKeys:   opts.inputKeys,
+		ExampleKeys: opts.exampleKeys,
+	}
+
+	if len(examples) == 0 {
+		return s, nil
+	}
+	texts := make([]string, 0, len(examples))
+	for _, example := range examples {
+		texts = append(texts, exampleText(example, s.InputKeys))
+	}
+	vectors, err := embedder.EmbedDocuments(ctx, texts)
+	if err != nil {
+		return nil, fmt.Errorf("embedding examples: %w", err)
+	}
+	s.examples = slices.Clone(examples)
+	s.vectors = vectors
+	return s, nil
+}
+
+// AddExample embeds and adds an example. It returns an empty string.
+func (s *MaxMarginalRelevanceExampleSelector) AddExample(example map[string]string) string {
+	_, _ = s.AddExampleContext(context.Background(), example)
+	return ""
+}
+
+// AddExampleContext embeds and adds an example. It returns an empty string.
+func (s *MaxMarginalRelevanceExampleSelector) AddExampleContext(
+	ctx context.Context, example map[string]string,
+) (string, error) {
+	vectors, err := s.Embedder.EmbedDocuments(ctx, []string{exampleText(example, s.InputKeys)})
+	if err != nil {
+		return "", err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.examples = append(s.examples, example)
+	s.vectors = append(s.vectors, vectors[0])
+	return "", nil
+}
+
+// SelectExamples returns K examples similar to the input and diverse, or no
+// examples if the embedder fails.
+func (s *MaxMarginalRelevanceExampleSelector) SelectExamples(inputVariables map[string]string) []map[string]string {
+	examples, _ := s.SelectExamplesContext(context.Background(), inputVariables)
+	return examples
+}
+
+// SelectExamplesContext returns K examples similar to the input and diverse.
+func (s *MaxMarginalRelevanceExampleSelector) SelectExamplesContext(
+	ctx context.Context, inputVariables map[string]string,
+) ([]map[string]string, error) {
+	query, err := s.Embedder.EmbedQuery(ctx, exampleText(inputVariables, s.InputKeys))
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	// Fetch the FetchK examples most similar to the input.
+	similarities := make([]float32, len(s.vectors))
+	for i, vector := range s.vectors {
+		if similarities[i], err = embeddings.CosineSimilarity(query, vector); err != nil {
+			return nil, err
+		}
+	}
+	candidates := make([]int, len(s.vectors))
+	for i := range candidates {
+		candidates[i] = i
+	}
+	slices.SortStableFunc(candidates, func(a, b int) int {
+		switch {
+		case similarities[a] > similarities[b]:
+			return -1
+		case similarities[a] < similarities[b]:
+			return 1
+		default:
+			return 0
+		}
+	})
+	if s.FetchK > 0 && len(candidates) > s.FetchK {
+		candidates = candidates[:s.FetchK]
+	}
+
+	selected, err := maximalMarginalRelevance(candidates, similarities, s.vectors, s.K, s.LambdaMult)
+	if err != nil {
+		return nil, err
+	}
+
+	examples := make([]map[string]string, 0, len(selected))
+	for _, i := range selected {
+		examples = append(examples, filterExample(s.examples[i], s.ExampleKeys))
+	}
+	return examples, nil
+}
+
+// maximalMarginalRelevance selects k of the candidates, each maximizing
+// lambdaMult times its similarity to the query minus (1 - lambdaMult) times
+// its highest similarity to the candidates already selected.
+func maximalMarginalRelevance(
+	candidates []int, similarities []float32, vectors [][]float32, k int, lambdaMult float32,
+) ([]int, error) {
+	selected := make([]int, 0, k)
+	remaining := slices.Clone(candidates)
+	for len(selected) < k && len(remaining) > 0 {
+		best, bestScore := -1, float32(0)
+		for j, candidate := range remaining {
+			var redundancy float32
+			for i, chosen := range selected {
+				similarity, err := embeddings.CosineSimilarity(vectors[candidate], vectors[chosen])
+				if err != nil {
+					return nil, err
+				}
+				if i == 0 || similarity > redundancy {
+					redundancy = similarity
+				}
+			}
+			score := lambdaMult*similarities[candidate] - (1-lambdaMult)*redundancy
+			if best < 0 || score > bestScore {
+				best, bestScore = j, score
+			}
+		}
+		selected = append(selected, remaining[best])
+		remaining = slices.Delete(remaining, best, best+1)
+	}
+	return selected, nil
+}
+
+// exampleText joins the values of the keys of the example, or of all its
+// keys in sorted order if keys is empty.
+func exampleText(example map[string]string, keys []string) string {
+	if len(keys) == 0 {
+		keys = slices.Sorted(maps.Keys(example))
+	}
+	values := make([]string, 0, len(keys))
+	for _, key := range keys {
+		values = append(values, example[key])
+	}
+	return strings.Join(values, " ")
+}
+
+func exampleDocument(example map[string]string, inputKeys []string) schema.Document {
+	metadata := make(map[string]any, len(example))
+	for k, v := range example {
+		metadata[k] = v
+	}
+	return schema.Document{
+		PageContent: exampleText(example, inputKeys),
+		Metadata:    metadata,
+	}
+}
+
+func documentExample(doc schema.Document, exampleKeys []string) map[string]string {
+	example := make(map[string]string, len(doc.Metadata))
+	for k, v := range doc.Metadata {
+		if s, ok := v.(string); ok {
+			example[k] = s
+		} else {
+			example[k] = fmt.Sprint(v)
+		}
+	}
+	return filterExample(example, exampleKeys)
+}
+
+// filterExample returns the example with only the keys given, or the example
+// if no keys are given.
+func filterExample(example map[string]string, keys []string) map[string]string {
+	if len(keys) == 0 {
+		return example
+	}
+	filtered := make(map[string]string, len(keys))
+	for _, key := range keys {
+		if v, ok := example[key]; ok {
+			filtered[key] = v
+		}
+	}
+	return filtered
+}
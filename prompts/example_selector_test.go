@@ -0,0 +1,159 @@
+package prompts
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/devmiahub/langchaingo/schema"
+	"github.com/devmiahub/langchaingo/vectorstores"
+	"github.com/stretchr/testify/require"
+)
+
+func wordCount(text string) int {
+	return len(strings.Fields(text))
+}
+
+func TestLengthBasedExampleSelector(t *testing.T) {
+	t.Parallel()
+
+	examplePrompt := NewPromptTemplate("{{.input}} -> {{.output}}", []string{"input", "output"})
+	examples := []map[string]string{
+		{"input": "happy", "output": "sad"},
+		{"input": "tall", "output": "short"},
+		{"input": "very energetic", "output": "very lethargic"},
+	}
+	s := NewLengthBasedExampleSelector(examplePrompt, examples, WithMaxLength(10), WithTextLength(wordCount))
+
+	// Each of the first two examples is 3 words long, the third 5 words.
+	require.Equal(t, examples[:2], s.SelectExamples(map[string]string{"adjective": "big"}))
+	require.Equal(t, examples[:1], s.SelectExamples(map[string]string{"adjective": "big and bright and bold"}))
+	require.Empty(t, s.SelectExamples(map[string]string{"adjective": strings.Repeat("word ", 10)}))
+
+	s.MaxLength = 20
+	s.AddExample(map[string]string{"input": "windy", "output": "calm"})
+	require.Len(t, s.SelectExamples(map[string]string{"adjective": "big"}), 4)
+}
+
+// wordStore is a vector store scoring documents by the fraction of the words
+// of the query they contain.
+type wordStore struct {
+	docs []schema.Document
+}
+
+func (s *wordStore) AddDocuments(_ context.Context, docs []schema.Document, _ ...vectorstores.Option) ([]string, error) {
+	ids := make([]string, 0, len(docs))
+	for range docs {
+		ids = append(ids, strings.Repeat("x", len(s.docs)+len(ids)+1))
+	}
+	s.docs = append(s.docs, docs...)
+	return ids, nil
+}
+
+func (s *wordStore) SimilaritySearch(
+	_ context.Context, query string, numDocuments int, _ ...vectorstores.Option,
+) ([]schema.Document, error) {
+	words := strings.Fields(strings.ToLower(query))
+	docs := make([]schema.Document, 0, len(s.docs))
+	for _, doc := range s.docs {
+		matches := 0
+		for _, word := range words {
+			if strings.Contains(strings.ToLower(doc.PageContent), word) {
+				matches++
+			}
+		}
+		doc.Score = float32(matches) / float32(len(words))
+		docs = append(docs, doc)
+	}
+	sort.SliceStable(docs, func(i, j int) bool { return docs[i].Score > docs[j].Score })
+	if len(docs) > numDocuments {
+		docs = docs[:numDocuments]
+	}
+	return docs, nil
+}
+
+func TestSemanticSimilarityExampleSelector(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	examples := []map[string]string{
+		{"question": "What is the capital of France?", "answer": "Paris"},
+		{"question": "How much is two plus two?", "answer": "Four"},
+		{"question": "What is the capital of Italy?", "answer": "Rome"},
+	}
+	s, err := NewSemanticSimilarityExampleSelector(ctx, &wordStore{}, examples,
+		WithK(2), WithInputKeys("question"), WithExampleKeys("question", "answer"))
+	require.NoError(t, err)
+
+	selected := s.SelectExamples(map[string]string{"question": "capital of Spain"})
+	require.Equal(t, []map[string]string{examples[0], examples[2]}, selected)
+
+	// Answers are not compared since only the question is an input key.
+	id := s.AddExample(map[string]string{"question": "How much is three plus three?", "answer": "capital"})
+	require.NotEmpty(t, id)
+	selected, err = s.SelectExamplesContext(ctx, map[string]string{"question": "how much is one plus one"})
+	require.NoError(t, err)
+	require.Equal(t, []map[string]string{
+		examples[1],
+		{"question": "How much is three plus three?", "answer": "capital"},
+	}, selected)
+}
+
+// vectorEmbedder embeds the texts with fixed vectors.
+type vectorEmbedder map[string][]float32
+
+func (e vectorEmbedder) EmbedDocuments(_ context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, 0, len(texts))
+	for _, text := range texts {
+		vectors = append(vectors, e[text])
+	}
+	return vectors, nil
+}
+
+func (e vectorEmbedder) EmbedQuery(_ context.Context, text string) ([]float32, error) {
+	return e[text], nil
+}
+
+func TestMaxMarginalRelevanceExampleSelector(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	embedder := vectorEmbedder{
+		"cat":     {1, 0, 0},
+		"kitten":  {0.99, 0.1, 0},
+		"tiger":   {0.8, 0, 0.6},
+		"rocket":  {0, -1, 0},
+		"a pet":   {1, 0.05, 0.1},
+		"unknown": {0, 1, 0},
+	}
+	examples := []map[string]string{
+		{"input": "cat"},
+		{"input": "kitten"},
+		{"input": "tiger"},
+		{"input": "rocket"},
+	}
+
+	s, err := NewMaxMarginalRelevanceExampleSelector(ctx, embedder, examples, WithK(2))
+	require.NoError(t, err)
+
+	// The kitten is the second most similar example but is redundant with the
+	// cat, the tiger is picked for diversity.
+	require.Equal(t, []map[string]string{examples[0], examples[2]},
+		s.SelectExamples(map[string]string{"input": "a pet"}))
+
+	s.LambdaMult = 1
+	require.Equal(t, []map[string]string{examples[0], examples[1]},
+		s.SelectExamples(map[string]string{"input": "a pet"}))
+
+	s.FetchK = 1
+	require.Equal(t, []map[string]string{examples[0]},
+		s.SelectExamples(map[string]string{"input": "a pet"}))
+
+	_, err = s.AddExampleContext(ctx, map[string]string{"input": "unknown"})
+	require.NoError(t, err)
+	s.FetchK = 0
+	selected, err := s.SelectExamplesContext(ctx, map[string]string{"input": "unknown"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"input": "unknown"}, selected[0])
+}
@@ -1,6 +1,9 @@
 package fstring
 
-import "errors"
+import (
+	"errors"
+	"slices"
+)
 
 var (
 	ErrEmptyExpression       = errors.New("empty expression not allowed")
@@ -18,3 +21,15 @@ func Format(template string, values map[string]any) (string, error) {
 	}
 	return string(p.result), nil
 }
+
+// Variables returns the names of the variables used by the given f-string
+// template, sorted and without duplicates.
+func Variables(template string) ([]string, error) {
+	p := newParser(template, nil)
+	p.collect = true
+	if err := p.parse(); err != nil {
+		return nil, err
+	}
+	slices.Sort(p.variables)
+	return slices.Compact(p.variables), nil
+}
@@ -46,3 +46,31 @@ func TestFormat(t *testing.T) {
 		})
 	}
 }
+
+func TestVariables(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		format  string
+		want    []string
+		wantErr string
+	}{
+		{"none", "hello {{world}}", nil, ""},
+		{"sorted", "{b} and { a } and {b}", []string{"a", "b"}, ""},
+		{"empty", "{}", nil, "empty expression not allowed"},
+		{"unclosed", "a {", nil, "single '{' is not allowed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := Variables(tt.format)
+			if (err != nil) != (tt.wantErr != "") {
+				t.Fatalf("Variables() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Variables() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
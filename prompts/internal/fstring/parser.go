@@ -11,6 +11,10 @@ type parser struct {
 	result []rune
 	idx    int
 	values map[string]any
+	// collect makes the parser collect the variable names instead of
+	// interpolating them.
+	collect   bool
+	variables []string
 }
 
 func newParser(s string, values map[string]any) *parser {
@@ -41,6 +45,10 @@ func (r *parser) parse() error {
 		if valName == "" {
 			return ErrEmptyExpression
 		}
+		if r.collect {
+			r.variables = append(r.variables, valName)
+			continue
+		}
 		val, ok := r.values[valName]
 		if !ok {
 			return fmt.Errorf("%w: %s", ErrArgsNotDefined, valName)
@@ -0,0 +1,7 @@
+// Package mustache contains template format with mustache.
+//
+// It supports variables, dotted names, the implicit iterator, sections,
+// inverted sections, comments and partials. Values are not HTML-escaped, so
+// {{name}}, {{{name}}} and {{&name}} render the same. Set delimiter tags and
+// lambdas are not supported.
+package mustache
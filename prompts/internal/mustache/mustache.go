@@ -0,0 +1,295 @@
+package mustache
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+const (
+	// _partialExtension is tried when a partial is not found by its name.
+	_partialExtension = ".mustache"
+	// _maxPartialDepth limits the nesting of partials, which may be recursive.
+	_maxPartialDepth = 100
+)
+
+var (
+	ErrUnclosedTag              = errors.New("unclosed tag")
+	ErrEmptyTag                 = errors.New("empty tag")
+	ErrUnclosedSection          = errors.New("unclosed section")
+	ErrUnexpectedClosingTag     = errors.New("unexpected closing tag")
+	ErrSetDelimiterNotSupported = errors.New("set delimiter tags are not supported")
+	ErrPartialDepthExceeded     = errors.New("partials nested too deeply")
+)
+
+// Loader loads the partials of a template.
+type Loader interface {
+	Get(path string) (io.Reader, error)
+}
+
+// Format interpolates the given template with the given values by using
+// mustache. The partials are loaded with the loader.
+func Format(template string, values map[string]any, loader Loader) (string, error) {
+	nodes, err := parse(template)
+	if err != nil {
+		return "", err
+	}
+
+	r := &renderer{loader: loader, partials: map[string][]*node{}}
+	sb := new(strings.Builder)
+	if err := r.render(sb, nodes, []any{values}, 0); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// Variables returns the names of the variables the template uses from its
+// values, sorted. Only the first part of dotted names is returned, and the
+// variables inside sections, which may refer to the section value, are not
+// returned, unlike those inside inverted sections. Partials are not loaded.
+func Variables(template string) ([]string, error) {
+	nodes, err := parse(template)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	collectVariables(nodes, seen)
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+func collectVariables(nodes []*node, seen map[string]bool) {
+	for _, n := range nodes {
+		switch n.kind {
+		case variableNode, sectionNode, invertedNode:
+			if n.name != _implicitIterator {
+				seen[strings.Split(n.name, ".")[0]] = true
+			}
+		case textNode, partialNode:
+		}
+		// An inverted section is rendered in the enclosing context.
+		if n.kind == invertedNode {
+			collectVariables(n.children, seen)
+		}
+	}
+}
+
+type renderer struct {
+	loader   Loader
+	partials map[string][]*node
+}
+
+func (r *renderer) render(sb *strings.Builder, nodes []*node, stack []any, depth int) error {
+	for _, n := range nodes {
+		switch n.kind {
+		case textNode:
+			sb.WriteString(n.text)
+		case variableNode:
+			value, _ := lookup(stack, n.name)
+			sb.WriteString(toString(value))
+		case sectionNode:
+			if err := r.renderSection(sb, n, stack, depth); err != nil {
+				return err
+			}
+		case invertedNode:
+			if value, _ := lookup(stack, n.name); !truthy(value) {
+				if err := r.render(sb, n.children, stack, depth); err != nil {
+					return err
+				}
+			}
+		case partialNode:
+			if err := r.renderPartial(sb, n, stack, depth); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (r *renderer) renderSection(sb *strings.Builder, n *node, stack []any, depth int) error {
+	value, _ := lookup(stack, n.name)
+	if !truthy(value) {
+		return nil
+	}
+
+	v := reflect.ValueOf(value)
+	if v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+		for i := range v.Len() {
+			if err := r.render(sb, n.children, append(stack, v.Index(i).Interface()), depth); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return r.render(sb, n.children, append(stack, value), depth)
+}
+
+func (r *renderer) renderPartial(sb *strings.Builder, n *node, stack []any, depth int) error {
+	if depth >= _maxPartialDepth {
+		return fmt.Errorf("%w: %s", ErrPartialDepthExceeded, n.name)
+	}
+
+	nodes, ok := r.partials[n.name]
+	if !ok {
+		template, err := r.loadPartial(n.name)
+		if err != nil {
+			return fmt.Errorf("loading partial %q: %w", n.name, err)
+		}
+		nodes, err = parse(template)
+		if err != nil {
+			return fmt.Errorf("parsing partial %q: %w", n.name, err)
+		}
+		r.partials[n.name] = nodes
+	}
+
+	if n.indent == "" {
+		return r.render(sb, nodes, stack, depth+1)
+	}
+
+	// Each line of a standalone partial is indented by the whitespace before
+	// it.
+	partial := new(strings.Builder)
+	if err := r.render(partial, nodes, stack, depth+1); err != nil {
+		return err
+	}
+	for _, line := range strings.SplitAfter(partial.String(), "\n") {
+		if line != "" {
+			sb.WriteString(n.indent)
+			sb.WriteString(line)
+		}
+	}
+	return nil
+}
+
+func (r *renderer) loadPartial(name string) (string, error) {
+	if r.loader == nil {
+		return "", fs.ErrNotExist
+	}
+
+	reader, err := r.loader.Get(name)
+	if errors.Is(err, fs.ErrNotExist) && !strings.HasSuffix(name, _partialExtension) {
+		reader, err = r.loader.Get(name + _partialExtension)
+	}
+	if err != nil {
+		return "", err
+	}
+	if closer, ok := reader.(io.Closer); ok {
+		defer closer.Close()
+	}
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+// lookup resolves the name in the context stack. The first part of a dotted
+// name is looked up from the innermost context outwards, the other parts in
+// the value found.
+func lookup(stack []any, name string) (any, bool) {
+	if name == _implicitIterator {
+		return stack[len(stack)-1], true
+	}
+
+	parts := strings.Split(name, ".")
+	for i := len(stack) - 1; i >= 0; i-- {
+		value, ok := field(stack[i], parts[0])
+		if !ok {
+			continue
+		}
+		for _, part := range parts[1:] {
+			if value, ok = field(value, part); !ok {
+				return nil, false
+			}
+		}
+		return value, true
+	}
+	return nil, false
+}
+
+// field returns the value of the key of a map or of the exported field of a
+// struct.
+func field(context any, name string) (any, bool) {
+	switch c := context.(type) {
+	case map[string]any:
+		value, ok := c[name]
+		return value, ok
+	case map[string]string:
+		value, ok := c[name]
+		return value, ok
+	}
+
+	v := reflect.ValueOf(context)
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, false
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() { //nolint:exhaustive
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return nil, false
+		}
+		value := v.MapIndex(reflect.ValueOf(name).Convert(v.Type().Key()))
+		if !value.IsValid() {
+			return nil, false
+		}
+		return value.Interface(), true
+	case reflect.Struct:
+		value := v.FieldByName(name)
+		if !value.IsValid() || !value.CanInterface() {
+			return nil, false
+		}
+		return value.Interface(), true
+	default:
+		return nil, false
+	}
+}
+
+// truthy reports whether a section is rendered for the value. As in
+// langchain, nil, false, zero, empty strings and empty collections are
+// falsy.
+func truthy(value any) bool {
+	if value == nil {
+		return false
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() { //nolint:exhaustive
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		return v.Len() > 0
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return !v.IsZero()
+	case reflect.Pointer, reflect.Interface:
+		return !v.IsNil()
+	default:
+		return true
+	}
+}
+
+func toString(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,134 @@
+package mustache
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// mapLoader loads the partials from an in-memory filesystem.
+type mapLoader struct {
+	fsys fs.FS
+}
+
+func (l mapLoader) Get(path string) (io.Reader, error) {
+	return l.fsys.Open(path)
+}
+
+type user struct {
+	Name string
+	Tags []string
+}
+
+func TestFormat(t *testing.T) {
+	t.Parallel()
+
+	loader := mapLoader{fstest.MapFS{
+		"item.mustache": {Data: []byte("- {{name}}\n")},
+		"header":        {Data: []byte("Title: {{title}}\nBy: {{author}}\n")},
+		"recursive":     {Data: []byte("{{> recursive}}")},
+	}}
+
+	tests := []struct {
+		name     string
+		template string
+		values   map[string]any
+		want     string
+		wantErr  error
+	}{
+		{"variable", "Hello {{name}}!", map[string]any{"name": "world"}, "Hello world!", nil},
+		{"spaces", "Hello {{ name }}!", map[string]any{"name": "world"}, "Hello world!", nil},
+		{"missing", "Hello {{name}}!", map[string]any{}, "Hello !", nil},
+		{"not escaped", "{{a}} {{{a}}} {{&a}}", map[string]any{"a": "<b>"}, "<b> <b> <b>", nil},
+		{"number", "{{n}}", map[string]any{"n": 42}, "42", nil},
+		{"dotted", "{{user.Name}}", map[string]any{"user": user{Name: "Ada"}}, "Ada", nil},
+		{"dotted map", "{{a.b.c}}", map[string]any{"a": map[string]any{"b": map[string]string{"c": "x"}}}, "x", nil},
+		{"comment", "a{{! ignored }}b", nil, "ab", nil},
+		{
+			"section list", "{{#items}}[{{name}}]{{/items}}",
+			map[string]any{"items": []map[string]any{{"name": "a"}, {"name": "b"}}}, "[a][b]", nil,
+		},
+		{
+			"implicit iterator", "{{#user.Tags}}{{.}},{{/user.Tags}}",
+			map[string]any{"user": &user{Tags: []string{"x", "y"}}}, "x,y,", nil,
+		},
+		{"section outer lookup", "{{#on}}{{name}}{{/on}}", map[string]any{"on": true, "name": "n"}, "n", nil},
+		{"section falsy", "{{#on}}yes{{/on}}", map[string]any{"on": ""}, "", nil},
+		{"section empty list", "{{#items}}yes{{/items}}", map[string]any{"items": []string{}}, "", nil},
+		{"inverted", "{{^items}}none{{/items}}", map[string]any{"items": []string{}}, "none", nil},
+		{"inverted truthy", "{{^on}}none{{/on}}", map[string]any{"on": 1}, "", nil},
+		{
+			"standalone lines", "Items:\n  {{#items}}\n  * {{.}}\n  {{/items}}\nDone",
+			map[string]any{"items": []string{"a", "b"}}, "Items:\n  * a\n  * b\nDone", nil,
+		},
+		{
+			"partial", "{{#items}}{{> item}}{{/items}}",
+			map[string]any{"items": []map[string]any{{"name": "a"}, {"name": "b"}}}, "- a\n- b\n", nil,
+		},
+		{
+			"standalone partial", "Doc:\n  {{> header}}\nEnd",
+			map[string]any{"title": "T", "author": "A"}, "Doc:\n  Title: T\n  By: A\nEnd", nil,
+		},
+		{"missing partial", "{{> missing}}", nil, "", fs.ErrNotExist},
+		{"recursive partial", "{{> recursive}}", nil, "", ErrPartialDepthExceeded},
+		{"unclosed tag", "Hello {{name", nil, "", ErrUnclosedTag},
+		{"empty tag", "Hello {{ }}", nil, "", ErrEmptyTag},
+		{"unclosed section", "{{#a}}b", nil, "", ErrUnclosedSection},
+		{"unexpected closing", "{{#a}}b{{/c}}", nil, "", ErrUnexpectedClosingTag},
+		{"set delimiter", "{{=<% %>=}}", nil, "", ErrSetDelimiterNotSupported},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := Format(tt.template, tt.values, loader)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Format() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Format() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatNilLoader(t *testing.T) {
+	t.Parallel()
+
+	_, err := Format("{{> item}}", nil, nil)
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Format() error = %v, want %v", err, fs.ErrNotExist)
+	}
+}
+
+func TestVariables(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		template string
+		want     []string
+		wantErr  error
+	}{
+		{"none", "Hello world", []string{}, nil},
+		{"variables", "{{b}} {{{a}}} {{&c}} {{b}}", []string{"a", "b", "c"}, nil},
+		{"dotted", "{{user.name}}", []string{"user"}, nil},
+		{"sections", "{{#items}}{{name}}{{/items}}{{^empty}}{{fallback}}{{/empty}}", []string{"empty", "fallback", "items"}, nil},
+		{"comments and partials", "{{! c }}{{> p}}", []string{}, nil},
+		{"error", "{{#a}}", nil, ErrUnclosedSection},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := Variables(tt.template)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Variables() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Variables() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package mustache
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	_openTag          = "{{"
+	_closeTag         = "}}"
+	_tripleOpen       = "{{{"
+	_tripleClose      = "}}}"
+	_implicitIterator = "."
+)
+
+type nodeKind int
+
+const (
+	textNode nodeKind = iota
+	variableNode
+	sectionNode
+	invertedNode
+	partialNode
+)
+
+type node struct {
+	kind nodeKind
+	// text is the text of a text node.
+	text string
+	// name is the name of a variable, a section or a partial.
+	name string
+	// indent is the indentation of a standalone partial.
+	indent string
+	// children are the nodes of a section.
+	children []*node
+}
+
+// parse parses the template into a list of nodes.
+func parse(template string) ([]*node, error) {
+	root := &node{kind: sectionNode}
+	stack := []*node{root}
+	pos := 0
+
+	for pos < len(template) {
+		start := strings.Index(template[pos:], _openTag)
+		if start < 0 {
+			appendText(stack[len(stack)-1], template[pos:])
+			break
+		}
+		start += pos
+
+		tag, end, err := scanTag(template, start)
+		if err != nil {
+			return nil, err
+		}
+
+		text := template[pos:start]
+		indent := ""
+		if tag.standaloneCandidate() {
+			if lineStart, next, ok := standalone(template, start, end); ok && lineStart >= pos {
+				indent = template[lineStart:start]
+				text = template[pos:lineStart]
+				end = next
+			}
+		}
+		appendText(stack[len(stack)-1], text)
+		pos = end
+
+		parent := stack[len(stack)-1]
+		switch tag.sigil {
+		case '!':
+		case '#', '^':
+			kind := sectionNode
+			if tag.sigil == '^' {
+				kind = invertedNode
+			}
+			section := &node{kind: kind, name: tag.name}
+			parent.children = append(parent.children, section)
+			stack = append(stack, section)
+		case '/':
+			if len(stack) == 1 || parent.name != tag.name {
+				return nil, fmt.Errorf("%w: {{/%s}}", ErrUnexpectedClosingTag, tag.name)
+			}
+			stack = stack[:len(stack)-1]
+		case '>':
+			parent.children = append(parent.children, &node{kind: partialNode, name: tag.name, indent: indent})
+		case '=':
+			return nil, ErrSetDelimiterNotSupported
+		default:
+			parent.children = append(parent.children, &node{kind: variableNode, name: tag.name})
+		}
+	}
+
+	if len(stack) > 1 {
+		return nil, fmt.Errorf("%w: {{#%s}}", ErrUnclosedSection, stack[len(stack)-1].name)
+	}
+	return root.children, nil
+}
+
+type tag struct {
+	sigil byte
+	name  string
+}
+
+// standaloneCandidate reports whether the tag is removed with its line when
+// it stands alone on it.
+func (t tag) standaloneCandidate() bool {
+	switch t.sigil {
+	case '!', '#', '^', '/', '>':
+		return true
+	default:
+		return false
+	}
+}
+
+// scanTag scans the tag starting at start and returns it with the position
+// right after it.
+func scanTag(template string, start int) (tag, int, error) {
+	if strings.HasPrefix(template[start:], _tripleOpen) {
+		end := strings.Index(template[start+len(_tripleOpen):], _tripleClose)
+		if end < 0 {
+			return tag{}, 0, fmt.Errorf("%w at offset %d", ErrUnclosedTag, start)
+		}
+		contentEnd := start + len(_tripleOpen) + end
+		name := strings.TrimSpace(template[start+len(_tripleOpen) : contentEnd])
+		if name == "" {
+			return tag{}, 0, fmt.Errorf("%w at offset %d", ErrEmptyTag, start)
+		}
+		return tag{sigil: '&', name: name}, contentEnd + len(_tripleClose), nil
+	}
+
+	end := strings.Index(template[start+len(_openTag):], _closeTag)
+	if end < 0 {
+		return tag{}, 0, fmt.Errorf("%w at offset %d", ErrUnclosedTag, start)
+	}
+	contentEnd := start + len(_openTag) + end
+	content := strings.TrimSpace(template[start+len(_openTag) : contentEnd])
+	next := contentEnd + len(_closeTag)
+
+	if content == "" {
+		return tag{}, 0, fmt.Errorf("%w at offset %d", ErrEmptyTag, start)
+	}
+
+	t := tag{name: content}
+	switch content[0] {
+	case '!':
+		return tag{sigil: '!'}, next, nil
+	case '#', '^', '/', '>', '&', '=':
+		t.sigil = content[0]
+		t.name = strings.TrimSpace(content[1:])
+	}
+	if t.name == "" {
+		return tag{}, 0, fmt.Errorf("%w at offset %d", ErrEmptyTag, start)
+	}
+	return t, next, nil
+}
+
+// standalone reports whether the tag between start and end is alone on its
+// line, with only whitespace around it. It returns the start of the line and
+// the start of the next line.
+func standalone(template string, start, end int) (int, int, bool) {
+	lineStart := strings.LastIndexByte(template[:start], '\n') + 1
+	if strings.TrimLeft(template[lineStart:start], " \t") != "" {
+		return 0, 0, false
+	}
+
+	lineEnd := strings.IndexByte(template[end:], '\n')
+	next := len(template)
+	if lineEnd >= 0 {
+		next = end + lineEnd + 1
+		lineEnd += end
+	} else {
+		lineEnd = len(template)
+	}
+	if strings.TrimRight(template[end:lineEnd], " \t\r") != "" {
+		return 0, 0, false
+	}
+	return lineStart, next, true
+}
+
+func appendText(parent *node, text string) {
+	if text == "" {
+		return
+	}
+	parent.children = append(parent.children, &node{kind: textNode, text: text})
+}
@@ -0,0 +1,273 @@
+package prompts
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/devmiahub/langchaingo/llms"
+)
+
+// ErrNeedBinaryData is returned when the variable of a binary part is not binary
+// data.
+var ErrNeedBinaryData = errors.New("variable should be binary data")
+
+// ContentPartTemplate is a template of a part of a multimodal message.
+type ContentPartTemplate interface {
+	FormatPart(values map[string]any) (llms.ContentPart, error)
+	GetInputVariables() []string
+}
+
+// TextPartTemplate is a template of a text part.
+type TextPartTemplate struct {
+	Prompt PromptTemplate
+}
+
+var _ ContentPartTemplate = TextPartTemplate{}
+
+// NewTextPartTemplate creates a new text part template using
+// [TemplateFormatGoTemplate] syntax.
+func NewTextPartTemplate(template string, inputVariables []string) TextPartTemplate {
+	return TextPartTemplate{Prompt: NewPromptTemplate(template, inputVariables)}
+}
+
+// FormatPart formats the text with the values given.
+func (p TextPartTemplate) FormatPart(values map[string]any) (llms.ContentPart, error) { //nolint:ireturn
+	text, err := p.Prompt.Format(values)
+	if err != nil {
+		return nil, fmt.Errorf("formatting text part: %w", err)
+	}
+	return llms.TextPart(text), nil
+}
+
+// GetInputVariables returns the input variables the part expects.
+func (p TextPartTemplate) GetInputVariables() []string {
+	return p.Prompt.InputVariables
+}
+
+// ImageURLPartTemplate is a template of an image part referenced by its URL,
+// which may be a data URL.
+type ImageURLPartTemplate struct {
+	// URL is the template of the URL of the image.
+	URL PromptTemplate
+	// Detail is the detail of the image, e.g. "low", "high".
+	Detail string
+}
+
+var _ ContentPartTemplate = ImageURLPartTemplate{}
+
+// NewImageURLPartTemplate creates a new image URL part template using
+// [TemplateFormatGoTemplate] syntax.
+func NewImageURLPartTemplate(urlTemplate string, inputVariables []string) ImageURLPartTemplate {
+	return ImageURLPartTemplate{URL: NewPromptTemplate(urlTemplate, inputVariables)}
+}
+
+// FormatPart formats the URL of the image with the values given.
+func (p ImageURLPartTemplate) FormatPart(values map[string]any) (llms.ContentPart, error) { //nolint:ireturn
+	url, err := p.URL.Format(values)
+	if err != nil {
+		return nil, fmt.Errorf("formatting image URL part: %w", err)
+	}
+	return llms.ImageURLContent{URL: url, Detail: p.Detail}, nil
+}
+
+// GetInputVariables returns the input variables the part expects.
+func (p ImageURLPartTemplate) GetInputVariables() []string {
+	return p.URL.InputVariables
+}
+
+// FilePartTemplate is a template of a file part referenced by its URI.
+type FilePartTemplate struct {
+	// URI is the template of the URI of the file.
+	URI PromptTemplate
+	// MIMEType is the MIME type of the file.
+	MIMEType string
+}
+
+var _ ContentPartTemplate = FilePartTemplate{}
+
+// NewFilePartTemplate creates a new file part template using
+// [TemplateFormatGoTemplate] syntax.
+func NewFilePartTemplate(mimeType, uriTemplate string, inputVariables []string) FilePartTemplate {
+	return FilePartTemplate{URI: NewPromptTemplate(uriTemplate, inputVariables), MIMEType: mimeType}
+}
+
+// FormatPart formats the URI of the file with the values given.
+func (p FilePartTemplate) FormatPart(values map[string]any) (llms.ContentPart, error) { //nolint:ireturn
+	uri, err := p.URI.Format(values)
+	if err != nil {
+		return nil, fmt.Errorf("formatting file part: %w", err)
+	}
+	return llms.FileContent{MIMEType: p.MIMEType, URI: uri}, nil
+}
+
+// GetInputVariables returns the input variables the part expects.
+func (p FilePartTemplate) GetInputVariables() []string {
+	return p.URI.InputVariables
+}
+
+// BinaryPartTemplate is a template of a binary part, such as an image, whose
+// data is the value of a variable. The value can be a []byte, a string or a
+// llms.BinaryContent, whose MIME type is used when MIMEType is empty.
+type BinaryPartTemplate struct {
+	// VariableName is the name of the variable holding the data.
+	VariableName string
+	// MIMEType is the MIME type of the data.
+	MIMEType string
+}
+
+var _ ContentPartTemplate = BinaryPartTemplate{}
+
+// NewBinaryPartTemplate creates a new binary part template.
+func NewBinaryPartTemplate(mimeType, variableName string) BinaryPartTemplate {
+	return BinaryPartTemplate{VariableName: variableName, MIMEType: mimeType}
+}
+
+// FormatPart returns the binary data of the variable.
+func (p BinaryPartTemplate) FormatPart(values map[string]any) (llms.ContentPart, error) { //nolint:ireturn
+	value, ok := values[p.VariableName]
+	if !ok {
+		return nil, fmt.Errorf("%w: variable %q not found", ErrNeedBinaryData, p.VariableName)
+	}
+
+	part := llms.BinaryContent{MIMEType: p.MIMEType}
+	switch value := value.(type) {
+	case []byte:
+		part.Data = value
+	case string:
+		part.Data = []byte(value)
+	case llms.BinaryContent:
+		part.Data = value.Data
+		if part.MIMEType == "" {
+			part.MIMEType = value.MIMEType
+		}
+	default:
+		return nil, fmt.Errorf("%w: variable %q has type %T", ErrNeedBinaryData, p.VariableName, value)
+	}
+	return part, nil
+}
+
+// GetInputVariables returns the input variables the part expects.
+func (p BinaryPartTemplate) GetInputVariables() []string {
+	return []string{p.VariableName}
+}
+
+// MessageContentPromptTemplate is a message formatter that returns a message
+// made of several parts, such as text and images.
+//
+// Example:
+//
+//	prompt := prompts.NewChatPromptTemplate([]prompts.MessageFormatter{
+//		prompts.NewSystemMessagePromptTemplate("You describe images.", nil),
+//		prompts.NewMessageContentPromptTemplate(llms.ChatMessageTypeHuman,
+//			prompts.NewTextPartTemplate("Describe this {{.subject}}.", []string{"subject"}),
+//			prompts.NewImageURLPartTemplate("{{.url}}", []string{"url"}),
+//		),
+//	})
+//	messages, err := prompt.FormatMessageContents(values)
+//	resp, err := model.GenerateContent(ctx, messages)
+type MessageContentPromptTemplate struct {
+	Role  llms.ChatMessageType
+	Parts []ContentPartTemplate
+}
+
+var (
+	_ MessageFormatter        = MessageContentPromptTemplate{}
+	_ MessageContentFormatter = MessageContentPromptTemplate{}
+)
+
+// NewMessageContentPromptTemplate creates a new message content prompt template.
+func NewMessageContentPromptTemplate(
+	role llms.ChatMessageType, parts ...ContentPartTemplate,
+) MessageContentPromptTemplate {
+	return MessageContentPromptTemplate{Role: role, Parts: parts}
+}
+
+// FormatMessageContents formats the parts of the message with the values given.
+func (p MessageContentPromptTemplate) FormatMessageContents(values map[string]any) ([]llms.MessageContent, error) {
+	message := llms.MessageContent{Role: p.Role, Parts: make([]llms.ContentPart, 0, len(p.Parts))}
+	for _, partTemplate := range p.Parts {
+		part, err := partTemplate.FormatPart(values)
+		if err != nil {
+			return nil, fmt.Errorf("formatting %s message: %w", p.Role, err)
+		}
+		message.Parts = append(message.Parts, part)
+	}
+	return []llms.MessageContent{message}, nil
+}
+
+// FormatMessages formats the message with the values given. Chat messages only
+// hold text, so the parts other than text are dropped.
+func (p MessageContentPromptTemplate) FormatMessages(values map[string]any) ([]llms.ChatMessage, error) {
+	contents, err := p.FormatMessageContents(values)
+	if err != nil {
+		return nil, err
+	}
+	return []llms.ChatMessage{llms.ChatMessageFromMessageContent(contents[0])}, nil
+}
+
+// GetInputVariables returns the input variables of all the parts.
+func (p MessageContentPromptTemplate) GetInputVariables() []string {
+	var inputVariables []string
+	for _, part := range p.Parts {
+		for _, variable := range part.GetInputVariables() {
+			if !slices.Contains(inputVariables, variable) {
+				inputVariables = append(inputVariables, variable)
+			}
+		}
+	}
+	return inputVariables
+}
+
+var _ MessageContentFormatter = MessagesPlaceholder{}
+
+// FormatMessageContents formats the messages from the values by variable name.
+// The value can be a list of message contents or of chat messages.
+func (p MessagesPlaceholder) FormatMessageContents(values map[string]any) ([]llms.MessageContent, error) {
+	if contents, ok := values[p.VariableName].([]llms.MessageContent); ok {
+		return contents, nil
+	}
+	messages, err := p.FormatMessages(values)
+	if err != nil {
+		return nil, err
+	}
+	return messageContents(messages), nil
+}
+
+// FormatMessageContents formats the messages with the values given into
+// message contents, which can be passed to llms.Model.GenerateContent. Unlike
+// FormatMessages, it keeps the images, binary data and files of the
+// MessageContentPromptTemplate messages.
+func (p ChatPromptTemplate) FormatMessageContents(values map[string]any) ([]llms.MessageContent, error) {
+	resolvedValues, err := resolvePartialValues(p.PartialVariables, values)
+	if err != nil {
+		return nil, err
+	}
+
+	contents := make([]llms.MessageContent, 0, len(p.Messages))
+	for _, m := range p.Messages {
+		if formatter, ok := m.(MessageContentFormatter); ok {
+			curContents, err := formatter.FormatMessageContents(resolvedValues)
+			if err != nil {
+				return nil, err
+			}
+			contents = append(contents, curContents...)
+			continue
+		}
+
+		curMessages, err := m.FormatMessages(resolvedValues)
+		if err != nil {
+			return nil, err
+		}
+		contents = append(contents, messageContents(curMessages)...)
+	}
+	return contents, nil
+}
+
+func messageContents(messages []llms.ChatMessage) []llms.MessageContent {
+	contents := make([]llms.MessageContent, 0, len(messages))
+	for _, m := range messages {
+		contents = append(contents, llms.MessageContentFromChatMessage(m))
+	}
+	return contents
+}
@@ -0,0 +1,72 @@
+package prompts
+
+import (
+	"testing"
+
+	"github.com/devmiahub/langchaingo/llms"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChatPromptTemplateFormatMessageContents(t *testing.T) {
+	t.Parallel()
+
+	prompt := ChatPromptTemplate{
+		Messages: []MessageFormatter{
+			NewSystemMessagePromptTemplate("You describe {{.kind}}.", []string{"kind"}),
+			MessagesPlaceholder{VariableName: "history"},
+			NewMessageContentPromptTemplate(llms.ChatMessageTypeHuman,
+				NewTextPartTemplate("Describe this {{.subject}}.", []string{"subject"}),
+				ImageURLPartTemplate{
+					URL:    NewPromptTemplate("https://example.com/{{.image}}.png", []string{"image"}),
+					Detail: "low",
+				},
+				NewBinaryPartTemplate("image/png", "png"),
+				NewBinaryPartTemplate("", "jpeg"),
+				NewFilePartTemplate("application/pdf", "gs://bucket/{{.file}}", []string{"file"}),
+			),
+		},
+		PartialVariables: map[string]any{"kind": "images"},
+	}
+	values := map[string]any{
+		"subject": "cat",
+		"image":   "cat",
+		"file":    "cat.pdf",
+		"png":     []byte{1, 2},
+		"jpeg":    llms.BinaryContent{MIMEType: "image/jpeg", Data: []byte{3}},
+		"history": []llms.MessageContent{llms.TextParts(llms.ChatMessageTypeAI, "Hello")},
+	}
+
+	contents, err := prompt.FormatMessageContents(values)
+	require.NoError(t, err)
+	require.Equal(t, []llms.MessageContent{
+		llms.TextParts(llms.ChatMessageTypeSystem, "You describe images."),
+		llms.TextParts(llms.ChatMessageTypeAI, "Hello"),
+		{
+			Role: llms.ChatMessageTypeHuman,
+			Parts: []llms.ContentPart{
+				llms.TextPart("Describe this cat."),
+				llms.ImageURLContent{URL: "https://example.com/cat.png", Detail: "low"},
+				llms.BinaryContent{MIMEType: "image/png", Data: []byte{1, 2}},
+				llms.BinaryContent{MIMEType: "image/jpeg", Data: []byte{3}},
+				llms.FileContent{MIMEType: "application/pdf", URI: "gs://bucket/cat.pdf"},
+			},
+		},
+	}, contents)
+
+	// Chat messages only keep the text.
+	values["history"] = []llms.ChatMessage{llms.AIChatMessage{Content: "Hello"}}
+	messages, err := prompt.FormatMessages(values)
+	require.NoError(t, err)
+	require.Equal(t, llms.HumanChatMessage{Content: "Describe this cat."}, messages[2])
+
+	contents, err = prompt.FormatMessageContents(values)
+	require.NoError(t, err)
+	require.Equal(t, llms.TextParts(llms.ChatMessageTypeAI, "Hello"), contents[1])
+
+	require.ElementsMatch(t, []string{"kind", "history", "subject", "image", "png", "jpeg", "file"},
+		prompt.GetInputVariables())
+
+	values["png"] = 42
+	_, err = prompt.FormatMessageContents(values)
+	require.ErrorIs(t, err, ErrNeedBinaryData)
+}
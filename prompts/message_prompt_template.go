@@ -34,6 +34,18 @@ func NewSystemMessagePromptTemplate(template string, inputVariables []string) Sy
 	}
 }
 
+// ParseSystemMessagePromptTemplate creates a new system message prompt template in
+// the given format, with the input variables inferred from the template.
+func ParseSystemMessagePromptTemplate(
+	template string, templateFormat TemplateFormat,
+) (SystemMessagePromptTemplate, error) {
+	prompt, err := ParsePromptTemplate(template, templateFormat)
+	if err != nil {
+		return SystemMessagePromptTemplate{}, err
+	}
+	return SystemMessagePromptTemplate{Prompt: prompt}, nil
+}
+
 // AIMessagePromptTemplate is a message formatter that returns an AI message.
 type AIMessagePromptTemplate struct {
 	Prompt PromptTemplate
@@ -62,6 +74,18 @@ func NewAIMessagePromptTemplate(template string, inputVariables []string) AIMess
 	}
 }
 
+// ParseAIMessagePromptTemplate creates a new AI message prompt template in
+// the given format, with the input variables inferred from the template.
+func ParseAIMessagePromptTemplate(
+	template string, templateFormat TemplateFormat,
+) (AIMessagePromptTemplate, error) {
+	prompt, err := ParsePromptTemplate(template, templateFormat)
+	if err != nil {
+		return AIMessagePromptTemplate{}, err
+	}
+	return AIMessagePromptTemplate{Prompt: prompt}, nil
+}
+
 // HumanMessagePromptTemplate is a message formatter that returns a human message.
 type HumanMessagePromptTemplate struct {
 	Prompt PromptTemplate
@@ -90,6 +114,18 @@ func NewHumanMessagePromptTemplate(template string, inputVariables []string) Hum
 	}
 }
 
+// ParseHumanMessagePromptTemplate creates a new human message prompt template in
+// the given format, with the input variables inferred from the template.
+func ParseHumanMessagePromptTemplate(
+	template string, templateFormat TemplateFormat,
+) (HumanMessagePromptTemplate, error) {
+	prompt, err := ParsePromptTemplate(template, templateFormat)
+	if err != nil {
+		return HumanMessagePromptTemplate{}, err
+	}
+	return HumanMessagePromptTemplate{Prompt: prompt}, nil
+}
+
 // GenericMessagePromptTemplate is a message formatter that returns message with the specified speaker.
 type GenericMessagePromptTemplate struct {
 	Prompt PromptTemplate
@@ -120,6 +156,18 @@ func NewGenericMessagePromptTemplate(role, template string, inputVariables []str
 	}
 }
 
+// ParseGenericMessagePromptTemplate creates a new generic message prompt template
+// in the given format, with the input variables inferred from the template.
+func ParseGenericMessagePromptTemplate(
+	role, template string, templateFormat TemplateFormat,
+) (GenericMessagePromptTemplate, error) {
+	prompt, err := ParsePromptTemplate(template, templateFormat)
+	if err != nil {
+		return GenericMessagePromptTemplate{}, err
+	}
+	return GenericMessagePromptTemplate{Prompt: prompt, Role: role}, nil
+}
+
 type MessagesPlaceholder struct {
 	VariableName string
 }
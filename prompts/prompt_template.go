@@ -58,6 +58,28 @@ func NewPromptTemplate(template string, inputVars []string) PromptTemplate {
 	}
 }
 
+// ParsePromptTemplate creates a new [PromptTemplate] in the given format, with
+// the input variables inferred from the template by [TemplateVariables].
+//
+// Example:
+//
+//	template, err := prompts.ParsePromptTemplate(
+//		"Summarize this {content} in {style} style",
+//		prompts.TemplateFormatFString,
+//	)
+//	// template.InputVariables: []string{"content", "style"}
+func ParsePromptTemplate(template string, templateFormat TemplateFormat) (PromptTemplate, error) {
+	inputVars, err := TemplateVariables(template, templateFormat)
+	if err != nil {
+		return PromptTemplate{}, err
+	}
+	return PromptTemplate{
+		Template:       template,
+		InputVariables: inputVars,
+		TemplateFormat: templateFormat,
+	}, nil
+}
+
 var (
 	_ Formatter      = PromptTemplate{}
 	_ FormatPrompter = PromptTemplate{}
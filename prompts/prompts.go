@@ -14,6 +14,13 @@ type MessageFormatter interface {
 	GetInputVariables() []string
 }
 
+// MessageContentFormatter is an interface for formatting a map of values into
+// a list of message contents, which may hold images and other non-text parts.
+type MessageContentFormatter interface {
+	FormatMessageContents(values map[string]any) ([]llms.MessageContent, error)
+	GetInputVariables() []string
+}
+
 // FormatPrompter is an interface for formatting a map of values into a prompt.
 type FormatPrompter interface {
 	FormatPrompt(values map[string]any) (llms.PromptValue, error)
@@ -0,0 +1,503 @@
+package prompts
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// PromptFileVersion is the version of the prompt file format written by
+// [MarshalPrompt] and [SavePrompt]. Files without a version, such as the ones
+// written by LangChain, are read as version 1.
+const PromptFileVersion = 1
+
+var (
+	// ErrInvalidPromptFile is returned when a prompt file can't be decoded into a
+	// prompt.
+	ErrInvalidPromptFile = errors.New("invalid prompt file")
+	// ErrUnsupportedPromptVersion is returned when a prompt file was written by a
+	// newer version of the format.
+	ErrUnsupportedPromptVersion = errors.New("unsupported prompt file version")
+	// ErrPromptNotSerializable is returned when a prompt holds values that can't
+	// be written to a file, such as functions or example selectors.
+	ErrPromptNotSerializable = errors.New("prompt is not serializable")
+)
+
+// PromptFileFormat is the encoding of a prompt file.
+type PromptFileFormat string
+
+const (
+	// PromptFileFormatJSON encodes prompt files as JSON.
+	PromptFileFormatJSON PromptFileFormat = "json"
+	// PromptFileFormatYAML encodes prompt files as YAML.
+	PromptFileFormatYAML PromptFileFormat = "yaml"
+)
+
+// The types of the prompts and of the chat messages in a prompt file. The
+// prompt types are the ones of LangChain's prompt files.
+const (
+	_promptTypePrompt  = "prompt"
+	_promptTypeFewShot = "few_shot"
+	_promptTypeChat    = "chat"
+
+	_messageTypeMessage     = "message"
+	_messageTypeGeneric     = "chat"
+	_messageTypePlaceholder = "placeholder"
+
+	_roleSystem = "system"
+	_roleHuman  = "human"
+	_roleAI     = "ai"
+)
+
+// promptConfig is the content of a prompt file. The keys are the ones of
+// LangChain's prompt files, plus the version and the chat messages.
+type promptConfig struct {
+	Type             string         `json:"_type"`
+	Version          int            `json:"_version,omitempty"`
+	InputVariables   []string       `json:"input_variables"`
+	PartialVariables map[string]any `json:"partial_variables,omitempty"`
+	TemplateFormat   TemplateFormat `json:"template_format,omitempty"`
+	ValidateTemplate bool           `json:"validate_template,omitempty"`
+
+	// Template and TemplatePath are the template of a prompt.
+	Template     string `json:"template,omitempty"`
+	TemplatePath string `json:"template_path,omitempty"`
+
+	// Messages are the messages of a chat prompt.
+	Messages []messageConfig `json:"messages,omitempty"`
+
+	// The fields of a few-shot prompt. Examples is either the list of the
+	// examples or the path of a JSON or YAML file holding them.
+	Prefix            string          `json:"prefix,omitempty"`
+	PrefixPath        string          `json:"prefix_path,omitempty"`
+	Suffix            string          `json:"suffix,omitempty"`
+	SuffixPath        string          `json:"suffix_path,omitempty"`
+	ExampleSeparator  string          `json:"example_separator,omitempty"`
+	Examples          json.RawMessage `json:"examples,omitempty"`
+	ExamplePrompt     *promptConfig   `json:"example_prompt,omitempty"`
+	ExamplePromptPath string          `json:"example_prompt_path,omitempty"`
+}
+
+// messageConfig is a message of a chat prompt file. Messages with a role
+// system, human or ai have the type message, messages with another role are
+// generic chat messages, and placeholders are filled with the messages of a
+// variable.
+type messageConfig struct {
+	Type         string        `json:"_type,omitempty"`
+	Role         string        `json:"role,omitempty"`
+	Prompt       *promptConfig `json:"prompt,omitempty"`
+	VariableName string        `json:"variable_name,omitempty"`
+}
+
+// LoadPrompt loads a PromptTemplate, a ChatPromptTemplate or a *FewShotPrompt
+// from a JSON or YAML prompt file. The paths referenced by the file, such as
+// template_path or the path of the examples, are resolved in fsys like name.
+func LoadPrompt(fsys fs.FS, name string) (FormatPrompter, error) { //nolint:ireturn
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, fmt.Errorf("reading prompt file %q: %w", name, err)
+	}
+	prompt, err := unmarshalPrompt(fsys, data)
+	if err != nil {
+		return nil, fmt.Errorf("loading prompt file %q: %w", name, err)
+	}
+	return prompt, nil
+}
+
+// SavePrompt writes the prompt to a file, encoded as JSON or YAML depending on
+// the extension of the name.
+func SavePrompt(name string, prompt FormatPrompter) error {
+	format, err := promptFileFormat(name)
+	if err != nil {
+		return err
+	}
+	data, err := MarshalPrompt(prompt, format)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(name, data, 0o600)
+}
+
+// UnmarshalPrompt decodes a PromptTemplate, a ChatPromptTemplate or a
+// *FewShotPrompt from JSON or YAML. Files referencing other files must be read
+// with [LoadPrompt].
+func UnmarshalPrompt(data []byte) (FormatPrompter, error) { //nolint:ireturn
+	return unmarshalPrompt(nil, data)
+}
+
+// MarshalPrompt encodes a PromptTemplate, a ChatPromptTemplate or a
+// *FewShotPrompt in the given format.
+func MarshalPrompt(prompt FormatPrompter, format PromptFileFormat) ([]byte, error) {
+	config, err := newPromptConfig(prompt)
+	if err != nil {
+		return nil, err
+	}
+	config.Version = PromptFileVersion
+
+	switch format {
+	case PromptFileFormatJSON:
+		return json.MarshalIndent(config, "", "  ")
+	case PromptFileFormatYAML:
+		return yaml.Marshal(config)
+	default:
+		return nil, fmt.Errorf("%w: unknown format %q", ErrInvalidPromptFile, format)
+	}
+}
+
+func promptFileFormat(name string) (PromptFileFormat, error) {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".json":
+		return PromptFileFormatJSON, nil
+	case ".yaml", ".yml":
+		return PromptFileFormatYAML, nil
+	default:
+		return "", fmt.Errorf("%w: unknown extension of %q, expected .json, .yaml or .yml", ErrInvalidPromptFile, name)
+	}
+}
+
+func unmarshalPrompt(fsys fs.FS, data []byte) (FormatPrompter, error) { //nolint:ireturn
+	var config promptConfig
+	// YAML is a superset of JSON, so both are decoded the same way.
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPromptFile, err)
+	}
+	if config.Version > PromptFileVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedPromptVersion, config.Version)
+	}
+	return config.prompt(fsys)
+}
+
+func newPromptConfig(prompt FormatPrompter) (*promptConfig, error) {
+	switch p := prompt.(type) {
+	case PromptTemplate:
+		return newPromptTemplateConfig(p)
+	case *PromptTemplate:
+		return newPromptTemplateConfig(*p)
+	case ChatPromptTemplate:
+		return newChatPromptConfig(p)
+	case *ChatPromptTemplate:
+		return newChatPromptConfig(*p)
+	case *FewShotPrompt:
+		return newFewShotPromptConfig(p)
+	default:
+		return nil, fmt.Errorf("%w: unsupported prompt type %T", ErrPromptNotSerializable, prompt)
+	}
+}
+
+func newPromptTemplateConfig(p PromptTemplate) (*promptConfig, error) {
+	if p.OutputParser != nil {
+		return nil, fmt.Errorf("%w: prompt has an output parser", ErrPromptNotSerializable)
+	}
+	partials, err := serializablePartials(p.PartialVariables)
+	if err != nil {
+		return nil, err
+	}
+	return &promptConfig{
+		Type:             _promptTypePrompt,
+		InputVariables:   nonNil(p.InputVariables),
+		PartialVariables: partials,
+		TemplateFormat:   p.TemplateFormat,
+		Template:         p.Template,
+	}, nil
+}
+
+func newChatPromptConfig(p ChatPromptTemplate) (*promptConfig, error) {
+	partials, err := serializablePartials(p.PartialVariables)
+	if err != nil {
+		return nil, err
+	}
+	messages := make([]messageConfig, 0, len(p.Messages))
+	for i, m := range p.Messages {
+		message, err := newMessageConfig(m)
+		if err != nil {
+			return nil, fmt.Errorf("message %d: %w", i, err)
+		}
+		messages = append(messages, message)
+	}
+	inputVariables := nonNil(p.GetInputVariables())
+	slices.Sort(inputVariables)
+	return &promptConfig{
+		Type:             _promptTypeChat,
+		InputVariables:   inputVariables,
+		PartialVariables: partials,
+		Messages:         messages,
+	}, nil
+}
+
+func newMessageConfig(m MessageFormatter) (messageConfig, error) {
+	var (
+		message messageConfig
+		prompt  PromptTemplate
+	)
+	switch m := m.(type) {
+	case SystemMessagePromptTemplate:
+		message, prompt = messageConfig{Type: _messageTypeMessage, Role: _roleSystem}, m.Prompt
+	case HumanMessagePromptTemplate:
+		message, prompt = messageConfig{Type: _messageTypeMessage, Role: _roleHuman}, m.Prompt
+	case AIMessagePromptTemplate:
+		message, prompt = messageConfig{Type: _messageTypeMessage, Role: _roleAI}, m.Prompt
+	case GenericMessagePromptTemplate:
+		message, prompt = messageConfig{Type: _messageTypeGeneric, Role: m.Role}, m.Prompt
+	case MessagesPlaceholder:
+		return messageConfig{Type: _messageTypePlaceholder, VariableName: m.VariableName}, nil
+	default:
+		return messageConfig{}, fmt.Errorf("%w: unsupported message type %T", ErrPromptNotSerializable, m)
+	}
+
+	config, err := newPromptTemplateConfig(prompt)
+	if err != nil {
+		return messageConfig{}, err
+	}
+	message.Prompt = config
+	return message, nil
+}
+
+func newFewShotPromptConfig(p *FewShotPrompt) (*promptConfig, error) {
+	if p.ExampleSelector != nil {
+		return nil, fmt.Errorf("%w: prompt has an example selector", ErrPromptNotSerializable)
+	}
+	partials, err := serializablePartials(p.PartialVariables)
+	if err != nil {
+		return nil, err
+	}
+	examplePrompt, err := newPromptTemplateConfig(p.ExamplePrompt)
+	if err != nil {
+		return nil, fmt.Errorf("example prompt: %w", err)
+	}
+	examples, err := json.Marshal(p.Examples)
+	if err != nil {
+		return nil, err
+	}
+	return &promptConfig{
+		Type:             _promptTypeFewShot,
+		InputVariables:   nonNil(p.InputVariables),
+		PartialVariables: partials,
+		TemplateFormat:   p.TemplateFormat,
+		ValidateTemplate: p.ValidateTemplate,
+		Prefix:           p.Prefix,
+		Suffix:           p.Suffix,
+		ExampleSeparator: p.ExampleSeparator,
+		Examples:         examples,
+		ExamplePrompt:    examplePrompt,
+	}, nil
+}
+
+// serializablePartials returns the partial variables holding values, which
+// can be written to a file, unlike functions.
+func serializablePartials(partials map[string]any) (map[string]any, error) {
+	for name, value := range partials {
+		switch value.(type) {
+		case string, int, float64, bool:
+		default:
+			return nil, fmt.Errorf("%w: partial variable %q has type %T", ErrPromptNotSerializable, name, value)
+		}
+	}
+	return partials, nil
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+func (c *promptConfig) prompt(fsys fs.FS) (FormatPrompter, error) { //nolint:ireturn
+	switch c.Type {
+	// LangChain defaults to a prompt template when the type is missing.
+	case _promptTypePrompt, "":
+		return c.promptTemplate(fsys)
+	case _promptTypeChat:
+		return c.chatPromptTemplate(fsys)
+	case _promptTypeFewShot:
+		return c.fewShotPrompt(fsys)
+	default:
+		return nil, fmt.Errorf("%w: unknown prompt type %q", ErrInvalidPromptFile, c.Type)
+	}
+}
+
+// templateFormat returns the template format, which defaults to f-string like
+// in LangChain.
+func (c *promptConfig) templateFormat() TemplateFormat {
+	if c.TemplateFormat == "" {
+		return TemplateFormatFString
+	}
+	return c.TemplateFormat
+}
+
+func (c *promptConfig) promptTemplate(fsys fs.FS) (PromptTemplate, error) {
+	template, err := readInline(fsys, "template", c.Template, c.TemplatePath)
+	if err != nil {
+		return PromptTemplate{}, err
+	}
+	p := PromptTemplate{
+		Template:         template,
+		InputVariables:   c.InputVariables,
+		TemplateFormat:   c.templateFormat(),
+		PartialVariables: c.PartialVariables,
+	}
+	if c.ValidateTemplate {
+		inputVariables := append(append([]string{}, c.InputVariables...), getMapKeys(c.PartialVariables)...)
+		if err := CheckValidTemplate(p.Template, p.TemplateFormat, inputVariables); err != nil {
+			return PromptTemplate{}, fmt.Errorf("template validation failed: %w", err)
+		}
+	}
+	return p, nil
+}
+
+func (c *promptConfig) chatPromptTemplate(fsys fs.FS) (ChatPromptTemplate, error) {
+	messages := make([]MessageFormatter, 0, len(c.Messages))
+	for i, m := range c.Messages {
+		message, err := m.messageFormatter(fsys)
+		if err != nil {
+			return ChatPromptTemplate{}, fmt.Errorf("message %d: %w", i, err)
+		}
+		messages = append(messages, message)
+	}
+	return ChatPromptTemplate{
+		Messages:         messages,
+		PartialVariables: c.PartialVariables,
+	}, nil
+}
+
+func (m messageConfig) messageFormatter(fsys fs.FS) (MessageFormatter, error) { //nolint:ireturn
+	if m.Type == _messageTypePlaceholder {
+		if m.VariableName == "" {
+			return nil, fmt.Errorf("%w: placeholder without a variable name", ErrInvalidPromptFile)
+		}
+		return MessagesPlaceholder{VariableName: m.VariableName}, nil
+	}
+
+	if m.Prompt == nil {
+		return nil, fmt.Errorf("%w: message without a prompt", ErrInvalidPromptFile)
+	}
+	prompt, err := m.Prompt.promptTemplate(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	switch m.Type {
+	case _messageTypeMessage, "":
+		switch m.Role {
+		case _roleSystem:
+			return SystemMessagePromptTemplate{Prompt: prompt}, nil
+		case _roleHuman:
+			return HumanMessagePromptTemplate{Prompt: prompt}, nil
+		case _roleAI:
+			return AIMessagePromptTemplate{Prompt: prompt}, nil
+		default:
+			return nil, fmt.Errorf("%w: unknown message role %q", ErrInvalidPromptFile, m.Role)
+		}
+	case _messageTypeGeneric:
+		return GenericMessagePromptTemplate{Prompt: prompt, Role: m.Role}, nil
+	default:
+		return nil, fmt.Errorf("%w: unknown message type %q", ErrInvalidPromptFile, m.Type)
+	}
+}
+
+func (c *promptConfig) fewShotPrompt(fsys fs.FS) (*FewShotPrompt, error) {
+	prefix, err := readInline(fsys, "prefix", c.Prefix, c.PrefixPath)
+	if err != nil {
+		return nil, err
+	}
+	suffix, err := readInline(fsys, "suffix", c.Suffix, c.SuffixPath)
+	if err != nil {
+		return nil, err
+	}
+	examples, err := c.examples(fsys)
+	if err != nil {
+		return nil, err
+	}
+	examplePrompt, err := c.examplePrompt(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewFewShotPrompt(examplePrompt, examples, nil, prefix, suffix, c.InputVariables,
+		c.PartialVariables, c.ExampleSeparator, c.templateFormat(), c.ValidateTemplate)
+}
+
+// examples decodes the examples, given inline or as the path of a JSON or YAML
+// file.
+func (c *promptConfig) examples(fsys fs.FS) ([]map[string]string, error) {
+	if len(c.Examples) == 0 {
+		return nil, nil
+	}
+	data := []byte(c.Examples)
+
+	var examplesPath string
+	if json.Unmarshal(c.Examples, &examplesPath) == nil {
+		content, err := readFile(fsys, examplesPath)
+		if err != nil {
+			return nil, err
+		}
+		data = content
+	}
+
+	var examples []map[string]string
+	if err := yaml.Unmarshal(data, &examples); err != nil {
+		return nil, fmt.Errorf("%w: examples: %w", ErrInvalidPromptFile, err)
+	}
+	return examples, nil
+}
+
+func (c *promptConfig) examplePrompt(fsys fs.FS) (PromptTemplate, error) {
+	switch {
+	case c.ExamplePrompt != nil && c.ExamplePromptPath != "":
+		return PromptTemplate{}, fmt.Errorf("%w: both example_prompt and example_prompt_path are set",
+			ErrInvalidPromptFile)
+	case c.ExamplePromptPath != "":
+		content, err := readFile(fsys, c.ExamplePromptPath)
+		if err != nil {
+			return PromptTemplate{}, err
+		}
+		prompt, err := unmarshalPrompt(fsys, content)
+		if err != nil {
+			return PromptTemplate{}, fmt.Errorf("example prompt: %w", err)
+		}
+		examplePrompt, ok := prompt.(PromptTemplate)
+		if !ok {
+			return PromptTemplate{}, fmt.Errorf("%w: example prompt is a %T", ErrInvalidPromptFile, prompt)
+		}
+		return examplePrompt, nil
+	case c.ExamplePrompt != nil:
+		return c.ExamplePrompt.promptTemplate(fsys)
+	default:
+		return PromptTemplate{}, fmt.Errorf("%w: missing example_prompt", ErrInvalidPromptFile)
+	}
+}
+
+// readInline returns the inline value of a field or the content of the file
+// referenced by its path.
+func readInline(fsys fs.FS, field, value, valuePath string) (string, error) {
+	if valuePath == "" {
+		return value, nil
+	}
+	if value != "" {
+		return "", fmt.Errorf("%w: both %s and %s_path are set", ErrInvalidPromptFile, field, field)
+	}
+	content, err := readFile(fsys, valuePath)
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+func readFile(fsys fs.FS, name string) ([]byte, error) {
+	if fsys == nil {
+		return nil, fmt.Errorf("%w: %q is referenced but no filesystem is given, use LoadPrompt",
+			ErrInvalidPromptFile, name)
+	}
+	content, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, fmt.Errorf("reading %q: %w", name, err)
+	}
+	return content, nil
+}
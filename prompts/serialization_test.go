@@ -0,0 +1,184 @@
+package prompts
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/devmiahub/langchaingo/llms"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPromptSerializationRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	fewShot, err := NewFewShotPrompt(
+		NewPromptTemplate("{{.input}} -> {{.output}}", []string{"input", "output"}),
+		[]map[string]string{{"input": "happy", "output": "sad"}},
+		nil, "Give the {{.task}}.", "{{.word}} ->", []string{"word"}, map[string]any{"task": "antonym"},
+		"\n", TemplateFormatGoTemplate, true,
+	)
+	require.NoError(t, err)
+
+	chat := ChatPromptTemplate{
+		Messages: []MessageFormatter{
+			NewSystemMessagePromptTemplate("You are {{.name}}.", []string{"name"}),
+			MessagesPlaceholder{VariableName: "history"},
+			NewHumanMessagePromptTemplate("{{.question}}", []string{"question"}),
+			NewAIMessagePromptTemplate("Let me think.", nil),
+			NewGenericMessagePromptTemplate("critic", "Be brief.", nil),
+		},
+		PartialVariables: map[string]any{"name": "a bot"},
+	}
+
+	tests := []struct {
+		name   string
+		prompt FormatPrompter
+		values map[string]any
+	}{
+		{
+			"prompt",
+			PromptTemplate{
+				Template:         "Tell me a {adjective} joke about {content}.",
+				InputVariables:   []string{"content"},
+				TemplateFormat:   TemplateFormatFString,
+				PartialVariables: map[string]any{"adjective": "funny"},
+			},
+			map[string]any{"content": "cats"},
+		},
+		{
+			"chat",
+			chat,
+			map[string]any{
+				"question": "Why?",
+				"history":  []llms.ChatMessage{llms.HumanChatMessage{Content: "Hi"}},
+			},
+		},
+		{"few_shot", fewShot, map[string]any{"word": "tall"}},
+	}
+
+	for _, tt := range tests {
+		for _, format := range []PromptFileFormat{PromptFileFormatJSON, PromptFileFormatYAML} {
+			t.Run(tt.name+"/"+string(format), func(t *testing.T) {
+				t.Parallel()
+
+				data, err := MarshalPrompt(tt.prompt, format)
+				require.NoError(t, err)
+				loaded, err := UnmarshalPrompt(data)
+				require.NoError(t, err)
+				require.IsType(t, tt.prompt, loaded)
+
+				want, err := tt.prompt.FormatPrompt(tt.values)
+				require.NoError(t, err)
+				got, err := loaded.FormatPrompt(tt.values)
+				require.NoError(t, err)
+				require.Equal(t, want.Messages(), got.Messages())
+			})
+		}
+	}
+}
+
+func TestLoadPrompt(t *testing.T) {
+	t.Parallel()
+
+	// The files are written in LangChain's prompt file format.
+	fsys := fstest.MapFS{
+		"prompt.yaml": {Data: []byte(`_type: prompt
+input_variables: ["adjective", "content"]
+template_path: joke.txt
+`)},
+		"joke.txt": {Data: []byte("Tell me a {adjective} joke about {content}.")},
+		"few_shot.json": {Data: []byte(`{
+  "_type": "few_shot",
+  "input_variables": ["adjective"],
+  "prefix": "Write antonyms for the following words.",
+  "example_prompt_path": "example_prompt.json",
+  "examples": "examples.yaml",
+  "suffix": "Input: {adjective}\nOutput:"
+}`)},
+		"example_prompt.json": {Data: []byte(`{
+  "_type": "prompt",
+  "input_variables": ["input", "output"],
+  "template": "Input: {input}\nOutput: {output}"
+}`)},
+		"examples.yaml": {Data: []byte(`- input: happy
+  output: sad
+- input: tall
+  output: short
+`)},
+		"chat.yaml": {Data: []byte(`_type: chat
+_version: 1
+messages:
+  - role: system
+    prompt:
+      template: "You are {{name}}."
+      template_format: mustache
+      input_variables: [name]
+  - _type: placeholder
+    variable_name: history
+`)},
+		"future.yaml":  {Data: []byte("_type: prompt\n_version: 99\ntemplate: hi\n")},
+		"unknown.yaml": {Data: []byte("_type: few_shot_with_templates\n")},
+	}
+
+	prompt, err := LoadPrompt(fsys, "prompt.yaml")
+	require.NoError(t, err)
+	result, err := prompt.(PromptTemplate).Format(map[string]any{"adjective": "funny", "content": "chickens"})
+	require.NoError(t, err)
+	require.Equal(t, "Tell me a funny joke about chickens.", result)
+
+	prompt, err = LoadPrompt(fsys, "few_shot.json")
+	require.NoError(t, err)
+	result, err = prompt.(*FewShotPrompt).Format(map[string]any{"adjective": "big"})
+	require.NoError(t, err)
+	require.Equal(t, "Write antonyms for the following words.\n\nInput: happy\nOutput: sad\n\n"+
+		"Input: tall\nOutput: short\n\nInput: big\nOutput:", result)
+
+	prompt, err = LoadPrompt(fsys, "chat.yaml")
+	require.NoError(t, err)
+	messages, err := prompt.(ChatPromptTemplate).FormatMessages(map[string]any{
+		"name":    "a bot",
+		"history": []llms.ChatMessage{llms.AIChatMessage{Content: "Hello"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []llms.ChatMessage{
+		llms.SystemChatMessage{Content: "You are a bot."},
+		llms.AIChatMessage{Content: "Hello"},
+	}, messages)
+
+	_, err = LoadPrompt(fsys, "future.yaml")
+	require.ErrorIs(t, err, ErrUnsupportedPromptVersion)
+	_, err = LoadPrompt(fsys, "unknown.yaml")
+	require.ErrorIs(t, err, ErrInvalidPromptFile)
+
+	// References need a filesystem.
+	_, err = UnmarshalPrompt(fsys["prompt.yaml"].Data)
+	require.ErrorIs(t, err, ErrInvalidPromptFile)
+}
+
+func TestSavePrompt(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	prompt := NewPromptTemplate("Hello {{.name}}!", []string{"name"})
+	require.NoError(t, SavePrompt(filepath.Join(dir, "prompt.yml"), prompt))
+
+	loaded, err := LoadPrompt(fstest.MapFS{}, "prompt.yml")
+	require.Error(t, err)
+	require.Nil(t, loaded)
+
+	loaded, err = LoadPrompt(os.DirFS(dir), "prompt.yml")
+	require.NoError(t, err)
+	require.Equal(t, prompt, loaded)
+
+	require.ErrorIs(t, SavePrompt(filepath.Join(dir, "prompt.txt"), prompt), ErrInvalidPromptFile)
+
+	notSerializable := PromptTemplate{
+		Template:         "{{.date}}",
+		TemplateFormat:   TemplateFormatGoTemplate,
+		PartialVariables: map[string]any{"date": func() string { return "today" }},
+	}
+	_, err = MarshalPrompt(notSerializable, PromptFileFormatJSON)
+	require.ErrorIs(t, err, ErrPromptNotSerializable)
+}
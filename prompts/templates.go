@@ -4,6 +4,7 @@
 // - Jinja2: Full Jinja2 syntax with controlled filesystem access
 // - Go Templates: Standard text/template with sprig functions
 // - F-Strings: Python-style string formatting
+// - Mustache: Logic-less templates shared with langchain and langchainjs
 //
 // Basic Usage:
 //
@@ -42,12 +43,18 @@ import (
 
 	"github.com/Masterminds/sprig/v3"
 	"github.com/devmiahub/langchaingo/prompts/internal/fstring"
+	"github.com/devmiahub/langchaingo/prompts/internal/mustache"
 	sanitization "github.com/devmiahub/langchaingo/prompts/internal/sanitization"
 )
 
-// ErrInvalidTemplateFormat is the error when the template format is invalid and
-// not supported.
-var ErrInvalidTemplateFormat = errors.New("invalid template format")
+var (
+	// ErrInvalidTemplateFormat is the error when the template format is invalid and
+	// not supported.
+	ErrInvalidTemplateFormat = errors.New("invalid template format")
+	// ErrMissingTemplateVariable is the error when a template uses a variable
+	// that is not one of its input variables.
+	ErrMissingTemplateVariable = errors.New("template variable is not an input variable")
+)
 
 // TemplateFormat is the format of the template.
 type TemplateFormat string
@@ -61,6 +68,9 @@ const (
 	TemplateFormatJinja2 TemplateFormat = "jinja2"
 	// TemplateFormatFString uses Python-style f-string variable substitution.
 	TemplateFormatFString TemplateFormat = "f-string"
+	// TemplateFormatMustache uses mustache templating with sections, inverted
+	// sections and partials, compatible with langchain and langchainjs.
+	TemplateFormatMustache TemplateFormat = "mustache"
 )
 
 // interpolator is the function that interpolates the given template with the given values.
@@ -71,6 +81,7 @@ var defaultFormatterMapping = map[TemplateFormat]interpolator{ //nolint:gocheckn
 	TemplateFormatGoTemplate: interpolateGoTemplate,
 	TemplateFormatJinja2:     interpolateJinja2,
 	TemplateFormatFString:    fstring.Format,
+	TemplateFormatMustache:   interpolateMustache,
 }
 
 // interpolateGoTemplate interpolates the given template with the given values by using
@@ -104,15 +115,25 @@ func newInvalidTemplateError(gotTemplateFormat TemplateFormat) error {
 // CheckValidTemplate checks if the template is valid through checking whether the given
 // TemplateFormat is available and whether the template can be rendered.
 //
+// Mustache templates render missing variables as empty strings, so the
+// variables they use are extracted and checked against the input variables
+// instead.
+//
 // Note: This function blocks filesystem access for security. Templates using
-// include, extends, import, or from statements will fail. Use RenderTemplateFS
-// for controlled filesystem access if needed.
+// include, extends, import, or from statements, or mustache partials, will
+// fail. Use RenderTemplateFS for controlled filesystem access if needed.
 func CheckValidTemplate(template string, templateFormat TemplateFormat, inputVariables []string) error {
 	_, ok := defaultFormatterMapping[templateFormat]
 	if !ok {
 		return newInvalidTemplateError(templateFormat)
 	}
 
+	if templateFormat == TemplateFormatMustache {
+		if err := checkMustacheVariables(template, inputVariables); err != nil {
+			return err
+		}
+	}
+
 	dummyInputs := make(map[string]any, len(inputVariables))
 	for _, v := range inputVariables {
 		dummyInputs[v] = "foo"
@@ -122,6 +143,28 @@ func CheckValidTemplate(template string, templateFormat TemplateFormat, inputVar
 	return err
 }
 
+// TemplateVariables returns the names of the variables used by the template,
+// sorted. Only the first part of a variable path is returned, such as user
+// for user.name, and the variables defined by the template itself, such as the
+// variables of loops, are left out.
+//
+// The variables used by templates loaded from files, such as jinja2 includes
+// or mustache partials, are not returned.
+func TemplateVariables(template string, templateFormat TemplateFormat) ([]string, error) {
+	switch templateFormat {
+	case TemplateFormatGoTemplate:
+		return goTemplateVariables(template)
+	case TemplateFormatJinja2:
+		return jinja2Variables(template)
+	case TemplateFormatFString:
+		return fstring.Variables(template)
+	case TemplateFormatMustache:
+		return mustache.Variables(template)
+	default:
+		return nil, newInvalidTemplateError(templateFormat)
+	}
+}
+
 // RenderTemplate renders the template with the given values.
 //
 // This function is designed for inline templates and simple use cases.
@@ -203,6 +246,7 @@ func RenderTemplate(tmpl string, tmplFormat TemplateFormat, values map[string]an
 // - Jinja2: include, extends, import, from statements
 // - Go templates: ParseFS functionality for template inheritance
 // - F-strings: File reading from the specified filesystem
+// - Mustache: partials loaded from the specified filesystem
 func RenderTemplateFS(fsys fs.FS, name string, tmplFormat TemplateFormat, values map[string]any, opts ...RenderOption) (string, error) {
 	// Apply options
 	cfg := applyOptions(opts)
@@ -229,6 +273,8 @@ func RenderTemplateFS(fsys fs.FS, name string, tmplFormat TemplateFormat, values
 			return "", fmt.Errorf("failed to read template file %q: %w", name, err)
 		}
 		return fstring.Format(string(content), valuesToUse)
+	case TemplateFormatMustache:
+		return renderMustacheWithFS(fsys, name, valuesToUse)
 	default:
 		return "", newInvalidTemplateError(tmplFormat)
 	}
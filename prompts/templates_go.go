@@ -4,8 +4,11 @@ import (
 	"errors"
 	"fmt"
 	"io/fs"
+	"maps"
+	"slices"
 	"strings"
 	"text/template"
+	"text/template/parse"
 
 	"github.com/Masterminds/sprig/v3"
 )
@@ -32,3 +35,75 @@ func renderGoTemplateWithFS(fsys fs.FS, name string, values map[string]any) (str
 
 	return sb.String(), nil
 }
+
+// goTemplateVariables returns the top-level fields of the data used by the Go
+// template, such as name for {{ .name.first }} or {{ $.name }}. The fields used
+// inside range and with blocks, where the dot is rebound, are not returned
+// unless they are accessed through $.
+func goTemplateVariables(tmpl string) ([]string, error) {
+	parsedTmpl, err := template.New("template").
+		Funcs(sprig.TxtFuncMap()).
+		Parse(tmpl)
+	if err != nil {
+		return nil, fmt.Errorf("template parse failure: %w", err)
+	}
+
+	seen := map[string]bool{}
+	for _, t := range parsedTmpl.Templates() {
+		if t.Tree != nil {
+			collectGoTemplateVariables(t.Tree.Root, true, seen)
+		}
+	}
+	return slices.Sorted(maps.Keys(seen)), nil
+}
+
+// collectGoTemplateVariables collects the fields of the data used by the node.
+// root reports whether the dot is the data of the template.
+func collectGoTemplateVariables(node parse.Node, root bool, seen map[string]bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			collectGoTemplateVariables(child, root, seen)
+		}
+	case *parse.ActionNode:
+		collectGoTemplateVariables(n.Pipe, root, seen)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			collectGoTemplateVariables(cmd, root, seen)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			collectGoTemplateVariables(arg, root, seen)
+		}
+	case *parse.ChainNode:
+		collectGoTemplateVariables(n.Node, root, seen)
+	case *parse.FieldNode:
+		if root {
+			seen[n.Ident[0]] = true
+		}
+	case *parse.VariableNode:
+		if n.Ident[0] == "$" && len(n.Ident) > 1 {
+			seen[n.Ident[1]] = true
+		}
+	case *parse.IfNode:
+		collectGoTemplateBranch(&n.BranchNode, root, false, seen)
+	case *parse.RangeNode:
+		collectGoTemplateBranch(&n.BranchNode, root, true, seen)
+	case *parse.WithNode:
+		collectGoTemplateBranch(&n.BranchNode, root, true, seen)
+	case *parse.TemplateNode:
+		collectGoTemplateVariables(n.Pipe, root, seen)
+	}
+}
+
+func collectGoTemplateBranch(n *parse.BranchNode, root, rebindsDot bool, seen map[string]bool) {
+	collectGoTemplateVariables(n.Pipe, root, seen)
+	collectGoTemplateVariables(n.List, root && !rebindsDot, seen)
+	collectGoTemplateVariables(n.ElseList, root, seen)
+}
@@ -4,10 +4,16 @@ import (
 	"errors"
 	"fmt"
 	"io/fs"
+	"maps"
+	"reflect"
+	"slices"
 	"sync"
 
 	"github.com/nikolalohinski/gonja"
+	"github.com/nikolalohinski/gonja/builtins/statements"
 	"github.com/nikolalohinski/gonja/config"
+	"github.com/nikolalohinski/gonja/nodes"
+	"github.com/nikolalohinski/gonja/tokens"
 	"github.com/devmiahub/langchaingo/prompts/internal/loader"
 )
 
@@ -73,3 +79,183 @@ func renderJinja2WithFS(fsys fs.FS, name string, values map[string]any) (string,
 	}
 	return result, nil
 }
+
+// jinja2Globals are the names defined by jinja2 itself, which are not input
+// variables.
+var jinja2Globals = map[string]bool{ //nolint:gochecknoglobals
+	"range": true, "dict": true, "cycler": true, "joiner": true, "lipsum": true, "namespace": true,
+	"loop": true, "caller": true, "varargs": true, "kwargs": true, "self": true,
+}
+
+// errJinja2Tree is returned when the syntax tree of gonja does not have the
+// shape jinja2Variables expects, as after a gonja upgrade renaming the fields
+// it reads.
+var errJinja2Tree = errors.New("unexpected gonja syntax tree")
+
+// jinja2Variables returns the names of the variables used by the jinja2
+// template. The names assigned by the template itself, with for loops, set,
+// with and macros, are not returned, regardless of their scope.
+func jinja2Variables(tmpl string) ([]string, error) {
+	if err := checkJinja2Fields(); err != nil {
+		return nil, err
+	}
+
+	tpl, err := getSecureGonjaEnv().FromString(tmpl)
+	if err != nil {
+		return nil, fmt.Errorf("template parse failure: %w", err)
+	}
+
+	w := newJinja2Walker()
+	w.walk(reflect.ValueOf(tpl.Root))
+	for name := range w.used {
+		if w.bound[name] || jinja2Globals[name] {
+			delete(w.used, name)
+		}
+	}
+	return slices.Sorted(maps.Keys(w.used)), nil
+}
+
+//nolint:gochecknoglobals
+var (
+	jinja2NameType   = reflect.TypeFor[*nodes.Name]()
+	jinja2TokenType  = reflect.TypeFor[*tokens.Token]()
+	jinja2StringType = reflect.TypeFor[*nodes.String]()
+	jinja2PairType   = reflect.TypeFor[*nodes.Pair]()
+	jinja2MacroType  = reflect.TypeFor[*nodes.Macro]()
+	jinja2ForType    = reflect.TypeFor[*statements.ForStmt]()
+	jinja2SetType    = reflect.TypeFor[*statements.SetStmt]()
+	jinja2WithType   = reflect.TypeFor[*statements.WithStmt]()
+)
+
+// jinja2Fields are the fields of the gonja nodes read by jinja2Walker, with
+// their kinds. The fields of ForStmt are unexported, so any gonja release may
+// change them.
+//
+//nolint:gochecknoglobals
+var jinja2Fields = []struct {
+	node reflect.Type
+	name string
+	kind reflect.Kind
+}{
+	{jinja2NameType, "Name", reflect.Pointer},
+	{jinja2TokenType, "Val", reflect.String},
+	{jinja2StringType, "Val", reflect.String},
+	{jinja2PairType, "Key", reflect.Interface},
+	{jinja2PairType, "Value", reflect.Interface},
+	{jinja2MacroType, "Name", reflect.String},
+	{jinja2MacroType, "Kwargs", reflect.Slice},
+	{jinja2MacroType, "Wrapper", reflect.Pointer},
+	{jinja2ForType, "key", reflect.String},
+	{jinja2ForType, "value", reflect.String},
+	{jinja2SetType, "Target", reflect.Interface},
+	{jinja2SetType, "Expression", reflect.Interface},
+	{jinja2WithType, "Pairs", reflect.Map},
+}
+
+// checkJinja2Fields checks that the gonja nodes have the fields jinja2Walker
+// reads, so that it never reads a missing field.
+func checkJinja2Fields() error {
+	for _, field := range jinja2Fields {
+		f, ok := field.node.Elem().FieldByName(field.name)
+		if !ok || f.Type.Kind() != field.kind {
+			return fmt.Errorf("%w: %s has no %s field %s", errJinja2Tree, field.node.Elem(), field.kind, field.name)
+		}
+	}
+	return nil
+}
+
+// jinja2Walker collects the names used and assigned in a gonja syntax tree.
+// The statements of gonja keep their fields unexported, so the tree is walked
+// with reflection, reading only the fields of jinja2Fields.
+type jinja2Walker struct {
+	used    map[string]bool
+	bound   map[string]bool
+	visited map[uintptr]bool
+}
+
+func newJinja2Walker() *jinja2Walker {
+	return &jinja2Walker{used: map[string]bool{}, bound: map[string]bool{}, visited: map[uintptr]bool{}}
+}
+
+func (w *jinja2Walker) walk(v reflect.Value) {
+	switch v.Kind() { //nolint:exhaustive
+	case reflect.Pointer:
+		if v.IsNil() || w.visited[v.Pointer()] {
+			return
+		}
+		w.visited[v.Pointer()] = true
+		if w.walkNode(v) {
+			return
+		}
+		w.walk(v.Elem())
+	case reflect.Interface:
+		if !v.IsNil() {
+			w.walk(v.Elem())
+		}
+	case reflect.Struct:
+		for i := range v.NumField() {
+			w.walk(v.Field(i))
+		}
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			w.walk(v.Index(i))
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			w.walk(iter.Value())
+		}
+	}
+}
+
+// walkNode handles the nodes using or assigning names and reports whether the
+// node was fully handled. The values read from the tree may come from
+// unexported fields, so they are never converted back to interfaces.
+func (w *jinja2Walker) walkNode(v reflect.Value) bool {
+	switch v.Type() {
+	case jinja2NameType:
+		if token := v.Elem().FieldByName("Name"); !token.IsNil() {
+			w.used[token.Elem().FieldByName("Val").String()] = true
+		}
+		return true
+	case jinja2TokenType:
+		return true
+	case jinja2ForType:
+		w.bound[v.Elem().FieldByName("key").String()] = true
+		w.bound[v.Elem().FieldByName("value").String()] = true
+	case jinja2SetType:
+		w.bind(v.Elem().FieldByName("Target"))
+		w.walk(v.Elem().FieldByName("Expression"))
+		return true
+	case jinja2WithType:
+		for _, key := range v.Elem().FieldByName("Pairs").MapKeys() {
+			w.bound[key.String()] = true
+		}
+	case jinja2MacroType:
+		w.bound[v.Elem().FieldByName("Name").String()] = true
+		kwargs := v.Elem().FieldByName("Kwargs")
+		for i := range kwargs.Len() {
+			pair := kwargs.Index(i)
+			if pair.IsNil() {
+				continue
+			}
+			key := pair.Elem().FieldByName("Key")
+			if !key.IsNil() && key.Elem().Type() == jinja2StringType && !key.Elem().IsNil() {
+				w.bound[key.Elem().Elem().FieldByName("Val").String()] = true
+			}
+			w.walk(pair.Elem().FieldByName("Value"))
+		}
+		w.walk(v.Elem().FieldByName("Wrapper"))
+		return true
+	}
+	return false
+}
+
+// bind marks the names used by the target of an assignment as assigned.
+func (w *jinja2Walker) bind(target reflect.Value) {
+	targetWalker := newJinja2Walker()
+	targetWalker.walk(target)
+	for name := range targetWalker.used {
+		w.bound[name] = true
+	}
+}
@@ -0,0 +1,52 @@
+package prompts
+
+import (
+	"fmt"
+	"io/fs"
+	"slices"
+
+	"github.com/devmiahub/langchaingo/prompts/internal/loader"
+	"github.com/devmiahub/langchaingo/prompts/internal/mustache"
+)
+
+// interpolateMustache interpolates the given template with the given values by
+// using mustache.
+//
+// Security: partials are not loaded, like the filesystem access of jinja2
+// templates. For partials, use RenderTemplateFS with an explicit fs.FS.
+func interpolateMustache(tmpl string, values map[string]any) (string, error) {
+	result, err := mustache.Format(tmpl, values, &loader.NilFSLoader{})
+	if err != nil {
+		return "", fmt.Errorf("template execution failure: %w", err)
+	}
+	return result, nil
+}
+
+// renderMustacheWithFS renders a mustache template from the filesystem, loading
+// its partials from the same filesystem.
+func renderMustacheWithFS(fsys fs.FS, name string, values map[string]any) (string, error) {
+	content, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return "", fmt.Errorf("failed to read template file %q: %w", name, err)
+	}
+	result, err := mustache.Format(string(content), values, loader.NewFSLoader(fsys))
+	if err != nil {
+		return "", fmt.Errorf("failed to execute template %q: %w", name, err)
+	}
+	return result, nil
+}
+
+// checkMustacheVariables checks that the variables used by the mustache
+// template are given, since missing variables render as empty strings.
+func checkMustacheVariables(tmpl string, inputVariables []string) error {
+	variables, err := mustache.Variables(tmpl)
+	if err != nil {
+		return fmt.Errorf("template parse failure: %w", err)
+	}
+	for _, variable := range variables {
+		if !slices.Contains(inputVariables, variable) {
+			return fmt.Errorf("%w: %q", ErrMissingTemplateVariable, variable)
+		}
+	}
+	return nil
+}
@@ -5,6 +5,8 @@ import (
 	"strings"
 	"testing"
 	"testing/fstest"
+
+	"github.com/devmiahub/langchaingo/prompts/internal/loader"
 )
 
 //nolint:funlen // TestInterpolateGoTemplate requires comprehensive coverage
@@ -116,7 +118,7 @@ func TestCheckValidTemplate(t *testing.T) {
 			t.Errorf("expected error, got nil")
 		} else if !errors.Is(err, ErrInvalidTemplateFormat) {
 			t.Errorf("expected ErrInvalidTemplateFormat, got %v", err)
-		} else if err.Error() != "invalid template format, got: unknown, should be one of [f-string go-template jinja2]" {
+		} else if err.Error() != "invalid template format, got: unknown, should be one of [f-string go-template jinja2 mustache]" {
 			t.Errorf("expected specific error message, got %q", err.Error())
 		}
 	})
@@ -140,6 +142,26 @@ func TestCheckValidTemplate(t *testing.T) {
 			t.Errorf("unexpected error: %v", err)
 		}
 	})
+
+	t.Run("MustacheValid", func(t *testing.T) {
+		t.Parallel()
+
+		err := CheckValidTemplate("Hello, {{#users}}{{name}} {{/users}}", TemplateFormatMustache, []string{"users"})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("MustacheMissingVariable", func(t *testing.T) {
+		t.Parallel()
+
+		err := CheckValidTemplate("Hello, {{ name }} from {{ city }}", TemplateFormatMustache, []string{"name"})
+		if !errors.Is(err, ErrMissingTemplateVariable) {
+			t.Errorf("expected ErrMissingTemplateVariable, got %v", err)
+		} else if !strings.Contains(err.Error(), `"city"`) {
+			t.Errorf("expected error to name the variable, got %q", err.Error())
+		}
+	})
 }
 
 func TestRenderTemplate(t *testing.T) {
@@ -179,6 +201,35 @@ func TestRenderTemplate(t *testing.T) {
 			t.Errorf("expected ErrInvalidTemplateFormat, got %v", err)
 		}
 	})
+
+	t.Run("Mustache", func(t *testing.T) {
+		t.Parallel()
+
+		actual, err := RenderTemplate(
+			"Hello {{#names}}{{.}} {{/names}}{{^title}}guest{{/title}}",
+			TemplateFormatMustache,
+			map[string]any{
+				"names": []string{"Ada", "Alan"},
+			},
+		)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if actual != "Hello Ada Alan guest" {
+			t.Errorf("expected %q, got %q", "Hello Ada Alan guest", actual)
+		}
+	})
+
+	t.Run("MustachePartialBlocked", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := RenderTemplate("{{> /etc/passwd}}", TemplateFormatMustache, map[string]any{})
+		if err == nil {
+			t.Errorf("expected error, got nil")
+		} else if !errors.Is(err, loader.ErrFilesystemAccessDisabled) {
+			t.Errorf("expected ErrFilesystemAccessDisabled, got %v", err)
+		}
+	})
 }
 
 //nolint:funlen // TestRenderTemplateFS requires comprehensive filesystem tests
@@ -303,6 +354,30 @@ func TestRenderTemplateFS(t *testing.T) {
 			t.Errorf("expected %q, got %q", "Hello Charlie! Your score is 88%.", result)
 		}
 	})
+
+	t.Run("MustacheWithPartials", func(t *testing.T) {
+		t.Parallel()
+
+		fsys := &fstest.MapFS{
+			"main.mustache": &fstest.MapFile{
+				Data: []byte("Hello {{ name }}!\n{{#items}}\n{{> item}}\n{{/items}}"),
+			},
+			"item.mustache": &fstest.MapFile{
+				Data: []byte("- {{ . }}\n"),
+			},
+		}
+
+		result, err := RenderTemplateFS(fsys, "main.mustache", TemplateFormatMustache, map[string]any{
+			"name":  "Dana",
+			"items": []string{"milk", "eggs"},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result != "Hello Dana!\n- milk\n- eggs\n" {
+			t.Errorf("expected %q, got %q", "Hello Dana!\n- milk\n- eggs\n", result)
+		}
+	})
 }
 
 //nolint:funlen // TestMigrationPatterns requires comprehensive migration examples
@@ -0,0 +1,201 @@
+package prompts
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// ErrInvalidVariables is returned when the variables of a prompt don't match
+// the variables used by its templates.
+var ErrInvalidVariables = errors.New("invalid prompt variables")
+
+// VariablesError is the error returned by the Validate methods of the prompts.
+// It matches ErrInvalidVariables with errors.Is.
+type VariablesError struct {
+	// Missing are the variables used by the templates that are neither input
+	// nor partial variables.
+	Missing []string
+	// Unused are the input variables that no template uses.
+	Unused []string
+	// UnusedPartials are the partial variables that no template uses.
+	UnusedPartials []string
+}
+
+func (e *VariablesError) Error() string {
+	var problems []string
+	if len(e.Missing) > 0 {
+		problems = append(problems, "missing "+strings.Join(e.Missing, ", "))
+	}
+	if len(e.Unused) > 0 {
+		problems = append(problems, "unused "+strings.Join(e.Unused, ", "))
+	}
+	if len(e.UnusedPartials) > 0 {
+		problems = append(problems, "unused partials "+strings.Join(e.UnusedPartials, ", "))
+	}
+	return fmt.Sprintf("%s: %s", ErrInvalidVariables, strings.Join(problems, "; "))
+}
+
+// Is reports whether target is ErrInvalidVariables.
+func (e *VariablesError) Is(target error) bool {
+	return target == ErrInvalidVariables //nolint:errorlint
+}
+
+// Validate checks that the template uses all the input and partial variables
+// of the prompt and no other variables. It returns a *VariablesError if it
+// doesn't, or the error of the template if it can't be parsed.
+func (p PromptTemplate) Validate() error {
+	c := newVariablesCheck()
+	if _, err := c.checkPrompt(p, nil); err != nil {
+		return err
+	}
+	return c.err()
+}
+
+// Validate checks the variables of all the messages of the chat prompt, like
+// [PromptTemplate.Validate]. The partial variables of the chat prompt must be
+// used by at least one message. It returns a *VariablesError for all the
+// messages at once.
+func (p ChatPromptTemplate) Validate() error {
+	c := newVariablesCheck()
+	if err := c.checkChatPrompt(p, nil); err != nil {
+		return err
+	}
+	return c.err()
+}
+
+// Validate checks that the prefix and the suffix use all the input and partial
+// variables of the prompt and no other variables, and validates the example
+// prompt.
+func (p *FewShotPrompt) Validate() error {
+	c := newVariablesCheck()
+	if _, err := c.checkPrompt(p.ExamplePrompt, nil); err != nil {
+		return fmt.Errorf("example prompt: %w", err)
+	}
+
+	used := map[string]bool{}
+	for _, template := range []string{p.Prefix, p.Suffix} {
+		variables, err := TemplateVariables(template, p.TemplateFormat)
+		if err != nil {
+			return err
+		}
+		for _, variable := range variables {
+			used[variable] = true
+		}
+	}
+	c.checkTemplate(used, p.InputVariables, p.PartialVariables)
+	c.checkPartials(p.PartialVariables, used)
+	return c.err()
+}
+
+// variablesCheck accumulates the variable problems of the templates of a
+// prompt.
+type variablesCheck struct {
+	used           map[string]bool
+	missing        map[string]bool
+	unused         map[string]bool
+	unusedPartials map[string]bool
+}
+
+func newVariablesCheck() *variablesCheck {
+	return &variablesCheck{
+		used:           map[string]bool{},
+		missing:        map[string]bool{},
+		unused:         map[string]bool{},
+		unusedPartials: map[string]bool{},
+	}
+}
+
+// checkPrompt checks the template of the prompt, whose partial variables are
+// completed by the partial variables of the enclosing chat prompts, and
+// returns the variables it uses.
+func (c *variablesCheck) checkPrompt(p PromptTemplate, enclosingPartials map[string]any) (map[string]bool, error) {
+	variables, err := TemplateVariables(p.Template, p.TemplateFormat)
+	if err != nil {
+		return nil, err
+	}
+	used := make(map[string]bool, len(variables))
+	for _, variable := range variables {
+		used[variable] = true
+	}
+
+	partials := maps.Clone(enclosingPartials)
+	if partials == nil {
+		partials = map[string]any{}
+	}
+	maps.Copy(partials, p.PartialVariables)
+	c.checkTemplate(used, p.InputVariables, partials)
+	c.checkPartials(p.PartialVariables, used)
+	return used, nil
+}
+
+func (c *variablesCheck) checkTemplate(used map[string]bool, inputVariables []string, partials map[string]any) {
+	for variable := range used {
+		c.used[variable] = true
+		if _, ok := partials[variable]; !ok && !slices.Contains(inputVariables, variable) {
+			c.missing[variable] = true
+		}
+	}
+	for _, variable := range inputVariables {
+		if !used[variable] {
+			c.unused[variable] = true
+		}
+	}
+}
+
+func (c *variablesCheck) checkChatPrompt(p ChatPromptTemplate, enclosingPartials map[string]any) error {
+	partials := maps.Clone(enclosingPartials)
+	if partials == nil {
+		partials = map[string]any{}
+	}
+	maps.Copy(partials, p.PartialVariables)
+
+	for i, message := range p.Messages {
+		var err error
+		switch m := message.(type) {
+		case SystemMessagePromptTemplate:
+			_, err = c.checkPrompt(m.Prompt, partials)
+		case HumanMessagePromptTemplate:
+			_, err = c.checkPrompt(m.Prompt, partials)
+		case AIMessagePromptTemplate:
+			_, err = c.checkPrompt(m.Prompt, partials)
+		case GenericMessagePromptTemplate:
+			_, err = c.checkPrompt(m.Prompt, partials)
+		case ChatPromptTemplate:
+			err = c.checkChatPrompt(m, partials)
+		default:
+			// Placeholders and other formatters use their input variables.
+			for _, variable := range message.GetInputVariables() {
+				c.used[variable] = true
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("message %d: %w", i, err)
+		}
+	}
+
+	c.checkPartials(p.PartialVariables, c.used)
+	return nil
+}
+
+// checkPartials records the partial variables that are not used.
+func (c *variablesCheck) checkPartials(partials map[string]any, used map[string]bool) {
+	for variable := range partials {
+		if !used[variable] {
+			c.unusedPartials[variable] = true
+		}
+	}
+}
+
+func (c *variablesCheck) err() error {
+	if len(c.missing) == 0 && len(c.unused) == 0 && len(c.unusedPartials) == 0 {
+		return nil
+	}
+	return &VariablesError{
+		Missing:        slices.Sorted(maps.Keys(c.missing)),
+		Unused:         slices.Sorted(maps.Keys(c.unused)),
+		UnusedPartials: slices.Sorted(maps.Keys(c.unusedPartials)),
+	}
+}
@@ -2,6 +2,8 @@ package prompts
 
 import (
 	"testing"
+
+	"github.com/stretchr/testify/require"
 )
 
 // TestVariableNamesNoLongerReserved verifies that previously "reserved" words
@@ -111,3 +113,133 @@ func TestEssentialValidationStillWorks(t *testing.T) {
 		}
 	})
 }
+
+func TestTemplateVariables(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		template string
+		format   TemplateFormat
+		want     []string
+	}{
+		{
+			"go template", `{{ .a.b }} {{ range .items }}{{ .name }}{{ $.c }}{{ else }}{{ .d }}{{ end }}` +
+				`{{ with .e }}{{ .f }}{{ end }}{{ if .g }}{{ .h | upper }}{{ end }}`,
+			TemplateFormatGoTemplate, []string{"a", "c", "d", "e", "g", "h", "items"},
+		},
+		{
+			"jinja2", "{% for k, v in items if v > limit %}{{ k }}{{ loop.index }}{% endfor %}" +
+				"{% set x = y | upper %}{{ x }} {{ user.name }} {{ range(3) }}" +
+				"{% macro m(a, b=c) %}{{ a }}{% endmacro %}{{ m(1) }}",
+			TemplateFormatJinja2, []string{"c", "items", "limit", "user", "y"},
+		},
+		{
+			"jinja2 nested", "{% for x in xs %}{% with w = z %}{{ w }}{% endwith %}" +
+				"{% macro m(a=d) %}{{ a }}{{ x }}{% endmacro %}{% endfor %}",
+			TemplateFormatJinja2, []string{"d", "xs", "z"},
+		},
+		{"f-string", "{b} and {a} {{escaped}}", TemplateFormatFString, []string{"a", "b"}},
+		{
+			"mustache", "{{#users}}{{name}}{{/users}}{{^admin}}{{guest.name}}{{/admin}}",
+			TemplateFormatMustache, []string{"admin", "guest", "users"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := TemplateVariables(tt.template, tt.format)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := TemplateVariables("{{ .a", TemplateFormatGoTemplate)
+	require.Error(t, err)
+	_, err = TemplateVariables("{a}", "unknown")
+	require.ErrorIs(t, err, ErrInvalidTemplateFormat)
+}
+
+// TestJinja2Fields fails if a gonja upgrade changed the fields of the syntax
+// tree read to infer the variables of jinja2 templates.
+func TestJinja2Fields(t *testing.T) {
+	t.Parallel()
+	require.NoError(t, checkJinja2Fields())
+}
+
+func TestParsePromptTemplate(t *testing.T) {
+	t.Parallel()
+
+	p, err := ParsePromptTemplate("Tell me a {adjective} joke about {content}.", TemplateFormatFString)
+	require.NoError(t, err)
+	require.Equal(t, []string{"adjective", "content"}, p.InputVariables)
+	require.NoError(t, p.Validate())
+
+	m, err := ParseHumanMessagePromptTemplate("{{ question }}", TemplateFormatJinja2)
+	require.NoError(t, err)
+	require.Equal(t, []string{"question"}, m.GetInputVariables())
+
+	g, err := ParseGenericMessagePromptTemplate("critic", "Rate {{.answer}}", TemplateFormatGoTemplate)
+	require.NoError(t, err)
+	require.Equal(t, "critic", g.Role)
+	require.Equal(t, []string{"answer"}, g.GetInputVariables())
+
+	_, err = ParseSystemMessagePromptTemplate("{", TemplateFormatFString)
+	require.Error(t, err)
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	p := PromptTemplate{
+		Template:         "{{.greeting}}, {{.name}} from {{.city}}!",
+		InputVariables:   []string{"name", "age"},
+		TemplateFormat:   TemplateFormatGoTemplate,
+		PartialVariables: map[string]any{"greeting": "Hi", "unused": "x"},
+	}
+	err := p.Validate()
+	require.ErrorIs(t, err, ErrInvalidVariables)
+	var variablesErr *VariablesError
+	require.ErrorAs(t, err, &variablesErr)
+	require.Equal(t, &VariablesError{
+		Missing:        []string{"city"},
+		Unused:         []string{"age"},
+		UnusedPartials: []string{"unused"},
+	}, variablesErr)
+	require.Equal(t, "invalid prompt variables: missing city; unused age; unused partials unused", err.Error())
+
+	chat := ChatPromptTemplate{
+		Messages: []MessageFormatter{
+			NewSystemMessagePromptTemplate("You are {{.persona}}.", nil),
+			MessagesPlaceholder{VariableName: "history"},
+			NewHumanMessagePromptTemplate("{{.question}}", []string{"question"}),
+		},
+		PartialVariables: map[string]any{"persona": "a bot"},
+	}
+	require.NoError(t, chat.Validate())
+
+	chat.Messages = append(chat.Messages, NewAIMessagePromptTemplate("{{.answer}}", []string{"question"}))
+	chat.PartialVariables["history"] = ""
+	chat.PartialVariables["tone"] = "dry"
+	err = chat.Validate()
+	require.ErrorAs(t, err, &variablesErr)
+	require.Equal(t, &VariablesError{
+		Missing:        []string{"answer"},
+		Unused:         []string{"question"},
+		UnusedPartials: []string{"tone"},
+	}, variablesErr)
+
+	fewShot := &FewShotPrompt{
+		ExamplePrompt:  NewPromptTemplate("{{.input}} -> {{.output}}", []string{"input", "output"}),
+		Examples:       []map[string]string{{"input": "a", "output": "b"}},
+		Prefix:         "Antonyms of {{.kind}} words:",
+		Suffix:         "{{.word}} ->",
+		InputVariables: []string{"word"},
+		TemplateFormat: TemplateFormatGoTemplate,
+	}
+	err = fewShot.Validate()
+	require.ErrorAs(t, err, &variablesErr)
+	require.Equal(t, []string{"kind"}, variablesErr.Missing)
+	fewShot.PartialVariables = map[string]any{"kind": "English"}
+	require.NoError(t, fewShot.Validate())
+}
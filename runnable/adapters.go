@@ -0,0 +1,122 @@
+package runnable
+
+import (
+	"context"
+
+	"github.com/devmiahub/langchaingo/chains"
+	"github.com/devmiahub/langchaingo/llms"
+	"github.com/devmiahub/langchaingo/prompts"
+	"github.com/devmiahub/langchaingo/schema"
+)
+
+// FromChain returns a runnable calling the chain, including its memory and
+// callbacks, with chains.Call.
+func FromChain(chain chains.Chain, opts ...chains.ChainCallOption) Runnable[map[string]any, map[string]any] { //nolint:ireturn,lll
+	return Func[map[string]any, map[string]any](func(ctx context.Context, inputs map[string]any) (map[string]any, error) {
+		return chains.Call(ctx, chain, inputs, opts...)
+	})
+}
+
+// ToChain returns a chain invoking the runnable with the inputs of the chain,
+// so that pipelines can be used wherever a chain is expected.
+func ToChain(r Runnable[map[string]any, map[string]any], inputKeys, outputKeys []string) chains.Transform {
+	return chains.NewTransform(
+		func(ctx context.Context, inputs map[string]any, _ ...chains.ChainCallOption) (map[string]any, error) {
+			return r.Invoke(ctx, inputs)
+		},
+		inputKeys,
+		outputKeys,
+	)
+}
+
+// Model is a runnable generating the text of the first choice of a model for
+// a prompt. It streams the text as the model generates it.
+type Model struct {
+	LLM     llms.Model
+	Options []llms.CallOption
+}
+
+var _ Streamer[llms.PromptValue, string] = Model{}
+
+// FromModel returns a runnable generating text with the model.
+func FromModel(llm llms.Model, opts ...llms.CallOption) Model {
+	return Model{LLM: llm, Options: opts}
+}
+
+// Invoke generates text for the messages of the prompt.
+func (m Model) Invoke(ctx context.Context, prompt llms.PromptValue) (string, error) {
+	return m.generate(ctx, prompt, m.Options...)
+}
+
+// Stream generates text for the messages of the prompt and calls the
+// streaming function for each chunk the model emits.
+func (m Model) Stream(
+	ctx context.Context,
+	prompt llms.PromptValue,
+	streamingFunc func(ctx context.Context, chunk string) error,
+) error {
+	opts := make([]llms.CallOption, 0, len(m.Options)+1)
+	opts = append(opts, m.Options...)
+	opts = append(opts, llms.WithStreamingFunc(func(ctx context.Context, chunk []byte) error {
+		return streamingFunc(ctx, string(chunk))
+	}))
+	_, err := m.generate(ctx, prompt, opts...)
+	return err
+}
+
+func (m Model) generate(ctx context.Context, prompt llms.PromptValue, opts ...llms.CallOption) (string, error) {
+	chatMessages := prompt.Messages()
+	messages := make([]llms.MessageContent, 0, len(chatMessages))
+	for _, message := range chatMessages {
+		messages = append(messages, llms.TextParts(message.GetType(), message.GetContent()))
+	}
+
+	resp, err := m.LLM.GenerateContent(ctx, messages, opts...)
+	if err != nil {
+		return "", err
+	}
+	if len(resp.Choices) == 0 {
+		return "", ErrEmptyResponse
+	}
+	return resp.Choices[0].Content, nil
+}
+
+// FromPrompt returns a runnable formatting the prompt with the input values.
+func FromPrompt(prompt prompts.FormatPrompter) Runnable[map[string]any, llms.PromptValue] { //nolint:ireturn
+	return Func[map[string]any, llms.PromptValue](func(_ context.Context, values map[string]any) (llms.PromptValue, error) { //nolint:lll
+		return prompt.FormatPrompt(values)
+	})
+}
+
+// FromParser returns a runnable parsing text with the output parser.
+func FromParser[T any](parser schema.OutputParser[T]) Runnable[string, T] { //nolint:ireturn
+	return Func[string, T](func(_ context.Context, text string) (T, error) {
+		return parser.Parse(text)
+	})
+}
+
+// FromRetriever returns a runnable retrieving the documents relevant to a
+// query.
+func FromRetriever(retriever schema.Retriever) Runnable[string, []schema.Document] { //nolint:ireturn
+	return Func[string, []schema.Document](func(ctx context.Context, query string) ([]schema.Document, error) {
+		return retriever.GetRelevantDocuments(ctx, query)
+	})
+}
+
+// Retriever is a retriever invoking a runnable, so that pipelines can be used
+// wherever a retriever is expected.
+type Retriever struct {
+	Runnable Runnable[string, []schema.Document]
+}
+
+var _ schema.Retriever = Retriever{}
+
+// ToRetriever returns a retriever invoking the runnable.
+func ToRetriever(r Runnable[string, []schema.Document]) Retriever {
+	return Retriever{Runnable: r}
+}
+
+// GetRelevantDocuments invokes the runnable with the query.
+func (r Retriever) GetRelevantDocuments(ctx context.Context, query string) ([]schema.Document, error) {
+	return r.Runnable.Invoke(ctx, query)
+}
@@ -0,0 +1,18 @@
+// Package runnable provides typed building blocks for composing pipelines.
+//
+// A Runnable turns an input of type In into an output of type Out. Runnables
+// are combined with Pipe, Parallel and Branch, made resilient with WithRetry
+// and WithFallbacks, and run over many inputs with Batch. Since the types of
+// inputs and outputs are checked at compile time, a pipeline like
+//
+//	pipeline := runnable.Pipe(
+//		runnable.Pipe(runnable.FromPrompt(prompt), runnable.FromModel(llm)),
+//		runnable.FromParser(parser),
+//	)
+//
+// only builds if the parser accepts what the model returns.
+//
+// Adapters turn chains, models, prompts, output parsers and retrievers into
+// runnables, and runnables back into chains and retrievers, so pipelines can
+// be used wherever the rest of the library expects them.
+package runnable
@@ -0,0 +1,6 @@
+package runnable
+
+import "errors"
+
+// ErrEmptyResponse is returned if a model responds without any choice.
+var ErrEmptyResponse = errors.New("empty response from model")
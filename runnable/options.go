@@ -0,0 +1,61 @@
+package runnable
+
+import "time"
+
+const _defaultMaxAttempts = 3
+
+// RetryOptions are the options of a runnable created with WithRetry.
+type RetryOptions struct {
+	maxAttempts int
+	delay       time.Duration
+	retryIf     func(err error) bool
+}
+
+// RetryOption is a function type that can be used to modify retries.
+type RetryOption func(*RetryOptions)
+
+func defaultRetryOptions() RetryOptions {
+	return RetryOptions{
+		maxAttempts: _defaultMaxAttempts,
+	}
+}
+
+// WithMaxAttempts is an option for setting the max number of times the
+// runnable is invoked, including the first attempt. Values below 1 mean a
+// single attempt.
+func WithMaxAttempts(attempts int) RetryOption {
+	return func(o *RetryOptions) {
+		o.maxAttempts = attempts
+	}
+}
+
+// WithDelay is an option for setting the time to wait between attempts.
+func WithDelay(delay time.Duration) RetryOption {
+	return func(o *RetryOptions) {
+		o.delay = delay
+	}
+}
+
+// WithRetryIf is an option for setting which errors are retried. By default
+// all errors are.
+func WithRetryIf(retryIf func(err error) bool) RetryOption {
+	return func(o *RetryOptions) {
+		o.retryIf = retryIf
+	}
+}
+
+// BatchOptions are the options of Batch.
+type BatchOptions struct {
+	maxConcurrency int
+}
+
+// BatchOption is a function type that can be used to modify a batch.
+type BatchOption func(*BatchOptions)
+
+// WithMaxConcurrency is an option for setting the max number of inputs run
+// concurrently. By default all inputs are.
+func WithMaxConcurrency(n int) BatchOption {
+	return func(o *BatchOptions) {
+		o.maxConcurrency = n
+	}
+}
@@ -0,0 +1,78 @@
+package runnable
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// WithRetry returns a runnable invoking the runnable again when it fails, up
+// to a maximum number of attempts. The error of the last attempt is returned.
+// The runnable is always invoked at least once.
+func WithRetry[In, Out any](r Runnable[In, Out], opts ...RetryOption) Runnable[In, Out] { //nolint:ireturn
+	options := defaultRetryOptions()
+	for _, opt := range opts {
+		opt(&options)
+	}
+	options.maxAttempts = max(options.maxAttempts, 1)
+
+	return Func[In, Out](func(ctx context.Context, input In) (Out, error) {
+		var (
+			output Out
+			err    error
+		)
+		for attempt := 0; attempt < options.maxAttempts; attempt++ {
+			if attempt > 0 {
+				if waitErr := wait(ctx, options.delay); waitErr != nil {
+					return output, errors.Join(err, waitErr)
+				}
+			}
+
+			output, err = r.Invoke(ctx, input)
+			if err == nil || (options.retryIf != nil && !options.retryIf(err)) {
+				return output, err
+			}
+		}
+		return output, err
+	})
+}
+
+// WithFallbacks returns a runnable invoking the fallbacks in order when the
+// runnable fails, until one of them succeeds. If all fail, the errors are
+// returned joined.
+func WithFallbacks[In, Out any](r Runnable[In, Out], fallbacks ...Runnable[In, Out]) Runnable[In, Out] { //nolint:ireturn
+	return Func[In, Out](func(ctx context.Context, input In) (Out, error) {
+		output, err := r.Invoke(ctx, input)
+		if err == nil {
+			return output, nil
+		}
+
+		errs := []error{err}
+		for _, fallback := range fallbacks {
+			if ctx.Err() != nil {
+				break
+			}
+			output, err = fallback.Invoke(ctx, input)
+			if err == nil {
+				return output, nil
+			}
+			errs = append(errs, err)
+		}
+		return output, errors.Join(errs...)
+	})
+}
+
+func wait(ctx context.Context, delay time.Duration) error {
+	if delay <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,173 @@
+package runnable
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// Runnable turns an input into an output.
+type Runnable[In, Out any] interface {
+	Invoke(ctx context.Context, input In) (Out, error)
+}
+
+// Streamer is a runnable that can emit its output in chunks while it runs.
+type Streamer[In, Out any] interface {
+	Runnable[In, Out]
+	// Stream runs with the input and calls the streaming function for each
+	// chunk of the output.
+	Stream(ctx context.Context, input In, streamingFunc func(ctx context.Context, chunk Out) error) error
+}
+
+// Func is a function used as a runnable.
+type Func[In, Out any] func(ctx context.Context, input In) (Out, error)
+
+var _ Runnable[string, string] = Func[string, string](nil)
+
+// Invoke calls the function.
+func (f Func[In, Out]) Invoke(ctx context.Context, input In) (Out, error) {
+	return f(ctx, input)
+}
+
+// Stream runs the runnable with the input and calls the streaming function
+// for each chunk of the output. Runnables that don't implement Streamer emit
+// their whole output as a single chunk.
+func Stream[In, Out any](
+	ctx context.Context,
+	r Runnable[In, Out],
+	input In,
+	streamingFunc func(ctx context.Context, chunk Out) error,
+) error {
+	if streamer, ok := r.(Streamer[In, Out]); ok {
+		return streamer.Stream(ctx, input, streamingFunc)
+	}
+
+	output, err := r.Invoke(ctx, input)
+	if err != nil {
+		return err
+	}
+	return streamingFunc(ctx, output)
+}
+
+// Batch runs the runnable with each of the inputs concurrently and returns the
+// outputs in the order of the inputs. The first error cancels the other runs
+// and is returned.
+func Batch[In, Out any](ctx context.Context, r Runnable[In, Out], inputs []In, opts ...BatchOption) ([]Out, error) {
+	options := BatchOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	outputs := make([]Out, len(inputs))
+	g, gctx := errgroup.WithContext(ctx)
+	if options.maxConcurrency > 0 {
+		g.SetLimit(options.maxConcurrency)
+	}
+	for i, input := range inputs {
+		g.Go(func() error {
+			output, err := r.Invoke(gctx, input)
+			if err != nil {
+				return fmt.Errorf("input %d: %w", i, err)
+			}
+			outputs[i] = output
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return outputs, nil
+}
+
+type pipe[In, Mid, Out any] struct {
+	first  Runnable[In, Mid]
+	second Runnable[Mid, Out]
+}
+
+// Pipe returns a runnable passing the output of the first runnable to the
+// second one. Streaming the result streams the output of the second runnable.
+func Pipe[In, Mid, Out any](first Runnable[In, Mid], second Runnable[Mid, Out]) Streamer[In, Out] { //nolint:ireturn
+	return pipe[In, Mid, Out]{first: first, second: second}
+}
+
+func (p pipe[In, Mid, Out]) Invoke(ctx context.Context, input In) (Out, error) {
+	mid, err := p.first.Invoke(ctx, input)
+	if err != nil {
+		var out Out
+		return out, err
+	}
+	return p.second.Invoke(ctx, mid)
+}
+
+func (p pipe[In, Mid, Out]) Stream(
+	ctx context.Context,
+	input In,
+	streamingFunc func(ctx context.Context, chunk Out) error,
+) error {
+	mid, err := p.first.Invoke(ctx, input)
+	if err != nil {
+		return err
+	}
+	return Stream(ctx, p.second, mid, streamingFunc)
+}
+
+// Parallel returns a runnable running the branches concurrently on the same
+// input. Its output maps the name of each branch to the output of the branch.
+// The first error cancels the other branches and is returned.
+func Parallel[In, Out any](branches map[string]Runnable[In, Out]) Runnable[In, map[string]Out] { //nolint:ireturn
+	return Func[In, map[string]Out](func(ctx context.Context, input In) (map[string]Out, error) {
+		names := make([]string, 0, len(branches))
+		for name := range branches {
+			names = append(names, name)
+		}
+
+		outputs := make([]Out, len(names))
+		g, gctx := errgroup.WithContext(ctx)
+		for i, name := range names {
+			g.Go(func() error {
+				output, err := branches[name].Invoke(gctx, input)
+				if err != nil {
+					return fmt.Errorf("branch %s: %w", name, err)
+				}
+				outputs[i] = output
+				return nil
+			})
+		}
+		if err := g.Wait(); err != nil {
+			return nil, err
+		}
+
+		result := make(map[string]Out, len(names))
+		for i, name := range names {
+			result[name] = outputs[i]
+		}
+		return result, nil
+	})
+}
+
+// Case is a conditional branch of a runnable created with Branch.
+type Case[In, Out any] struct {
+	// Condition decides whether the input is handled by the runnable.
+	Condition func(ctx context.Context, input In) (bool, error)
+	// Runnable handles the inputs matching the condition.
+	Runnable Runnable[In, Out]
+}
+
+// Branch returns a runnable handing the input to the runnable of the first
+// case whose condition matches, or to the default runnable if none does.
+func Branch[In, Out any](defaultRunnable Runnable[In, Out], cases ...Case[In, Out]) Runnable[In, Out] { //nolint:ireturn
+	return Func[In, Out](func(ctx context.Context, input In) (Out, error) {
+		for i, c := range cases {
+			ok, err := c.Condition(ctx, input)
+			if err != nil {
+				var out Out
+				return out, fmt.Errorf("condition %d: %w", i, err)
+			}
+			if ok {
+				return c.Runnable.Invoke(ctx, input)
+			}
+		}
+		return defaultRunnable.Invoke(ctx, input)
+	})
+}
@@ -0,0 +1,212 @@
+package runnable
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/devmiahub/langchaingo/chains"
+	"github.com/devmiahub/langchaingo/llms/fake"
+	"github.com/devmiahub/langchaingo/outputparser"
+	"github.com/devmiahub/langchaingo/prompts"
+	"github.com/devmiahub/langchaingo/schema"
+	"github.com/stretchr/testify/require"
+)
+
+var errFailed = errors.New("failed")
+
+func upper() Func[string, string] {
+	return func(_ context.Context, input string) (string, error) {
+		return strings.ToUpper(input), nil
+	}
+}
+
+func length() Func[string, int] {
+	return func(_ context.Context, input string) (int, error) {
+		return len(input), nil
+	}
+}
+
+func failing[In, Out any](calls *atomic.Int32) Func[In, Out] {
+	return func(_ context.Context, _ In) (Out, error) {
+		calls.Add(1)
+		var out Out
+		return out, errFailed
+	}
+}
+
+// words streams its input word by word.
+type words struct{}
+
+func (words) Invoke(_ context.Context, input string) (string, error) {
+	return input, nil
+}
+
+func (words) Stream(ctx context.Context, input string, streamingFunc func(ctx context.Context, chunk string) error) error {
+	for _, word := range strings.Fields(input) {
+		if err := streamingFunc(ctx, word); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestPipe(t *testing.T) {
+	t.Parallel()
+
+	output, err := Pipe(upper(), length()).Invoke(context.Background(), "hello")
+	require.NoError(t, err)
+	require.Equal(t, 5, output)
+
+	var chunks []string
+	err = Stream(context.Background(), Pipe(upper(), words{}), "hello world",
+		func(_ context.Context, chunk string) error {
+			chunks = append(chunks, chunk)
+			return nil
+		})
+	require.NoError(t, err)
+	require.Equal(t, []string{"HELLO", "WORLD"}, chunks)
+}
+
+func TestPromptModelParserPipeline(t *testing.T) {
+	t.Parallel()
+
+	prompt := prompts.NewPromptTemplate("List three {{.things}}.", []string{"things"})
+	llm := fake.NewFakeLLM([]string{"red, green, blue"})
+
+	pipeline := Pipe(Pipe(FromPrompt(prompt), FromModel(llm)), FromParser(outputparser.NewCommaSeparatedList()))
+
+	output, err := pipeline.Invoke(context.Background(), map[string]any{"things": "colors"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"red", "green", "blue"}, output)
+}
+
+func TestParallel(t *testing.T) {
+	t.Parallel()
+
+	r := Parallel(map[string]Runnable[string, any]{
+		"upper": Pipe[string, string, any](upper(), Func[string, any](func(_ context.Context, s string) (any, error) {
+			return s, nil
+		})),
+		"length": Func[string, any](func(ctx context.Context, s string) (any, error) {
+			return length().Invoke(ctx, s)
+		}),
+	})
+
+	output, err := r.Invoke(context.Background(), "hello")
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"upper": "HELLO", "length": 5}, output)
+
+	var calls atomic.Int32
+	_, err = Parallel(map[string]Runnable[string, string]{
+		"upper": upper(),
+		"fail":  failing[string, string](&calls),
+	}).Invoke(context.Background(), "hello")
+	require.ErrorIs(t, err, errFailed)
+}
+
+func TestBranch(t *testing.T) {
+	t.Parallel()
+
+	isShort := func(_ context.Context, s string) (bool, error) { return len(s) < 4, nil }
+	r := Branch[string, string](
+		Func[string, string](func(_ context.Context, s string) (string, error) { return s, nil }),
+		Case[string, string]{Condition: isShort, Runnable: upper()},
+	)
+
+	output, err := r.Invoke(context.Background(), "abc")
+	require.NoError(t, err)
+	require.Equal(t, "ABC", output)
+
+	output, err = r.Invoke(context.Background(), "abcd")
+	require.NoError(t, err)
+	require.Equal(t, "abcd", output)
+}
+
+func TestWithRetry(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	_, err := WithRetry[string, string](failing[string, string](&calls), WithMaxAttempts(4)).
+		Invoke(context.Background(), "hello")
+	require.ErrorIs(t, err, errFailed)
+	require.Equal(t, int32(4), calls.Load())
+
+	calls.Store(0)
+	_, err = WithRetry[string, string](failing[string, string](&calls), WithRetryIf(func(error) bool { return false })).
+		Invoke(context.Background(), "hello")
+	require.ErrorIs(t, err, errFailed)
+	require.Equal(t, int32(1), calls.Load())
+
+	for _, attempts := range []int{0, -1} {
+		calls.Store(0)
+		_, err = WithRetry[string, string](failing[string, string](&calls), WithMaxAttempts(attempts)).
+			Invoke(context.Background(), "hello")
+		require.ErrorIs(t, err, errFailed)
+		require.Equal(t, int32(1), calls.Load())
+	}
+
+	output, err := WithRetry[string, string](upper(), WithMaxAttempts(0)).Invoke(context.Background(), "hello")
+	require.NoError(t, err)
+	require.Equal(t, "HELLO", output)
+}
+
+func TestWithFallbacks(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	output, err := WithFallbacks[string, string](failing[string, string](&calls), upper()).
+		Invoke(context.Background(), "hello")
+	require.NoError(t, err)
+	require.Equal(t, "HELLO", output)
+
+	_, err = WithFallbacks[string, string](failing[string, string](&calls), failing[string, string](&calls)).
+		Invoke(context.Background(), "hello")
+	require.ErrorIs(t, err, errFailed)
+	require.Equal(t, int32(3), calls.Load())
+}
+
+func TestBatch(t *testing.T) {
+	t.Parallel()
+
+	outputs, err := Batch[string, int](context.Background(), length(), []string{"a", "bb", "ccc"}, WithMaxConcurrency(2))
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2, 3}, outputs)
+}
+
+type staticRetriever []schema.Document
+
+func (r staticRetriever) GetRelevantDocuments(_ context.Context, _ string) ([]schema.Document, error) {
+	return r, nil
+}
+
+func TestChainAndRetrieverAdapters(t *testing.T) {
+	t.Parallel()
+
+	documents := staticRetriever{{PageContent: "foo"}, {PageContent: "bar"}}
+	joinDocuments := Func[[]schema.Document, map[string]any](
+		func(_ context.Context, docs []schema.Document) (map[string]any, error) {
+			contents := make([]string, 0, len(docs))
+			for _, doc := range docs {
+				contents = append(contents, doc.PageContent)
+			}
+			return map[string]any{"text": strings.Join(contents, " ")}, nil
+		})
+	query := Func[map[string]any, string](func(_ context.Context, inputs map[string]any) (string, error) {
+		return inputs["query"].(string), nil
+	})
+
+	chain := ToChain(
+		Pipe(Pipe(query, FromRetriever(ToRetriever(FromRetriever(documents)))), joinDocuments),
+		[]string{"query"}, []string{"text"},
+	)
+
+	output, err := FromChain(chain).Invoke(context.Background(), map[string]any{"query": "q"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"text": "foo bar"}, output)
+
+	_, err = FromChain(chain).Invoke(context.Background(), map[string]any{})
+	require.ErrorIs(t, err, chains.ErrInvalidInputValues)
+}
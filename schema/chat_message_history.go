@@ -28,3 +28,21 @@ type ChatMessageHistory interface {
 	// SetMessages replaces existing messages in the store
 	SetMessages(ctx context.Context, messages []llms.ChatMessage) error
 }
+
+// MessageContentHistory is the interface for chat history storing the full
+// content of messages, including multi-modal parts, tool calls with their IDs,
+// thinking signatures and cache control, so that complete agent conversations
+// round-trip without loss.
+type MessageContentHistory interface {
+	// AddMessageContent adds a message to the store.
+	AddMessageContent(ctx context.Context, message llms.MessageContent) error
+
+	// MessageContents retrieves all messages from the store.
+	MessageContents(ctx context.Context) ([]llms.MessageContent, error)
+
+	// SetMessageContents replaces existing messages in the store.
+	SetMessageContents(ctx context.Context, messages []llms.MessageContent) error
+
+	// Clear removes all messages from the store.
+	Clear(ctx context.Context) error
+}
@@ -1,6 +1,10 @@
 package tools
 
-import "context"
+import (
+	"context"
+
+	"github.com/devmiahub/langchaingo/jsonschema"
+)
 
 // Tool is a tool for the llm agent to interact with different applications.
 type Tool interface {
@@ -8,3 +12,11 @@ type Tool interface {
 	Description() string
 	Call(ctx context.Context, input string) (string, error)
 }
+
+// SchemaTool is a tool that takes a JSON object as input. Agents that emit
+// structured actions validate the input against the schema before calling the
+// tool with the JSON encoded object.
+type SchemaTool interface {
+	Tool
+	Schema() jsonschema.Definition
+}